@@ -74,6 +74,8 @@ type DingoAdm struct {
 	clusterTopologyData string // cluster topology
 	clusterPoolData     string // cluster pool
 	monitor             storage.Monitor
+
+	sshClientPool *module.SSHClientPool // keep ssh clients open if not nil
 }
 
 /*
@@ -306,6 +308,13 @@ func (dingoadm *DingoAdm) ClusterName() string               { return dingoadm.c
 func (dingoadm *DingoAdm) ClusterTopologyData() string       { return dingoadm.clusterTopologyData }
 func (dingoadm *DingoAdm) ClusterPoolData() string           { return dingoadm.clusterPoolData }
 func (dingoadm *DingoAdm) Monitor() storage.Monitor          { return dingoadm.monitor }
+func (dingoadm *DingoAdm) SSHClientPool() *module.SSHClientPool {
+	return dingoadm.sshClientPool
+}
+
+func (dingoadm *DingoAdm) SetSSHClientPool(pool *module.SSHClientPool) {
+	dingoadm.sshClientPool = pool
+}
 
 func (dingoadm *DingoAdm) GetHost(host string) (*hosts.HostConfig, error) {
 	if len(dingoadm.Hosts()) == 0 {
@@ -334,6 +343,8 @@ func (dingoadm *DingoAdm) ParseTopologyData(data string) ([]*topology.DeployConf
 	}
 	for _, hc := range hcs {
 		ctx.Add(hc.GetHost(), hc.GetHostname())
+		ctx.AddLabels(hc.GetHost(), hc.GetLabels())
+		ctx.AddLocation(hc.GetHost(), hc.GetZone(), hc.GetRack())
 	}
 
 	dcs, err := topology.ParseTopology(data, ctx)
@@ -389,7 +400,12 @@ func (dingoadm *DingoAdm) FilterDeployConfigByRole(dcs []*topology.DeployConfig,
 }
 
 func (dingoadm *DingoAdm) GetServiceId(dcId string) string {
-	serviceId := fmt.Sprintf("%s_%s", dingoadm.ClusterUUId(), dcId)
+	return GenServiceId(dingoadm.ClusterUUId(), dcId)
+}
+
+// GenServiceId returns the service id of deploy config in cluster which specified by uuid
+func GenServiceId(clusterUUId, dcId string) string {
+	serviceId := fmt.Sprintf("%s_%s", clusterUUId, dcId)
 	return utils.MD5Sum(serviceId)[:12]
 }
 
@@ -518,6 +534,8 @@ func (dingoadm *DingoAdm) DiffTopology(data1, data2 string) ([]topology.Topology
 	}
 	for _, hc := range hcs {
 		ctx.Add(hc.GetHost(), hc.GetHostname())
+		ctx.AddLabels(hc.GetHost(), hc.GetLabels())
+		ctx.AddLocation(hc.GetHost(), hc.GetZone(), hc.GetRack())
 	}
 
 	if len(data1) == 0 {
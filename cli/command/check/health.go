@@ -0,0 +1,174 @@
+/*
+ *  Copyright (c) 2025 dingodb.com Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: dingoadm
+ * Author: dongwei (jackblack369)
+ */
+
+package check
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/dingodb/dingoadm/cli/cli"
+	comm "github.com/dingodb/dingoadm/internal/common"
+	"github.com/dingodb/dingoadm/internal/configure/topology"
+	"github.com/dingodb/dingoadm/internal/errno"
+	"github.com/dingodb/dingoadm/internal/playbook"
+	task "github.com/dingodb/dingoadm/internal/task/task/common"
+	"github.com/dingodb/dingoadm/internal/tui/output"
+	tui "github.com/dingodb/dingoadm/internal/tui/service"
+	cliutil "github.com/dingodb/dingoadm/internal/utils"
+	"github.com/spf13/cobra"
+)
+
+const (
+	HEALTH_EXAMPLE = `Examples:
+  $ dingoadm check health                     # Check health of all services
+  $ dingoadm check health --role store        # Check health of stores
+  $ dingoadm check health --format json       # Output checks in JSON format`
+)
+
+type healthOptions struct {
+	id     string
+	role   string
+	host   string
+	format string
+}
+
+func NewHealthCommand(dingoadm *cli.DingoAdm) *cobra.Command {
+	var options healthOptions
+
+	cmd := &cobra.Command{
+		Use:     "health [OPTIONS]",
+		Short:   "Check health of services by role-specific probes",
+		Args:    cliutil.NoArgs,
+		Example: HEALTH_EXAMPLE,
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			return output.CheckFormat(options.format)
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runHealth(dingoadm, options)
+		},
+		DisableFlagsInUseLine: true,
+	}
+
+	flags := cmd.Flags()
+	flags.StringVar(&options.id, "id", "*", "Specify service id")
+	flags.StringVar(&options.role, "role", "*", "Specify service role")
+	flags.StringVar(&options.host, "host", "*", "Specify service host")
+	flags.StringVar(&options.format, "format", output.FORMAT_TABLE, output.FLAG_FORMAT_USAGE)
+
+	return cmd
+}
+
+func genHealthPlaybook(dingoadm *cli.DingoAdm,
+	dcs []*topology.DeployConfig,
+	options healthOptions) (*playbook.Playbook, error) {
+	services := dingoadm.FilterDeployConfig(dcs, topology.FilterOption{
+		Id:   options.id,
+		Role: options.role,
+		Host: options.host,
+	})
+	if len(services) == 0 {
+		return nil, errno.ERR_NO_SERVICES_MATCHED
+	}
+
+	steps := []int{playbook.CHECK_SERVICE_HEALTH}
+	if len(dingoadm.FilterDeployConfigByRole(services, topology.ROLE_FS_MDS)) > 0 &&
+		services[0].GetCtx().Lookup(topology.CTX_KEY_MDS_VERSION) == topology.CTX_VAL_MDS_V2 {
+		steps = append(steps, playbook.CHECK_MDSV2_HEALTH)
+	}
+
+	pb := playbook.NewPlaybook(dingoadm)
+	for _, step := range steps {
+		pb.AddStep(&playbook.PlaybookStep{
+			Type:    step,
+			Configs: services,
+			Options: map[string]interface{}{
+				comm.KEY_ALL_DEPLOY_CONFIGS: dcs,
+			},
+			ExecOptions: playbook.ExecOptions{
+				SilentSubBar:  true,
+				SilentMainBar: output.IsStructured(options.format),
+				SkipError:     true,
+			},
+		})
+	}
+	return pb, nil
+}
+
+func getHealthChecks(dingoadm *cli.DingoAdm) []task.HealthCheck {
+	checks := []task.HealthCheck{}
+	value := dingoadm.MemStorage().Get(comm.KEY_ALL_HEALTH_CHECKS)
+	if value != nil {
+		for _, items := range value.(map[string][]task.HealthCheck) {
+			checks = append(checks, items...)
+		}
+	}
+	tui.SortHealthChecks(checks)
+	return checks
+}
+
+func checkHealthChecks(checks []task.HealthCheck) error {
+	failed := []string{}
+	for _, check := range checks {
+		if check.Result == task.HEALTH_FAIL && !cliutil.Contains(failed, check.Id) {
+			failed = append(failed, check.Id)
+		}
+	}
+	if len(failed) > 0 {
+		sort.Strings(failed)
+		return errno.ERR_HEALTH_CHECK_FAILED.F("services: %s", strings.Join(failed, ","))
+	}
+	return nil
+}
+
+func runHealth(dingoadm *cli.DingoAdm, options healthOptions) error {
+	// 1) parse cluster topology
+	dcs, err := dingoadm.ParseTopology()
+	if err != nil {
+		return err
+	}
+
+	// 2) generate health playbook
+	pb, err := genHealthPlaybook(dingoadm, dcs, options)
+	if err != nil {
+		return err
+	}
+
+	// 3) run playground, the failed probe is recorded as FAIL
+	runErr := pb.Run()
+
+	// 4) display health checks
+	checks := getHealthChecks(dingoadm)
+	if output.IsStructured(options.format) {
+		out, err := output.Marshal(options.format, checks)
+		if err != nil {
+			return err
+		}
+		dingoadm.WriteOut("%s", out)
+	} else {
+		dingoadm.WriteOutln("")
+		dingoadm.WriteOut("%s", tui.FormatHealthChecks(checks))
+	}
+	if runErr != nil {
+		return runErr
+	}
+	return checkHealthChecks(checks)
+}
@@ -27,6 +27,9 @@
 package client
 
 import (
+	"sort"
+	"strings"
+
 	"github.com/dingodb/dingoadm/cli/cli"
 	comm "github.com/dingodb/dingoadm/internal/common"
 	"github.com/dingodb/dingoadm/internal/errno"
@@ -34,6 +37,7 @@ import (
 	"github.com/dingodb/dingoadm/internal/storage"
 	task "github.com/dingodb/dingoadm/internal/task/task/common"
 	tui "github.com/dingodb/dingoadm/internal/tui/client"
+	"github.com/dingodb/dingoadm/internal/tui/output"
 	cliutil "github.com/dingodb/dingoadm/internal/utils"
 	"github.com/spf13/cobra"
 )
@@ -47,6 +51,7 @@ var (
 
 type statusOptions struct {
 	verbose bool
+	format  string
 }
 
 func NewStatusCommand(curveadm *cli.DingoAdm) *cobra.Command {
@@ -56,6 +61,9 @@ func NewStatusCommand(curveadm *cli.DingoAdm) *cobra.Command {
 		Use:   "status [OPTIONS]",
 		Short: "Display client status",
 		Args:  cliutil.NoArgs,
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			return output.CheckFormat(options.format)
+		},
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return runStatus(curveadm, options)
 		},
@@ -64,6 +72,7 @@ func NewStatusCommand(curveadm *cli.DingoAdm) *cobra.Command {
 
 	flags := cmd.Flags()
 	flags.BoolVarP(&options.verbose, "verbose", "v", false, "Verbose output for status")
+	flags.StringVar(&options.format, "format", output.FORMAT_TABLE, output.FLAG_FORMAT_USAGE)
 
 	return cmd
 }
@@ -87,7 +96,7 @@ func genStatusPlaybook(curveadm *cli.DingoAdm,
 			},
 			ExecOptions: playbook.ExecOptions{
 				SilentSubBar:  true,
-				SilentMainBar: step == playbook.INIT_CLIENT_STATUS,
+				SilentMainBar: step == playbook.INIT_CLIENT_STATUS || output.IsStructured(options.format),
 				SkipError:     true,
 			},
 		})
@@ -95,7 +104,7 @@ func genStatusPlaybook(curveadm *cli.DingoAdm,
 	return pb, nil
 }
 
-func displayStatus(curveadm *cli.DingoAdm, clients []storage.Client, options statusOptions) {
+func getClientStatuses(curveadm *cli.DingoAdm) []task.ClientStatus {
 	statuses := []task.ClientStatus{}
 	v := curveadm.MemStorage().Get(comm.KEY_ALL_CLIENT_STATUS)
 	if v != nil {
@@ -104,12 +113,45 @@ func displayStatus(curveadm *cli.DingoAdm, clients []storage.Client, options sta
 			statuses = append(statuses, status)
 		}
 	}
+	return statuses
+}
 
-	output := tui.FormatStatus(statuses, options.verbose)
+func checkClientStatuses(statuses []task.ClientStatus) error {
+	unhealthy := []string{}
+	for _, status := range statuses {
+		if !strings.HasPrefix(status.Status, "Up") {
+			unhealthy = append(unhealthy, status.Id)
+		}
+	}
+	if len(unhealthy) > 0 {
+		sort.Strings(unhealthy)
+		return errno.ERR_UNHEALTHY_CLIENTS_FOUND.F("clients: %s", strings.Join(unhealthy, ","))
+	}
+	return nil
+}
+
+func displayStatus(curveadm *cli.DingoAdm,
+	clients []storage.Client,
+	statuses []task.ClientStatus,
+	options statusOptions) error {
+	if output.IsStructured(options.format) {
+		sort.Slice(statuses, func(i, j int) bool {
+			return statuses[i].Id < statuses[j].Id
+		})
+		out, err := output.Marshal(options.format, statuses)
+		if err != nil {
+			return err
+		}
+		curveadm.WriteOut("%s", out)
+		return nil
+	}
+
+	out := tui.FormatStatus(statuses, options.verbose)
 	if len(clients) > 0 {
 		curveadm.WriteOutln("")
 	}
-	curveadm.WriteOut(output)
+	curveadm.WriteOut(out)
+	return nil
 }
 
 func runStatus(dingoadm *cli.DingoAdm, options statusOptions) error {
@@ -128,7 +170,18 @@ func runStatus(dingoadm *cli.DingoAdm, options statusOptions) error {
 	// 3) run playground
 	err = pb.Run()
 
-	// 4) display service status
-	displayStatus(dingoadm, clients, options)
-	return err
+	// 4) display client status
+	statuses := getClientStatuses(dingoadm)
+	if derr := displayStatus(dingoadm, clients, statuses, options); derr != nil {
+		return derr
+	} else if err != nil {
+		return err
+	}
+
+	// 5) exit with error if any client not running, only for structured
+	//    output which is consumed by automation
+	if !output.IsStructured(options.format) {
+		return nil
+	}
+	return checkClientStatuses(statuses)
 }
@@ -0,0 +1,248 @@
+/*
+ *  Copyright (c) 2025 dingodb.com Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: dingoadm
+ * Author: dongwei (jackblack369)
+ */
+
+package cluster
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/dingodb/dingoadm/cli/cli"
+	comm "github.com/dingodb/dingoadm/internal/common"
+	"github.com/dingodb/dingoadm/internal/configure/topology"
+	"github.com/dingodb/dingoadm/internal/errno"
+	"github.com/dingodb/dingoadm/internal/playbook"
+	task "github.com/dingodb/dingoadm/internal/task/task/common"
+	tui "github.com/dingodb/dingoadm/internal/tui/common"
+	"github.com/dingodb/dingoadm/internal/utils"
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+const (
+	ADOPT_EXAMPLE = `Examples:
+  $ dingoadm cluster adopt -f /path/to/topology.yaml  # Adopt running containers of the topology into current cluster`
+)
+
+var (
+	ADOPT_PLAYBOOK_STEPS = []int{
+		playbook.SCAN_CONTAINERS,
+	}
+)
+
+type adoptOptions struct {
+	filename string
+	force    bool
+}
+
+func NewAdoptCommand(dingoadm *cli.DingoAdm) *cobra.Command {
+	var options adoptOptions
+
+	cmd := &cobra.Command{
+		Use:     "adopt [OPTIONS]",
+		Short:   "Adopt existing service containers into current cluster",
+		Args:    utils.NoArgs,
+		Example: ADOPT_EXAMPLE,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runAdopt(dingoadm, options)
+		},
+		DisableFlagsInUseLine: true,
+	}
+
+	flags := cmd.Flags()
+	flags.StringVarP(&options.filename, "topology", "f", "", "Specify the path of topology file")
+	cmd.MarkFlagRequired("topology")
+	flags.BoolVar(&options.force, "force", false, "Adopt without confirmation when cluster already has services")
+
+	return cmd
+}
+
+func genAdoptPlaybook(dingoadm *cli.DingoAdm, dcs []*topology.DeployConfig) (*playbook.Playbook, error) {
+	steps := ADOPT_PLAYBOOK_STEPS
+	pb := playbook.NewPlaybook(dingoadm)
+	for _, step := range steps {
+		pb.AddStep(&playbook.PlaybookStep{
+			Type:    step,
+			Configs: dcs,
+			ExecOptions: playbook.ExecOptions{
+				SilentSubBar: true,
+			},
+		})
+	}
+	return pb, nil
+}
+
+// match labeled containers with deploy configs, return the cluster uuid
+// which containers belong to and the mapping of service id to container id
+func matchContainers(dcs []*topology.DeployConfig,
+	containers []task.LabeledContainer) (string, map[string]string, error) {
+	uuids := map[string]map[string]string{}
+	for _, container := range containers {
+		for _, dc := range dcs {
+			if cli.GenServiceId(container.ClusterUUId, dc.GetId()) != container.ServiceId {
+				continue
+			}
+			if _, ok := uuids[container.ClusterUUId]; !ok {
+				uuids[container.ClusterUUId] = map[string]string{}
+			}
+			uuids[container.ClusterUUId][container.ServiceId] = container.ContainerId
+		}
+	}
+
+	if len(uuids) == 0 {
+		return "", nil, errno.ERR_NO_LABELED_CONTAINERS_FOUND
+	} else if len(uuids) > 1 {
+		keys := []string{}
+		for uuid := range uuids {
+			keys = append(keys, uuid)
+		}
+		sort.Strings(keys)
+		return "", nil, errno.ERR_MULTIPLE_CLUSTERS_LABELED.
+			F("cluster uuids: %s", strings.Join(keys, ", "))
+	}
+
+	for uuid, services := range uuids {
+		return uuid, services, nil
+	}
+	return "", nil, nil
+}
+
+/*
+ * the service id is derived from cluster uuid, so replacing uuid of cluster which
+ * already has services makes all of them invalid, we refuse it; adopting into
+ * cluster with same uuid only replaces container ids, which requires confirmation
+ */
+func checkAdoptable(uuid, clusterUUId string, services int) (bool, error) {
+	if services == 0 {
+		return false, nil
+	} else if uuid != clusterUUId {
+		return false, errno.ERR_CLUSTER_HAS_OTHER_SERVICES.
+			F("cluster uuid: %s, containers cluster uuid: %s", clusterUUId, uuid)
+	}
+	return true, nil
+}
+
+func adoptContainers(dingoadm *cli.DingoAdm, uuid string, services map[string]string, data string) error {
+	storage := dingoadm.Storage()
+	clusterId := dingoadm.ClusterId()
+	if uuid != dingoadm.ClusterUUId() {
+		err := storage.SetClusterUUId(clusterId, uuid)
+		if err != nil {
+			return errno.ERR_UPDATE_CLUSTER_UUID_FAILED.E(err)
+		}
+	}
+
+	for serviceId, containerId := range services {
+		oldContainerId, err := storage.GetContainerId(serviceId)
+		if err != nil {
+			return errno.ERR_GET_SERVICE_CONTAINER_ID_FAILED.E(err)
+		} else if len(oldContainerId) > 0 {
+			err = storage.SetContainId(serviceId, containerId)
+			if err != nil {
+				return errno.ERR_SET_SERVICE_CONTAINER_ID_FAILED.E(err)
+			}
+		} else {
+			err = storage.InsertService(clusterId, serviceId, containerId)
+			if err != nil {
+				return errno.ERR_INSERT_SERVICE_CONTAINER_ID_FAILED.E(err)
+			}
+		}
+	}
+
+	err := storage.SetClusterTopology(clusterId, data)
+	if err != nil {
+		return errno.ERR_UPDATE_CLUSTER_TOPOLOGY_FAILED.E(err)
+	}
+	return nil
+}
+
+func runAdopt(dingoadm *cli.DingoAdm, options adoptOptions) error {
+	// 1) check current cluster
+	if dingoadm.ClusterId() == -1 {
+		return errno.ERR_NO_CLUSTER_SPECIFIED
+	}
+
+	// 2) read topology and parse it
+	data, err := readTopology(options.filename)
+	if err != nil {
+		return err
+	}
+	dcs, err := dingoadm.ParseTopologyData(data)
+	if err != nil {
+		return err
+	}
+
+	// 3) scan labeled containers on all hosts
+	pb, err := genAdoptPlaybook(dingoadm, dcs)
+	if err != nil {
+		return err
+	}
+	err = pb.Run()
+	if err != nil {
+		return err
+	}
+
+	// 4) match containers with deploy configs
+	containers := []task.LabeledContainer{}
+	if v := dingoadm.MemStorage().Get(comm.KEY_ALL_LABELED_CONTAINERS); v != nil {
+		containers = v.([]task.LabeledContainer)
+	}
+	uuid, services, err := matchContainers(dcs, containers)
+	if err != nil {
+		return err
+	}
+
+	// 5) check services which already in cluster
+	existed, err := dingoadm.Storage().GetServices(dingoadm.ClusterId())
+	if err != nil {
+		return errno.ERR_GET_ALL_SERVICES_CONTAINER_ID_FAILED.E(err)
+	}
+	confirm, err := checkAdoptable(uuid, dingoadm.ClusterUUId(), len(existed))
+	if err != nil {
+		return err
+	} else if confirm && !options.force &&
+		!tui.ConfirmYes(tui.PromptAdoptCluster(dingoadm.ClusterName(), len(existed))) {
+		dingoadm.WriteOutln(tui.PromptCancelOpetation("adopt"))
+		return nil
+	}
+
+	// 6) repopulate containers table and commit topology
+	err = adoptContainers(dingoadm, uuid, services, data)
+	if err != nil {
+		return err
+	}
+
+	// 7) print result
+	dingoadm.WriteOutln("")
+	for _, dc := range dcs {
+		serviceId := cli.GenServiceId(uuid, dc.GetId())
+		if containerId, ok := services[serviceId]; ok {
+			dingoadm.WriteOutln("  + %s  host=%s  role=%s  containerId=%s",
+				serviceId, dc.GetHost(), dc.GetRole(), containerId)
+		} else {
+			dingoadm.WriteOutln(color.YellowString("  - %s  host=%s  role=%s  (container not found)",
+				serviceId, dc.GetHost(), dc.GetRole()))
+		}
+	}
+	dingoadm.WriteOutln(color.GreenString("Adopted %d/%d services into cluster '%s'",
+		len(services), len(dcs), dingoadm.ClusterName()))
+	return nil
+}
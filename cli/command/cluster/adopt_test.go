@@ -0,0 +1,65 @@
+package cluster
+
+import (
+	"testing"
+
+	"github.com/dingodb/dingoadm/cli/cli"
+	"github.com/dingodb/dingoadm/internal/configure/topology"
+	"github.com/dingodb/dingoadm/internal/errno"
+	task "github.com/dingodb/dingoadm/internal/task/task/common"
+	"github.com/stretchr/testify/assert"
+)
+
+func newDeployConfigs(t *testing.T) []*topology.DeployConfig {
+	dcs := []*topology.DeployConfig{}
+	for i, host := range []string{"server-1", "server-2"} {
+		dc, err := topology.NewDeployConfig(nil, topology.KIND_DINGOSTORE, topology.ROLE_STORE,
+			host, "store", 1, i, 0, map[string]interface{}{})
+		assert.Nil(t, err)
+		dcs = append(dcs, dc)
+	}
+	return dcs
+}
+
+func TestMatchContainers(t *testing.T) {
+	assert := assert.New(t)
+	dcs := newDeployConfigs(t)
+	containers := []task.LabeledContainer{
+		{ContainerId: "c1", ClusterUUId: "uuid-1", ServiceId: cli.GenServiceId("uuid-1", dcs[0].GetId())},
+		{ContainerId: "c2", ClusterUUId: "uuid-1", ServiceId: cli.GenServiceId("uuid-1", dcs[1].GetId())},
+		{ContainerId: "c3", ClusterUUId: "uuid-1", ServiceId: "unknown"},
+	}
+
+	uuid, services, err := matchContainers(dcs, containers)
+	assert.Nil(err)
+	assert.Equal("uuid-1", uuid)
+	assert.Equal(map[string]string{
+		cli.GenServiceId("uuid-1", dcs[0].GetId()): "c1",
+		cli.GenServiceId("uuid-1", dcs[1].GetId()): "c2",
+	}, services)
+
+	// containers of multiple clusters
+	containers = append(containers, task.LabeledContainer{
+		ContainerId: "c4", ClusterUUId: "uuid-2", ServiceId: cli.GenServiceId("uuid-2", dcs[0].GetId()),
+	})
+	_, _, err = matchContainers(dcs, containers)
+	assert.Equal(errno.ERR_MULTIPLE_CLUSTERS_LABELED.GetCode(), err.(*errno.ErrorCode).GetCode())
+
+	_, _, err = matchContainers(dcs, []task.LabeledContainer{})
+	assert.Equal(errno.ERR_NO_LABELED_CONTAINERS_FOUND, err)
+}
+
+func TestCheckAdoptable(t *testing.T) {
+	assert := assert.New(t)
+
+	confirm, err := checkAdoptable("uuid-1", "uuid-2", 0)
+	assert.Nil(err)
+	assert.False(confirm)
+
+	confirm, err = checkAdoptable("uuid-1", "uuid-1", 3)
+	assert.Nil(err)
+	assert.True(confirm)
+
+	_, err = checkAdoptable("uuid-1", "uuid-2", 3)
+	assert.Equal(errno.ERR_CLUSTER_HAS_OTHER_SERVICES.GetCode(), err.(*errno.ErrorCode).GetCode())
+}
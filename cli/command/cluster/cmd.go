@@ -50,6 +50,7 @@ func NewClusterCommand(dingoadm *cli.DingoAdm) *cobra.Command {
 		// TODO(P1): enable export
 		//NewExportCommand(curveadm),
 		NewImportCommand(dingoadm),
+		NewAdoptCommand(dingoadm),
 		NewRenameCommand(dingoadm),
 	)
 	return cmd
@@ -31,7 +31,9 @@ package cluster
 import (
 	"github.com/dingodb/dingoadm/cli/cli"
 	"github.com/dingodb/dingoadm/internal/errno"
+	"github.com/dingodb/dingoadm/internal/storage"
 	"github.com/dingodb/dingoadm/internal/tui"
+	"github.com/dingodb/dingoadm/internal/tui/output"
 	cliutil "github.com/dingodb/dingoadm/internal/utils"
 	log "github.com/dingodb/dingoadm/pkg/log/glg"
 	"github.com/spf13/cobra"
@@ -39,6 +41,7 @@ import (
 
 type listOptions struct {
 	verbose bool
+	format  string
 }
 
 func NewListCommand(dingoadm *cli.DingoAdm) *cobra.Command {
@@ -49,6 +52,9 @@ func NewListCommand(dingoadm *cli.DingoAdm) *cobra.Command {
 		Aliases: []string{"list"},
 		Short:   "List clusters",
 		Args:    cliutil.NoArgs,
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			return output.CheckFormat(options.format)
+		},
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return runList(dingoadm, options)
 		},
@@ -57,10 +63,27 @@ func NewListCommand(dingoadm *cli.DingoAdm) *cobra.Command {
 
 	flags := cmd.Flags()
 	flags.BoolVarP(&options.verbose, "verbose", "v", false, "Verbose output for clusters")
+	flags.StringVar(&options.format, "format", output.FORMAT_TABLE, output.FLAG_FORMAT_USAGE)
 
 	return cmd
 }
 
+// topology and pool are only emitted in verbose mode, they are too long
+func displayStructuredClusters(dingoadm *cli.DingoAdm, clusters []storage.Cluster, options listOptions) error {
+	if !options.verbose {
+		for i := range clusters {
+			clusters[i].Topology = ""
+			clusters[i].Pool = ""
+		}
+	}
+	out, err := output.Marshal(options.format, clusters)
+	if err != nil {
+		return err
+	}
+	dingoadm.WriteOut("%s", out)
+	return nil
+}
+
 func runList(dingoadm *cli.DingoAdm, options listOptions) error {
 	// 1) get all clusters
 	storage := dingoadm.Storage()
@@ -72,7 +95,10 @@ func runList(dingoadm *cli.DingoAdm, options listOptions) error {
 	}
 
 	// 2) display clusters
-	output := tui.FormatClusters(clusters, options.verbose)
-	dingoadm.WriteOut(output)
+	if output.IsStructured(options.format) {
+		return displayStructuredClusters(dingoadm, clusters, options)
+	}
+	out := tui.FormatClusters(clusters, options.verbose)
+	dingoadm.WriteOut(out)
 	return nil
 }
@@ -34,13 +34,18 @@ import (
 	"github.com/dingodb/dingoadm/cli/command/gateway"
 
 	"github.com/dingodb/dingoadm/cli/cli"
+	"github.com/dingodb/dingoadm/cli/command/check"
 	"github.com/dingodb/dingoadm/cli/command/client"
 	"github.com/dingodb/dingoadm/cli/command/cluster"
 	"github.com/dingodb/dingoadm/cli/command/config"
+	"github.com/dingodb/dingoadm/cli/command/cores"
+	"github.com/dingodb/dingoadm/cli/command/flags"
 	"github.com/dingodb/dingoadm/cli/command/hosts"
+	"github.com/dingodb/dingoadm/cli/command/leader"
 	"github.com/dingodb/dingoadm/cli/command/monitor"
 	"github.com/dingodb/dingoadm/cli/command/pfs"
 	"github.com/dingodb/dingoadm/cli/command/playground"
+	"github.com/dingodb/dingoadm/cli/command/store"
 	"github.com/dingodb/dingoadm/cli/command/target"
 	"github.com/dingodb/dingoadm/internal/errno"
 	tools "github.com/dingodb/dingoadm/internal/tools/upgrade"
@@ -73,24 +78,34 @@ func addSubCommands(cmd *cobra.Command, dingoadm *cli.DingoAdm) {
 		pfs.NewPFSCommand(dingoadm),               // dingoadm pfs ...
 		monitor.NewMonitorCommand(dingoadm),       // dingoadm monitor ...
 		gateway.NewGatewayCommand(dingoadm),       // dingoadm gateway ...
-
-		NewAuditCommand(dingoadm),      // dingoadm audit
-		NewCleanCommand(dingoadm),      // dingoadm clean
-		NewCompletionCommand(dingoadm), // dingoadm completion
-		NewDeployCommand(dingoadm),     // dingoadm deploy
-		NewEnterCommand(dingoadm),      // dingoadm enter
-		NewExecCommand(dingoadm),       // dingoadm exec
-		NewFormatCommand(dingoadm),     // dingoadm format
-		NewMigrateCommand(dingoadm),    // dingoadm migrate
-		NewPrecheckCommand(dingoadm),   // dingoadm precheck
-		NewReloadCommand(dingoadm),     // dingoadm reload
-		NewRestartCommand(dingoadm),    // dingoadm restart
-		NewScaleOutCommand(dingoadm),   // dingoadm scale-out
-		NewStartCommand(dingoadm),      // dingoadm start
-		NewStatusCommand(dingoadm),     // dingoadm status
-		NewStopCommand(dingoadm),       // dingoadm stop
-		NewSupportCommand(dingoadm),    // dingoadm support
-		NewUpgradeCommand(dingoadm),    // dingoadm upgrade
+		leader.NewLeaderCommand(dingoadm),         // dingoadm leader ...
+		check.NewCheckCommand(dingoadm),           // dingoadm check ...
+		store.NewStoreCommand(dingoadm),           // dingoadm store ...
+		cores.NewCoresCommand(dingoadm),           // dingoadm cores ...
+		flags.NewFlagsCommand(dingoadm),           // dingoadm flags ...
+
+		NewAuditCommand(dingoadm),       // dingoadm audit
+		NewCleanCommand(dingoadm),       // dingoadm clean
+		NewCompletionCommand(dingoadm),  // dingoadm completion
+		NewDeployCommand(dingoadm),      // dingoadm deploy
+		NewDriftCommand(dingoadm),       // dingoadm drift
+		NewEnterCommand(dingoadm),       // dingoadm enter
+		NewExecCommand(dingoadm),        // dingoadm exec
+		NewFormatCommand(dingoadm),      // dingoadm format
+		NewLogsCommand(dingoadm),        // dingoadm logs
+		NewMigrateCommand(dingoadm),     // dingoadm migrate
+		NewPrecheckCommand(dingoadm),    // dingoadm precheck
+		NewProfileCommand(dingoadm),     // dingoadm profile
+		NewReloadCommand(dingoadm),      // dingoadm reload
+		NewReplaceHostCommand(dingoadm), // dingoadm replace-host
+		NewRestartCommand(dingoadm),     // dingoadm restart
+		NewScaleInCommand(dingoadm),     // dingoadm scale-in
+		NewScaleOutCommand(dingoadm),    // dingoadm scale-out
+		NewStartCommand(dingoadm),       // dingoadm start
+		NewStatusCommand(dingoadm),      // dingoadm status
+		NewStopCommand(dingoadm),        // dingoadm stop
+		NewSupportCommand(dingoadm),     // dingoadm support
+		NewUpgradeCommand(dingoadm),     // dingoadm upgrade
 		// commonly used shorthands
 		hosts.NewSSHCommand(dingoadm),      // dingoadm ssh
 		hosts.NewPlaybookCommand(dingoadm), // dingoadm playbook
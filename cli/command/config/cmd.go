@@ -46,6 +46,8 @@ func NewConfigCommand(dingoadm *cli.DingoAdm) *cobra.Command {
 		NewShowCommand(dingoadm),
 		NewDiffCommand(dingoadm),
 		NewCommitCommand(dingoadm),
+		NewPortsCommand(dingoadm),
+		NewSetCommand(dingoadm),
 	)
 	return cmd
 }
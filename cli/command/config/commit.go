@@ -101,7 +101,7 @@ func checkDiff(dingoadm *cli.DingoAdm, newData string) error {
 		case topology.DIFF_DELETE:
 			//return errno.ERR_DELETE_SERVICE_WHILE_COMMIT_TOPOLOGY_IS_DENIED.
 			//	F("delete service: %s.host[%s]", dc.GetRole(), dc.GetHost())
-			fmt.Printf("Warning: delete service: %s.host[%s]\n", dc.GetRole(), dc.GetHost())
+			fmt.Printf("Warning: delete service: %s.host[%s], please use `dingoadm scale-in` to remove it\n", dc.GetRole(), dc.GetHost())
 		case topology.DIFF_ADD:
 			//return errno.ERR_ADD_SERVICE_WHILE_COMMIT_TOPOLOGY_IS_DENIED.
 			//	F("added service: %s.host[%s]", dc.GetRole(), dc.GetHost())
@@ -0,0 +1,94 @@
+/*
+ *  Copyright (c) 2025 dingodb.com Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: dingoadm
+ * Author: dongwei (jackblack369)
+ */
+
+package config
+
+import (
+	"github.com/dingodb/dingoadm/cli/cli"
+	"github.com/dingodb/dingoadm/internal/errno"
+	"github.com/dingodb/dingoadm/internal/tui"
+	"github.com/dingodb/dingoadm/internal/utils"
+	"github.com/spf13/cobra"
+)
+
+const (
+	PORTS_EXAMPLE = `Examples:
+  $ dingoadm config ports                            # Display port map of current cluster
+  $ dingoadm config ports -f /path/to/topology.yaml  # Display port map of topology file`
+)
+
+type portsOptions struct {
+	filename string
+}
+
+func NewPortsCommand(dingoadm *cli.DingoAdm) *cobra.Command {
+	var options portsOptions
+
+	cmd := &cobra.Command{
+		Use:     "ports [OPTIONS]",
+		Short:   "Display ports which services listen on",
+		Args:    utils.NoArgs,
+		Example: PORTS_EXAMPLE,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runPorts(dingoadm, options)
+		},
+		DisableFlagsInUseLine: true,
+	}
+
+	flags := cmd.Flags()
+	flags.StringVarP(&options.filename, "topology", "f", "", "Specify the path of topology file")
+
+	return cmd
+}
+
+func runPorts(dingoadm *cli.DingoAdm, options portsOptions) error {
+	// 1) read topology from file or current cluster
+	data := dingoadm.ClusterTopologyData()
+	if len(options.filename) > 0 {
+		if !utils.PathExist(options.filename) {
+			return errno.ERR_TOPOLOGY_FILE_NOT_FOUND.
+				F("%s: no such file", utils.AbsPath(options.filename))
+		}
+		content, err := utils.ReadFile(options.filename)
+		if err != nil {
+			return errno.ERR_READ_TOPOLOGY_FILE_FAILED.E(err)
+		}
+		data = content
+	} else if dingoadm.ClusterId() == -1 {
+		return errno.ERR_NO_CLUSTER_SPECIFIED
+	}
+
+	// 2) parse topology, the omitted ports will be allocated
+	dcs, err := dingoadm.ParseTopologyData(data)
+	if err != nil {
+		return err
+	}
+
+	// 3) display port map
+	serviceIds := map[string]string{}
+	if dingoadm.ClusterId() != -1 {
+		for _, dc := range dcs {
+			serviceIds[dc.GetId()] = dingoadm.GetServiceId(dc.GetId())
+		}
+	}
+	dingoadm.WriteOut("%s", tui.FormatPorts(dcs, serviceIds))
+	return nil
+}
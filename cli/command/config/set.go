@@ -0,0 +1,195 @@
+/*
+ *  Copyright (c) 2025 dingodb.com Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: dingoadm
+ * Author: dongwei (jackblack369)
+ */
+
+package config
+
+import (
+	"strings"
+
+	"github.com/dingodb/dingoadm/cli/cli"
+	comm "github.com/dingodb/dingoadm/internal/common"
+	"github.com/dingodb/dingoadm/internal/configure/topology"
+	"github.com/dingodb/dingoadm/internal/errno"
+	"github.com/dingodb/dingoadm/internal/playbook"
+	tui "github.com/dingodb/dingoadm/internal/tui"
+	tuicomm "github.com/dingodb/dingoadm/internal/tui/common"
+	"github.com/dingodb/dingoadm/internal/utils"
+	"github.com/spf13/cobra"
+)
+
+const (
+	SET_EXAMPLE = `Examples:
+  $ dingoadm config set --role store gflags.log_level=1              # Set config and restart store services
+  $ dingoadm config set --role store gflags.log_level=1 --runtime    # Set config through brpc /flags if it's reloadable
+  $ dingoadm config set --role mds --runtime mds_scan_batch_size=100 # Set mds v2 flag at runtime`
+)
+
+type setOptions struct {
+	role    string
+	key     string
+	value   string
+	runtime bool
+	force   bool
+}
+
+func NewSetCommand(dingoadm *cli.DingoAdm) *cobra.Command {
+	var options setOptions
+
+	cmd := &cobra.Command{
+		Use:     "set KEY=VALUE [OPTIONS]",
+		Short:   "Set config of role services",
+		Args:    utils.ExactArgs(1),
+		Example: SET_EXAMPLE,
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			items := strings.SplitN(args[0], "=", 2)
+			if len(items) != 2 || len(strings.TrimSpace(items[0])) == 0 {
+				return errno.ERR_INVALID_CONFIG_ITEM.F("config item: %s", args[0])
+			}
+			options.key = strings.TrimSpace(items[0])
+			options.value = strings.TrimSpace(items[1])
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runSet(dingoadm, options)
+		},
+		DisableFlagsInUseLine: true,
+	}
+
+	flags := cmd.Flags()
+	flags.StringVar(&options.role, "role", "", "Specify service role")
+	flags.BoolVar(&options.runtime, "runtime", false, "Set flag through brpc /flags without restart if it's reloadable")
+	flags.BoolVarP(&options.force, "force", "f", false, "Never prompt")
+	cmd.MarkFlagRequired("role")
+
+	return cmd
+}
+
+func genSetConfigPlaybook(dingoadm *cli.DingoAdm,
+	dcs []*topology.DeployConfig,
+	dcs2restart []*topology.DeployConfig) *playbook.Playbook {
+	pb := playbook.NewPlaybook(dingoadm)
+	// sync config for all services, so the value is kept after restart
+	pb.AddStep(&playbook.PlaybookStep{
+		Type:    playbook.SYNC_CONFIG,
+		Configs: dcs,
+	})
+	if len(dcs2restart) > 0 {
+		pb.AddStep(&playbook.PlaybookStep{
+			Type:    playbook.RESTART_SERVICE,
+			Configs: dcs2restart,
+			ExecOptions: playbook.ExecOptions{
+				Concurrency: 1, // restart services one by one to keep cluster available
+			},
+		})
+	}
+	return pb
+}
+
+// return services which applied the config at runtime
+func setRuntimeFlags(dingoadm *cli.DingoAdm, dcs []*topology.DeployConfig, options setOptions) (map[string]bool, error) {
+	pb := playbook.NewPlaybook(dingoadm)
+	pb.AddStep(&playbook.PlaybookStep{
+		Type:    playbook.SET_RUNTIME_FLAG,
+		Configs: dcs,
+		Options: map[string]interface{}{
+			comm.KEY_CONFIG_ITEM_KEY: options.key,
+		},
+	})
+	if err := pb.Run(); err != nil {
+		return nil, err
+	}
+
+	v := dingoadm.MemStorage().Get(comm.KEY_RUNTIME_FLAG_RESULTS)
+	if v == nil {
+		return map[string]bool{}, nil
+	}
+	return v.(map[string]bool), nil
+}
+
+func runSet(dingoadm *cli.DingoAdm, options setOptions) error {
+	// 1) parse cluster topology
+	dcs, err := dingoadm.ParseTopology()
+	if err != nil {
+		return err
+	} else if len(dingoadm.FilterDeployConfigByRole(dcs, options.role)) == 0 {
+		return errno.ERR_NO_SERVICES_MATCHED.F("role: %s", options.role)
+	}
+
+	// 2) set config in topology
+	oldData := dingoadm.ClusterTopologyData()
+	data, err := topology.SetServicesConfig(oldData, options.role, options.key, options.value)
+	if err != nil {
+		return err
+	}
+	dcs, err = dingoadm.ParseTopologyData(data)
+	if err != nil {
+		return err
+	}
+	dcs = dingoadm.FilterDeployConfigByRole(dcs, options.role)
+
+	// 3) confirm by user
+	dingoadm.WriteOutln("%s", utils.Diff(oldData, data))
+	if !options.force {
+		if pass := tuicomm.ConfirmYes(tuicomm.DEFAULT_CONFIRM_PROMPT); !pass {
+			dingoadm.WriteOutln(tuicomm.PromptCancelOpetation("set config"))
+			return errno.ERR_CANCEL_OPERATION
+		}
+	}
+
+	// 4) update cluster topology in database
+	err = dingoadm.Storage().SetClusterTopology(dingoadm.ClusterId(), data)
+	if err != nil {
+		return errno.ERR_UPDATE_CLUSTER_TOPOLOGY_FAILED.E(err)
+	}
+
+	// 5) push the value to services at runtime
+	reloaded := map[string]bool{}
+	if options.runtime {
+		reloaded, err = setRuntimeFlags(dingoadm, dcs, options)
+		if err != nil {
+			return err
+		}
+	}
+
+	// 6) sync config, and restart services which not reloaded
+	paths := map[string]string{}
+	serviceIds := map[string]string{}
+	dcs2restart := []*topology.DeployConfig{}
+	for _, dc := range dcs {
+		serviceId := dingoadm.GetServiceId(dc.GetId())
+		serviceIds[dc.GetId()] = serviceId
+		if reloaded[serviceId] {
+			paths[serviceId] = tui.CONFIG_APPLY_RUNTIME
+		} else {
+			paths[serviceId] = tui.CONFIG_APPLY_RESTART
+			dcs2restart = append(dcs2restart, dc)
+		}
+	}
+	pb := genSetConfigPlaybook(dingoadm, dcs, dcs2restart)
+	if err = pb.Run(); err != nil {
+		return err
+	}
+
+	// 7) report which path each service took
+	dingoadm.WriteOutln("")
+	dingoadm.WriteOut("%s", tui.FormatConfigSet(dcs, serviceIds, paths))
+	return nil
+}
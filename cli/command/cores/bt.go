@@ -0,0 +1,89 @@
+/*
+ *  Copyright (c) 2025 dingodb.com Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: dingoadm
+ * Author: dongwei (jackblack369)
+ */
+package cores
+
+import (
+	"github.com/dingodb/dingoadm/cli/cli"
+	comm "github.com/dingodb/dingoadm/internal/common"
+	"github.com/dingodb/dingoadm/internal/configure/topology"
+	"github.com/dingodb/dingoadm/internal/playbook"
+	cliutil "github.com/dingodb/dingoadm/internal/utils"
+	"github.com/spf13/cobra"
+)
+
+const (
+	BACKTRACE_EXAMPLE = `Examples:
+  $ dingoadm cores bt 5f3a2c1b9e0d                # Print backtrace of all threads in core
+  $ dingoadm cores bt 5f3a2c1b9e0d > bt.txt       # Save backtrace for bug report`
+)
+
+type backtraceOptions struct {
+	id string
+}
+
+func NewBacktraceCommand(dingoadm *cli.DingoAdm) *cobra.Command {
+	var options backtraceOptions
+
+	cmd := &cobra.Command{
+		Use:     "bt ID",
+		Aliases: []string{"backtrace"},
+		Short:   "Print backtrace of core by gdb in service container",
+		Args:    cliutil.ExactArgs(1),
+		Example: BACKTRACE_EXAMPLE,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			options.id = args[0]
+			return runBacktrace(dingoadm, options)
+		},
+		DisableFlagsInUseLine: true,
+	}
+
+	return cmd
+}
+
+func runBacktrace(dingoadm *cli.DingoAdm, options backtraceOptions) error {
+	// 1) find core
+	core, dc, err := findCore(dingoadm, options.id)
+	if err != nil {
+		return err
+	}
+
+	// 2) run gdb in service container
+	pb := playbook.NewPlaybook(dingoadm)
+	pb.AddStep(&playbook.PlaybookStep{
+		Type:    playbook.BACKTRACE_CORE,
+		Configs: []*topology.DeployConfig{dc},
+		Options: map[string]interface{}{
+			comm.KEY_CORE: core,
+		},
+		ExecOptions: playbook.ExecOptions{
+			SilentSubBar:  true,
+			SilentMainBar: true,
+		},
+	})
+	if err := pb.Run(); err != nil {
+		return err
+	}
+
+	// 3) print backtrace
+	out := dingoadm.MemStorage().Get(comm.KEY_CORE_BACKTRACE).(string)
+	dingoadm.WriteOutln("%s", out)
+	return nil
+}
@@ -0,0 +1,130 @@
+/*
+ *  Copyright (c) 2025 dingodb.com Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: dingoadm
+ * Author: dongwei (jackblack369)
+ */
+package cores
+
+import (
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/dingodb/dingoadm/cli/cli"
+	comm "github.com/dingodb/dingoadm/internal/common"
+	"github.com/dingodb/dingoadm/internal/configure/topology"
+	"github.com/dingodb/dingoadm/internal/errno"
+	"github.com/dingodb/dingoadm/internal/playbook"
+	task "github.com/dingodb/dingoadm/internal/task/task/common"
+)
+
+/*
+ * return the service which generated the core among the services which
+ * share the core directory, the binary of core is under root directory of
+ * its owner, e.g. coordinator and store on the same host maybe mount the
+ * same core directory but run binaries under different prefixes. the first
+ * service is returned if the binary unknown or no prefix matched.
+ */
+func getCoreOwner(core task.Core, dcs []*topology.DeployConfig) *topology.DeployConfig {
+	if core.Binary != task.CORE_BINARY_UNKNOWN {
+		for _, dc := range dcs {
+			root := dc.GetProjectLayout().ServiceRootDir
+			if len(root) > 0 && strings.HasPrefix(core.Binary, root+"/") {
+				return dc
+			}
+		}
+	}
+	return dcs[0]
+}
+
+/*
+ * list cores of matched services, the newest core comes first.
+ * services on the same host maybe share one core directory,
+ * the core is displayed only once for the service which owns it.
+ */
+func listCores(dingoadm *cli.DingoAdm, dcs []*topology.DeployConfig, silent bool) ([]task.Core, error) {
+	pb := playbook.NewPlaybook(dingoadm)
+	pb.AddStep(&playbook.PlaybookStep{
+		Type:    playbook.LIST_CORES,
+		Configs: dcs,
+		ExecOptions: playbook.ExecOptions{
+			SilentSubBar:  true,
+			SilentMainBar: silent,
+			SkipError:     true,
+		},
+	})
+	if err := pb.Run(); err != nil {
+		return nil, err
+	}
+
+	m := map[string][]task.Core{}
+	if v := dingoadm.MemStorage().Get(comm.KEY_ALL_CORES); v != nil {
+		m = v.(map[string][]task.Core)
+	}
+	cores := []task.Core{}
+	owners := map[string][]*topology.DeployConfig{}
+	for _, dc := range dcs {
+		for _, core := range m[dingoadm.GetServiceId(dc.GetId())] {
+			if len(owners[core.Id]) == 0 {
+				cores = append(cores, core)
+			}
+			owners[core.Id] = append(owners[core.Id], dc)
+		}
+	}
+	for i := range cores {
+		core := &cores[i]
+		owner := getCoreOwner(*core, owners[core.Id])
+		core.ServiceId = dingoadm.GetServiceId(owner.GetId())
+		core.Role = owner.GetRole()
+		core.ContainerPath = path.Join(owner.GetSourceCoreDir(), path.Base(core.Path))
+	}
+	sort.SliceStable(cores, func(i, j int) bool {
+		return cores[i].Time.After(cores[j].Time)
+	})
+	return cores, nil
+}
+
+// find core by id (or unique prefix of id) and the service which owns it
+func findCore(dingoadm *cli.DingoAdm, id string) (task.Core, *topology.DeployConfig, error) {
+	dcs, err := dingoadm.ParseTopology()
+	if err != nil {
+		return task.Core{}, nil, err
+	}
+
+	cores, err := listCores(dingoadm, dcs, true)
+	if err != nil {
+		return task.Core{}, nil, err
+	}
+	matched := []task.Core{}
+	for _, core := range cores {
+		if strings.HasPrefix(core.Id, id) {
+			matched = append(matched, core)
+		}
+	}
+	if len(matched) != 1 {
+		return task.Core{}, nil, errno.ERR_CORE_NOT_FOUND.F("id: %s", id)
+	}
+
+	core := matched[0]
+	for _, dc := range dcs {
+		if dingoadm.GetServiceId(dc.GetId()) == core.ServiceId {
+			return core, dc, nil
+		}
+	}
+	return task.Core{}, nil, errno.ERR_CORE_NOT_FOUND.F("id: %s", id)
+}
@@ -0,0 +1,48 @@
+package cores
+
+import (
+	"testing"
+
+	"github.com/dingodb/dingoadm/cli/cli"
+	"github.com/dingodb/dingoadm/internal/configure/topology"
+	task "github.com/dingodb/dingoadm/internal/task/task/common"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetCoreOwner(t *testing.T) {
+	assert := assert.New(t)
+
+	data := `
+kind: dingofs
+coordinator_services:
+  deploy:
+    - host: host1
+store_services:
+  deploy:
+    - host: host1
+mds_services:
+  deploy:
+    - host: host1
+executor_services:
+  deploy:
+    - host: host1
+`
+	ctx := topology.NewContext()
+	ctx.Add("host1", "host1")
+	dcs, err := topology.ParseTopology(data, ctx)
+	assert.Nil(err)
+	dingoadm := &cli.DingoAdm{}
+	coordinator := dingoadm.FilterDeployConfigByRole(dcs, topology.ROLE_COORDINATOR)[0]
+	mds := dingoadm.FilterDeployConfigByRole(dcs, topology.ROLE_FS_MDS)[0]
+
+	// the owner is decided by binary, not the order of services
+	shared := []*topology.DeployConfig{coordinator, mds}
+	core := task.Core{Binary: mds.GetProjectLayout().ServiceRootDir + "/bin/dingo-mds"}
+	assert.Equal(mds, getCoreOwner(core, shared))
+	core = task.Core{Binary: coordinator.GetProjectLayout().ServiceRootDir + "/build/bin/dingodb_server"}
+	assert.Equal(coordinator, getCoreOwner(core, []*topology.DeployConfig{mds, coordinator}))
+
+	// binary unknown
+	core = task.Core{Binary: task.CORE_BINARY_UNKNOWN}
+	assert.Equal(coordinator, getCoreOwner(core, shared))
+}
@@ -0,0 +1,97 @@
+/*
+ *  Copyright (c) 2025 dingodb.com Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: dingoadm
+ * Author: dongwei (jackblack369)
+ */
+package cores
+
+import (
+	"path"
+
+	"github.com/dingodb/dingoadm/cli/cli"
+	comm "github.com/dingodb/dingoadm/internal/common"
+	"github.com/dingodb/dingoadm/internal/configure/topology"
+	"github.com/dingodb/dingoadm/internal/errno"
+	"github.com/dingodb/dingoadm/internal/playbook"
+	cliutil "github.com/dingodb/dingoadm/internal/utils"
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+const (
+	FETCH_EXAMPLE = `Examples:
+  $ dingoadm cores fetch 5f3a2c1b9e0d             # Download core file and binary into current directory
+  $ dingoadm cores fetch 5f3a2c1b9e0d --dir /tmp  # Download core file and binary into /tmp`
+)
+
+type fetchOptions struct {
+	id  string
+	dir string
+}
+
+func NewFetchCommand(dingoadm *cli.DingoAdm) *cobra.Command {
+	var options fetchOptions
+
+	cmd := &cobra.Command{
+		Use:     "fetch ID [OPTIONS]",
+		Short:   "Download core file with the matching binary from service container",
+		Args:    cliutil.ExactArgs(1),
+		Example: FETCH_EXAMPLE,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			options.id = args[0]
+			return runFetch(dingoadm, options)
+		},
+		DisableFlagsInUseLine: true,
+	}
+
+	flags := cmd.Flags()
+	flags.StringVar(&options.dir, "dir", ".", "Specify directory to save core file and binary")
+
+	return cmd
+}
+
+func runFetch(dingoadm *cli.DingoAdm, options fetchOptions) error {
+	// 1) find core
+	core, dc, err := findCore(dingoadm, options.id)
+	if err != nil {
+		return err
+	}
+	dir := cliutil.AbsPath(options.dir)
+	if !cliutil.PathExist(dir) {
+		return errno.ERR_CORE_SAVE_DIR_NOT_EXIST.F("dir: %s", dir)
+	}
+
+	// 2) download core and binary
+	pb := playbook.NewPlaybook(dingoadm)
+	pb.AddStep(&playbook.PlaybookStep{
+		Type:    playbook.FETCH_CORE,
+		Configs: []*topology.DeployConfig{dc},
+		Options: map[string]interface{}{
+			comm.KEY_CORE:          core,
+			comm.KEY_CORE_SAVE_DIR: dir,
+		},
+	})
+	if err := pb.Run(); err != nil {
+		return err
+	}
+
+	// 3) print saved files
+	dingoadm.WriteOutln("%s", color.GreenString("Core saved to %s", path.Join(dir, path.Base(core.Path))))
+	dingoadm.WriteOutln("%s", color.GreenString("Binary saved to %s", path.Join(dir, path.Base(core.Binary))))
+	return nil
+}
@@ -0,0 +1,103 @@
+/*
+ *  Copyright (c) 2025 dingodb.com Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: dingoadm
+ * Author: dongwei (jackblack369)
+ */
+package cores
+
+import (
+	"github.com/dingodb/dingoadm/cli/cli"
+	"github.com/dingodb/dingoadm/internal/configure/topology"
+	"github.com/dingodb/dingoadm/internal/tui/output"
+	tui "github.com/dingodb/dingoadm/internal/tui/service"
+	cliutil "github.com/dingodb/dingoadm/internal/utils"
+	"github.com/spf13/cobra"
+)
+
+const (
+	LIST_EXAMPLE = `Examples:
+  $ dingoadm cores list                   # List core files of all services
+  $ dingoadm cores list --role store      # List core files of stores
+  $ dingoadm cores list --format json     # List core files in json format`
+)
+
+type listOptions struct {
+	id     string
+	role   string
+	host   string
+	format string
+}
+
+func NewListCommand(dingoadm *cli.DingoAdm) *cobra.Command {
+	var options listOptions
+
+	cmd := &cobra.Command{
+		Use:     "list [OPTIONS]",
+		Aliases: []string{"ls"},
+		Short:   "List core files under core directory of services",
+		Args:    cliutil.NoArgs,
+		Example: LIST_EXAMPLE,
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			return output.CheckFormat(options.format)
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runList(dingoadm, options)
+		},
+		DisableFlagsInUseLine: true,
+	}
+
+	flags := cmd.Flags()
+	flags.StringVar(&options.id, "id", "*", "Specify service id")
+	flags.StringVar(&options.role, "role", "*", "Specify service role")
+	flags.StringVar(&options.host, "host", "*", "Specify service host")
+	flags.StringVar(&options.format, "format", output.FORMAT_TABLE, output.FLAG_FORMAT_USAGE)
+
+	return cmd
+}
+
+func runList(dingoadm *cli.DingoAdm, options listOptions) error {
+	// 1) parse cluster topology
+	dcs, err := dingoadm.ParseTopology()
+	if err != nil {
+		return err
+	}
+
+	// 2) list cores of matched services
+	dcs = dingoadm.FilterDeployConfig(dcs, topology.FilterOption{
+		Id:   options.id,
+		Role: options.role,
+		Host: options.host,
+	})
+	cores, err := listCores(dingoadm, dcs, output.IsStructured(options.format))
+	if err != nil {
+		return err
+	}
+
+	// 3) display cores
+	if output.IsStructured(options.format) {
+		out, err := output.Marshal(options.format, cores)
+		if err != nil {
+			return err
+		}
+		dingoadm.WriteOut("%s", out)
+		return nil
+	}
+	dingoadm.WriteOutln("")
+	dingoadm.WriteOut("%s", tui.FormatCores(cores))
+	return nil
+}
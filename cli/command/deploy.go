@@ -315,9 +315,8 @@ func calcNumOfChunkserver(dingoadm *cli.DingoAdm, dcs []*topology.DeployConfig)
 	return len(services)
 }
 
-func genDeployPlaybook(dingoadm *cli.DingoAdm,
-	dcs []*topology.DeployConfig,
-	options deployOptions) (*playbook.Playbook, error) {
+// return deploy steps in dependency order by cluster kind and roles
+func getDeploySteps(dingoadm *cli.DingoAdm, dcs []*topology.DeployConfig) ([]int, error) {
 	var steps []int
 	kind := dcs[0].GetKind()
 
@@ -347,6 +346,16 @@ func genDeployPlaybook(dingoadm *cli.DingoAdm,
 	default:
 		return nil, errno.ERR_UNSUPPORT_CLUSTER_KIND.F("kind: %s", kind)
 	}
+	return steps, nil
+}
+
+func genDeployPlaybook(dingoadm *cli.DingoAdm,
+	dcs []*topology.DeployConfig,
+	options deployOptions) (*playbook.Playbook, error) {
+	steps, err := getDeploySteps(dingoadm, dcs)
+	if err != nil {
+		return nil, err
+	}
 
 	if options.useLocalImage {
 		// remove PULL_IMAGE step
@@ -0,0 +1,227 @@
+/*
+ *  Copyright (c) 2025 dingodb.com Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: dingoadm
+ * Author: dongwei (jackblack369)
+ */
+package command
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/dingodb/dingoadm/cli/cli"
+	comm "github.com/dingodb/dingoadm/internal/common"
+	"github.com/dingodb/dingoadm/internal/configure/topology"
+	"github.com/dingodb/dingoadm/internal/errno"
+	"github.com/dingodb/dingoadm/internal/playbook"
+	task "github.com/dingodb/dingoadm/internal/task/task/common"
+	tuicomm "github.com/dingodb/dingoadm/internal/tui/common"
+	"github.com/dingodb/dingoadm/internal/tui/output"
+	tui "github.com/dingodb/dingoadm/internal/tui/service"
+	cliutil "github.com/dingodb/dingoadm/internal/utils"
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+const (
+	DRIFT_EXAMPLE = `Examples:
+  $ dingoadm drift                     # Detect drift of all services
+  $ dingoadm drift --role store        # Detect drift of stores
+  $ dingoadm drift --fix               # Sync config and restart services whose config drifted`
+)
+
+var (
+	FIX_DRIFT_PLAYBOOK_STEPS = []int{
+		playbook.SYNC_CONFIG,
+		playbook.RESTART_SERVICE,
+	}
+)
+
+type driftOptions struct {
+	id     string
+	role   string
+	host   string
+	fix    bool
+	format string
+}
+
+func NewDriftCommand(dingoadm *cli.DingoAdm) *cobra.Command {
+	var options driftOptions
+
+	cmd := &cobra.Command{
+		Use:     "drift [OPTIONS]",
+		Short:   "Detect drift between topology and running services",
+		Args:    cliutil.NoArgs,
+		Example: DRIFT_EXAMPLE,
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			return output.CheckFormat(options.format)
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runDrift(dingoadm, options)
+		},
+		DisableFlagsInUseLine: true,
+	}
+
+	flags := cmd.Flags()
+	flags.StringVar(&options.id, "id", "*", "Specify service id")
+	flags.StringVar(&options.role, "role", "*", "Specify service role")
+	flags.StringVar(&options.host, "host", "*", "Specify service host")
+	flags.BoolVar(&options.fix, "fix", false, "Sync config and restart services whose config drifted")
+	flags.StringVar(&options.format, "format", output.FORMAT_TABLE, output.FLAG_FORMAT_USAGE)
+
+	return cmd
+}
+
+func getDrifts(dingoadm *cli.DingoAdm) []task.Drift {
+	drifts := []task.Drift{}
+	value := dingoadm.MemStorage().Get(comm.KEY_ALL_DRIFTS)
+	if value != nil {
+		for _, items := range value.(map[string][]task.Drift) {
+			drifts = append(drifts, items...)
+		}
+	}
+	tui.SortDrifts(drifts)
+	return drifts
+}
+
+// config drift can be fixed by sync config, others require recreating container (e.g. upgrade)
+// services which hold leaders transfer their leaders before restarted,
+// and all services are restarted one by one to keep cluster available
+func genFixDriftPlaybook(dingoadm *cli.DingoAdm, dcs, all []*topology.DeployConfig) *playbook.Playbook {
+	pb := playbook.NewPlaybook(dingoadm)
+	for _, step := range FIX_DRIFT_PLAYBOOK_STEPS {
+		if step == playbook.RESTART_SERVICE {
+			addOperateSteps(dingoadm, pb, step, dcs, all, true, nil)
+			continue
+		}
+		pb.AddStep(&playbook.PlaybookStep{
+			Type:    step,
+			Configs: dcs,
+		})
+	}
+	for _, step := range pb.Steps() {
+		if step.Type == playbook.RESTART_SERVICE {
+			step.ExecOptions.Concurrency = 1
+		}
+	}
+	return pb
+}
+
+func fixDrift(dingoadm *cli.DingoAdm, dcs, all []*topology.DeployConfig, drifts []task.Drift) error {
+	configDrifted := map[string]bool{}
+	containerDrifted := []string{}
+	for _, drift := range drifts {
+		if drift.Item == task.DRIFT_ITEM_CONFIG {
+			configDrifted[drift.Id] = true
+		} else if !cliutil.Contains(containerDrifted, drift.Id) {
+			containerDrifted = append(containerDrifted, drift.Id)
+		}
+	}
+
+	services := []*topology.DeployConfig{}
+	ids := []string{}
+	for _, dc := range dcs {
+		serviceId := dingoadm.GetServiceId(dc.GetId())
+		if configDrifted[serviceId] {
+			services = append(services, dc)
+			ids = append(ids, serviceId)
+		}
+	}
+	if len(services) > 0 {
+		sort.Strings(ids)
+		if pass := tuicomm.ConfirmYes(tuicomm.PromptFixDrift(ids)); !pass {
+			dingoadm.WriteOut(tuicomm.PromptCancelOpetation("fix drift"))
+			return errno.ERR_CANCEL_OPERATION
+		}
+
+		pb := genFixDriftPlaybook(dingoadm, services, all)
+		if err := pb.Run(); err != nil {
+			return err
+		}
+		dingoadm.WriteOutln("")
+		dingoadm.WriteOutln(color.GreenString("Config drift fixed :)"))
+	}
+
+	for _, id := range containerDrifted {
+		dingoadm.WriteOutln(color.YellowString("Service %s requires recreating container, run 'dingoadm upgrade --id %s'", id, id))
+	}
+	if len(containerDrifted) > 0 {
+		return errno.ERR_SERVICES_DRIFT_FOUND.F("services: %s", strings.Join(containerDrifted, ","))
+	}
+	return nil
+}
+
+func runDrift(dingoadm *cli.DingoAdm, options driftOptions) error {
+	// 1) parse cluster topology
+	dcs, err := dingoadm.ParseTopology()
+	if err != nil {
+		return err
+	}
+
+	// 2) detect drift of matched services
+	all := dcs
+	dcs = dingoadm.FilterDeployConfig(dcs, topology.FilterOption{
+		Id:   options.id,
+		Role: options.role,
+		Host: options.host,
+	})
+	if len(dcs) == 0 {
+		return errno.ERR_NO_SERVICES_MATCHED
+	}
+	pb := playbook.NewPlaybook(dingoadm)
+	pb.AddStep(&playbook.PlaybookStep{
+		Type:    playbook.CHECK_DRIFT,
+		Configs: dcs,
+		ExecOptions: playbook.ExecOptions{
+			SilentSubBar:  true,
+			SilentMainBar: output.IsStructured(options.format),
+			SkipError:     true,
+		},
+	})
+	if err := pb.Run(); err != nil {
+		return err
+	}
+
+	// 3) display drifts
+	drifts := getDrifts(dingoadm)
+	if output.IsStructured(options.format) {
+		out, err := output.Marshal(options.format, drifts)
+		if err != nil {
+			return err
+		}
+		dingoadm.WriteOut("%s", out)
+	} else {
+		dingoadm.WriteOutln("")
+		dingoadm.WriteOut("%s", tui.FormatDrifts(drifts))
+	}
+	if len(drifts) == 0 {
+		return nil
+	}
+
+	// 4) fix drift
+	if options.fix {
+		return fixDrift(dingoadm, dcs, all, drifts)
+	}
+	ids := []string{}
+	for _, drift := range drifts {
+		if !cliutil.Contains(ids, drift.Id) {
+			ids = append(ids, drift.Id)
+		}
+	}
+	return errno.ERR_SERVICES_DRIFT_FOUND.F("services: %s", strings.Join(ids, ","))
+}
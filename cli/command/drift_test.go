@@ -0,0 +1,35 @@
+package command
+
+import (
+	"testing"
+
+	"github.com/dingodb/dingoadm/cli/cli"
+	"github.com/dingodb/dingoadm/internal/configure/topology"
+	"github.com/dingodb/dingoadm/internal/playbook"
+	"github.com/stretchr/testify/assert"
+)
+
+// drifted services are restarted one by one after their leaders transferred
+func TestGenFixDriftPlaybook(t *testing.T) {
+	assert := assert.New(t)
+	curveadm := &cli.DingoAdm{}
+
+	all := parseTestTopology(t, SCALE_OUT_OLD_TOPOLOGY)
+	coordinators := curveadm.FilterDeployConfigByRole(all, topology.ROLE_COORDINATOR)
+	stores := curveadm.FilterDeployConfigByRole(all, topology.ROLE_STORE)
+	dcs := []*topology.DeployConfig{coordinators[0], stores[0]}
+
+	pb := genFixDriftPlaybook(curveadm, dcs, all)
+	assert.Equal([]int{
+		playbook.SYNC_CONFIG,
+		playbook.TRANSFER_LEADER, playbook.RESTART_SERVICE, playbook.WAIT_SERVICE_HEALTHY,
+		playbook.TRANSFER_LEADER, playbook.RESTART_SERVICE, playbook.WAIT_SERVICE_HEALTHY,
+	}, getStepTypes(pb))
+	assert.Equal(dcs, getStep(pb, playbook.SYNC_CONFIG).Configs)
+	assert.Equal([]*topology.DeployConfig{coordinators[0]}, getStep(pb, playbook.TRANSFER_LEADER).Configs)
+	for _, step := range pb.Steps() {
+		if step.Type == playbook.RESTART_SERVICE {
+			assert.Equal(uint(1), step.ExecOptions.Concurrency)
+		}
+	}
+}
@@ -0,0 +1,96 @@
+/*
+ *  Copyright (c) 2025 dingodb.com Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: dingoadm
+ * Author: dongwei (jackblack369)
+ */
+package flags
+
+import (
+	"github.com/dingodb/dingoadm/cli/cli"
+	comm "github.com/dingodb/dingoadm/internal/common"
+	"github.com/dingodb/dingoadm/internal/configure/topology"
+	"github.com/dingodb/dingoadm/internal/errno"
+	"github.com/dingodb/dingoadm/internal/playbook"
+	task "github.com/dingodb/dingoadm/internal/task/task/common"
+	"github.com/dingodb/dingoadm/internal/tui/output"
+	tui "github.com/dingodb/dingoadm/internal/tui/service"
+)
+
+type filterOptions struct {
+	id   string
+	role string
+	host string
+}
+
+// query flags from matched services, all configured flags are queried if names is empty
+func getFlags(dingoadm *cli.DingoAdm, filter filterOptions, names []string, format string) ([]task.FlagValue, error) {
+	dcs, err := dingoadm.ParseTopology()
+	if err != nil {
+		return nil, err
+	}
+
+	dcs = dingoadm.FilterDeployConfig(dcs, topology.FilterOption{
+		Id:   filter.id,
+		Role: filter.role,
+		Host: filter.host,
+	})
+	if len(dcs) == 0 {
+		return nil, errno.ERR_NO_SERVICES_MATCHED
+	}
+
+	pb := playbook.NewPlaybook(dingoadm)
+	pb.AddStep(&playbook.PlaybookStep{
+		Type:    playbook.GET_FLAGS,
+		Configs: dcs,
+		Options: map[string]interface{}{
+			comm.KEY_FLAG_NAMES: names,
+		},
+		ExecOptions: playbook.ExecOptions{
+			SilentSubBar:  true,
+			SilentMainBar: output.IsStructured(format),
+			SkipError:     true,
+		},
+	})
+	if err := pb.Run(); err != nil {
+		return nil, err
+	}
+
+	values := []task.FlagValue{}
+	if v := dingoadm.MemStorage().Get(comm.KEY_ALL_FLAGS); v != nil {
+		for _, vs := range v.(map[string][]task.FlagValue) {
+			values = append(values, vs...)
+		}
+	}
+	tui.SortFlagValues(values)
+	return values, nil
+}
+
+func display(dingoadm *cli.DingoAdm, format string, values []task.FlagValue) error {
+	if output.IsStructured(format) {
+		out, err := output.Marshal(format, values)
+		if err != nil {
+			return err
+		}
+		dingoadm.WriteOut("%s", out)
+		return nil
+	}
+
+	dingoadm.WriteOutln("")
+	dingoadm.WriteOut("%s", tui.FormatFlagValues(values))
+	return nil
+}
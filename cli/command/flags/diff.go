@@ -0,0 +1,89 @@
+/*
+ *  Copyright (c) 2025 dingodb.com Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: dingoadm
+ * Author: dongwei (jackblack369)
+ */
+package flags
+
+import (
+	"github.com/dingodb/dingoadm/cli/cli"
+	task "github.com/dingodb/dingoadm/internal/task/task/common"
+	"github.com/dingodb/dingoadm/internal/tui/output"
+	cliutil "github.com/dingodb/dingoadm/internal/utils"
+	"github.com/spf13/cobra"
+)
+
+const (
+	DIFF_EXAMPLE = `Examples:
+  $ dingoadm flags diff --id <id>        # Display configured flags whose runtime value differs
+  $ dingoadm flags diff --role store     # Display differences for all stores
+  $ dingoadm flags diff --id <id> --all  # Display all configured flags`
+)
+
+type diffOptions struct {
+	filterOptions
+	all    bool
+	format string
+}
+
+func NewDiffCommand(dingoadm *cli.DingoAdm) *cobra.Command {
+	var options diffOptions
+
+	cmd := &cobra.Command{
+		Use:     "diff [OPTIONS]",
+		Short:   "Display differences between runtime and configured flags",
+		Args:    cliutil.NoArgs,
+		Example: DIFF_EXAMPLE,
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			return output.CheckFormat(options.format)
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runDiff(dingoadm, options)
+		},
+		DisableFlagsInUseLine: true,
+	}
+
+	flags := cmd.Flags()
+	flags.StringVar(&options.id, "id", "*", "Specify service id")
+	flags.StringVar(&options.role, "role", "*", "Specify service role")
+	flags.StringVar(&options.host, "host", "*", "Specify service host")
+	flags.BoolVar(&options.all, "all", false, "Display all configured flags, including the same ones")
+	flags.StringVar(&options.format, "format", output.FORMAT_TABLE, output.FLAG_FORMAT_USAGE)
+
+	return cmd
+}
+
+func runDiff(dingoadm *cli.DingoAdm, options diffOptions) error {
+	// 1) query all configured flags
+	values, err := getFlags(dingoadm, options.filterOptions, []string{}, options.format)
+	if err != nil {
+		return err
+	}
+
+	// 2) display differences
+	if !options.all {
+		diffs := []task.FlagValue{}
+		for _, value := range values {
+			if value.Differ {
+				diffs = append(diffs, value)
+			}
+		}
+		values = diffs
+	}
+	return display(dingoadm, options.format, values)
+}
@@ -0,0 +1,82 @@
+/*
+ *  Copyright (c) 2025 dingodb.com Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: dingoadm
+ * Author: dongwei (jackblack369)
+ */
+package flags
+
+import (
+	"strings"
+
+	"github.com/dingodb/dingoadm/cli/cli"
+	"github.com/dingodb/dingoadm/internal/tui/output"
+	cliutil "github.com/dingodb/dingoadm/internal/utils"
+	"github.com/spf13/cobra"
+)
+
+const (
+	GET_EXAMPLE = `Examples:
+  $ dingoadm flags get raft_sync --role store        # Display runtime value of raft_sync on all stores
+  $ dingoadm flags get raft_sync,raft_max_byte_count_per_rpc  # Display multiple flags on all services
+  $ dingoadm flags get raft_sync --host server-1     # Display runtime value of raft_sync on server-1`
+)
+
+type getOptions struct {
+	filterOptions
+	name   string
+	format string
+}
+
+func NewGetCommand(dingoadm *cli.DingoAdm) *cobra.Command {
+	var options getOptions
+
+	cmd := &cobra.Command{
+		Use:     "get NAME [OPTIONS]",
+		Short:   "Display runtime value of flag on matched services",
+		Args:    cliutil.ExactArgs(1),
+		Example: GET_EXAMPLE,
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			return output.CheckFormat(options.format)
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			options.name = args[0]
+			return runGet(dingoadm, options)
+		},
+		DisableFlagsInUseLine: true,
+	}
+
+	flags := cmd.Flags()
+	flags.StringVar(&options.id, "id", "*", "Specify service id")
+	flags.StringVar(&options.role, "role", "*", "Specify service role")
+	flags.StringVar(&options.host, "host", "*", "Specify service host")
+	flags.StringVar(&options.format, "format", output.FORMAT_TABLE, output.FLAG_FORMAT_USAGE)
+
+	return cmd
+}
+
+func runGet(dingoadm *cli.DingoAdm, options getOptions) error {
+	// 1) query flags
+	names := strings.Split(options.name, ",")
+	values, err := getFlags(dingoadm, options.filterOptions, names, options.format)
+	if err != nil {
+		return err
+	}
+
+	// 2) display flags, value which differs from configured is highlighted
+	return display(dingoadm, options.format, values)
+}
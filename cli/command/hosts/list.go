@@ -32,14 +32,31 @@ import (
 	"github.com/dingodb/dingoadm/cli/cli"
 	"github.com/dingodb/dingoadm/internal/configure/hosts"
 	"github.com/dingodb/dingoadm/internal/tui"
+	tuioutput "github.com/dingodb/dingoadm/internal/tui/output"
 	cliutil "github.com/dingodb/dingoadm/internal/utils"
 	"github.com/spf13/cobra"
 )
 
-type listOptions struct {
-	verbose bool
-	labels  string
-}
+type (
+	listOptions struct {
+		verbose bool
+		labels  string
+		format  string
+	}
+
+	// host config for structured output
+	hostItem struct {
+		Host           string   `json:"host" yaml:"host"`
+		Hostname       string   `json:"hostname" yaml:"hostname"`
+		User           string   `json:"user" yaml:"user"`
+		Port           int      `json:"port" yaml:"port"`
+		PrivateKeyFile string   `json:"private_key_file" yaml:"private_key_file"`
+		ForwardAgent   bool     `json:"forward_agent" yaml:"forward_agent"`
+		BecomeUser     string   `json:"become_user" yaml:"become_user"`
+		Labels         []string `json:"labels" yaml:"labels"`
+		Envs           []string `json:"envs" yaml:"envs"`
+	}
+)
 
 func NewListCommand(dingoadm *cli.DingoAdm) *cobra.Command {
 	var options listOptions
@@ -49,6 +66,9 @@ func NewListCommand(dingoadm *cli.DingoAdm) *cobra.Command {
 		Aliases: []string{"list"},
 		Short:   "List hosts",
 		Args:    cliutil.NoArgs,
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			return tuioutput.CheckFormat(options.format)
+		},
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return runList(dingoadm, options)
 		},
@@ -58,6 +78,7 @@ func NewListCommand(dingoadm *cli.DingoAdm) *cobra.Command {
 	flags := cmd.Flags()
 	flags.BoolVarP(&options.verbose, "verbose", "v", false, "Verbose output for hosts")
 	flags.StringVarP(&options.labels, "labels", "l", "", "Specify the host labels")
+	flags.StringVar(&options.format, "format", tuioutput.FORMAT_TABLE, tuioutput.FLAG_FORMAT_USAGE)
 
 	return cmd
 }
@@ -157,6 +178,29 @@ func filter(data string, labels []string) ([]*hosts.HostConfig, error) {
 	return out, nil
 }
 
+func displayStructuredHosts(dingoadm *cli.DingoAdm, hcs []*hosts.HostConfig, options listOptions) error {
+	items := []hostItem{}
+	for _, hc := range hcs {
+		items = append(items, hostItem{
+			Host:           hc.GetHost(),
+			Hostname:       hc.GetHostname(),
+			User:           hc.GetUser(),
+			Port:           hc.GetSSHPort(),
+			PrivateKeyFile: hc.GetPrivateKeyFile(),
+			ForwardAgent:   hc.GetForwardAgent(),
+			BecomeUser:     hc.GetBecomeUser(),
+			Labels:         append([]string{}, hc.GetLabels()...),
+			Envs:           append([]string{}, hc.GetEnvs()...),
+		})
+	}
+	out, err := tuioutput.Marshal(options.format, items)
+	if err != nil {
+		return err
+	}
+	dingoadm.WriteOut("%s", out)
+	return nil
+}
+
 func runList(dingoadm *cli.DingoAdm, options listOptions) error {
 	var hcs []*hosts.HostConfig
 	var err error
@@ -169,7 +213,10 @@ func runList(dingoadm *cli.DingoAdm, options listOptions) error {
 		}
 	}
 
-	output := tui.FormatHosts(hcs, options.verbose)
-	dingoadm.WriteOut(output)
+	if tuioutput.IsStructured(options.format) {
+		return displayStructuredHosts(dingoadm, hcs, options)
+	}
+	out := tui.FormatHosts(hcs, options.verbose)
+	dingoadm.WriteOut(out)
 	return nil
 }
@@ -0,0 +1,119 @@
+/*
+ *  Copyright (c) 2025 dingodb.com Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: dingoadm
+ * Author: dongwei (jackblack369)
+ */
+
+package leader
+
+import (
+	"github.com/dingodb/dingoadm/cli/cli"
+	comm "github.com/dingodb/dingoadm/internal/common"
+	"github.com/dingodb/dingoadm/internal/configure/topology"
+	"github.com/dingodb/dingoadm/internal/errno"
+	"github.com/dingodb/dingoadm/internal/playbook"
+	cliutil "github.com/dingodb/dingoadm/internal/utils"
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+const (
+	BALANCE_EXAMPLE = `Examples:
+  $ dingoadm leader balance --role store  # Balance region leaders among stores`
+)
+
+var (
+	// roles which support balance leaders
+	BALANCE_SUPPORTED_ROLES = map[string]bool{
+		topology.ROLE_STORE: true,
+	}
+)
+
+type balanceOptions struct {
+	role string
+}
+
+func NewBalanceCommand(dingoadm *cli.DingoAdm) *cobra.Command {
+	var options balanceOptions
+
+	cmd := &cobra.Command{
+		Use:     "balance [OPTIONS]",
+		Short:   "Balance leaders among services",
+		Args:    cliutil.NoArgs,
+		Example: BALANCE_EXAMPLE,
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			if !BALANCE_SUPPORTED_ROLES[options.role] {
+				return errno.ERR_UNSUPPORT_BALANCE_LEADER_ROLE.F("role: %s", options.role)
+			}
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runBalance(dingoadm, options)
+		},
+		DisableFlagsInUseLine: true,
+	}
+
+	flags := cmd.Flags()
+	flags.StringVar(&options.role, "role", topology.ROLE_STORE, "Specify service role")
+
+	return cmd
+}
+
+func genBalancePlaybook(dingoadm *cli.DingoAdm,
+	dcs []*topology.DeployConfig,
+	options balanceOptions) (*playbook.Playbook, error) {
+	services := dingoadm.FilterDeployConfigByRole(dcs, options.role)
+	if len(services) == 0 {
+		return nil, errno.ERR_NO_SERVICES_MATCHED
+	}
+
+	pb := playbook.NewPlaybook(dingoadm)
+	pb.AddStep(&playbook.PlaybookStep{
+		Type:    playbook.TRANSFER_LEADER,
+		Configs: services[:1], // balance leaders of all stores through anyone
+		Options: map[string]interface{}{
+			comm.KEY_ALL_DEPLOY_CONFIGS: dcs,
+			comm.KEY_LEADER_BALANCE:     true,
+		},
+	})
+	return pb, nil
+}
+
+func runBalance(dingoadm *cli.DingoAdm, options balanceOptions) error {
+	// 1) parse cluster topology
+	dcs, err := dingoadm.ParseTopology()
+	if err != nil {
+		return err
+	}
+
+	// 2) generate balance playbook
+	pb, err := genBalancePlaybook(dingoadm, dcs, options)
+	if err != nil {
+		return err
+	}
+
+	// 3) run playground
+	if err = pb.Run(); err != nil {
+		return err
+	}
+
+	// 4) print success prompt
+	dingoadm.WriteOutln("")
+	dingoadm.WriteOutln(color.GreenString("Leaders of %s services balanced"), options.role)
+	return nil
+}
@@ -0,0 +1,42 @@
+/*
+ *  Copyright (c) 2025 dingodb.com Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: dingoadm
+ * Author: dongwei (jackblack369)
+ */
+
+package leader
+
+import (
+	"github.com/dingodb/dingoadm/cli/cli"
+	cliutil "github.com/dingodb/dingoadm/internal/utils"
+	"github.com/spf13/cobra"
+)
+
+func NewLeaderCommand(dingoadm *cli.DingoAdm) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "leader",
+		Short: "Manage leaders of raft-based services",
+		Args:  cliutil.NoArgs,
+		RunE:  cliutil.ShowHelp(dingoadm.Err()),
+	}
+
+	cmd.AddCommand(
+		NewBalanceCommand(dingoadm),
+	)
+	return cmd
+}
@@ -0,0 +1,266 @@
+/*
+ *  Copyright (c) 2025 dingodb.com Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: dingoadm
+ * Author: dongwei (jackblack369)
+ */
+package command
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"regexp"
+	"syscall"
+	"time"
+
+	"github.com/dingodb/dingoadm/cli/cli"
+	comm "github.com/dingodb/dingoadm/internal/common"
+	"github.com/dingodb/dingoadm/internal/configure/topology"
+	"github.com/dingodb/dingoadm/internal/errno"
+	"github.com/dingodb/dingoadm/internal/playbook"
+	task "github.com/dingodb/dingoadm/internal/task/task/common"
+	cliutil "github.com/dingodb/dingoadm/internal/utils"
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+const (
+	LOGS_EXAMPLE = `Examples:
+  $ dingoadm logs --role store --tail 50               # Display last 50 lines of container logs for stores
+  $ dingoadm logs -f --grep ERROR                      # Follow logs of all services which contains ERROR
+  $ dingoadm logs --host server-1 --since 10m          # Display logs in last 10 minutes on server-1
+  $ dingoadm logs --id <id> --source file --file '*.INFO'  # Display log files under log dir`
+
+	// lines in follow mode are buffered for a while to interleave by timestamp
+	LOGS_FLUSH_INTERVAL = 500 * time.Millisecond
+	LOGS_BUFFER_SIZE    = 1024
+)
+
+var (
+	LOGS_PREFIX_COLORS = []color.Attribute{
+		color.FgCyan,
+		color.FgGreen,
+		color.FgYellow,
+		color.FgBlue,
+		color.FgMagenta,
+	}
+)
+
+type logsOptions struct {
+	id     string
+	role   string
+	host   string
+	follow bool
+	since  string
+	grep   string
+	tail   int
+	source string
+	files  []string
+}
+
+func NewLogsCommand(dingoadm *cli.DingoAdm) *cobra.Command {
+	var options logsOptions
+
+	cmd := &cobra.Command{
+		Use:     "logs [OPTIONS]",
+		Short:   "Display logs of services",
+		Args:    cliutil.NoArgs,
+		Example: LOGS_EXAMPLE,
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			switch options.source {
+			case task.LOG_SOURCE_CONTAINER, task.LOG_SOURCE_FILE, task.LOG_SOURCE_ALL:
+			default:
+				return errno.ERR_UNSUPPORT_LOG_SOURCE.F("source: %s", options.source)
+			}
+			if _, err := regexp.Compile(options.grep); err != nil {
+				return errno.ERR_INVALID_LOG_GREP_PATTERN.E(err)
+			}
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runLogs(dingoadm, options)
+		},
+		DisableFlagsInUseLine: true,
+	}
+
+	flags := cmd.Flags()
+	flags.StringVar(&options.id, "id", "*", "Specify service id")
+	flags.StringVar(&options.role, "role", "*", "Specify service role")
+	flags.StringVar(&options.host, "host", "*", "Specify service host")
+	flags.BoolVarP(&options.follow, "follow", "f", false, "Follow log output until interrupted")
+	flags.StringVar(&options.since, "since", "", "Only display logs since relative duration (e.g. 10m) or timestamp (e.g. '2025-01-01 12:00:00')")
+	flags.StringVar(&options.grep, "grep", "", "Only display lines which match specified regular expression")
+	flags.IntVar(&options.tail, "tail", 100, "Number of lines to display from the end of logs for each service, -1 means all")
+	flags.StringVar(&options.source, "source", task.LOG_SOURCE_CONTAINER, "Specify log source: container, file or all")
+	flags.StringSliceVar(&options.files, "file", []string{"*.INFO", "*.log"}, "Specify log file patterns under log dir for file source")
+
+	return cmd
+}
+
+func genLogsPlaybook(dingoadm *cli.DingoAdm,
+	dcs []*topology.DeployConfig,
+	logOptions task.LogOptions) (*playbook.Playbook, error) {
+	pb := playbook.NewPlaybook(dingoadm)
+	pb.AddStep(&playbook.PlaybookStep{
+		Type:    playbook.STREAM_LOGS,
+		Configs: dcs,
+		Options: map[string]interface{}{
+			comm.KEY_LOG_OPTIONS: logOptions,
+		},
+		ExecOptions: playbook.ExecOptions{
+			Concurrency:   uint(len(dcs)), // all services are streamed at the same time
+			SilentSubBar:  true,
+			SilentMainBar: true,
+			SkipError:     true,
+		},
+	})
+	return pb, nil
+}
+
+type logsPrinter struct {
+	dingoadm *cli.DingoAdm
+	grep     *regexp.Regexp
+	since    time.Time
+	width    int
+	colors   map[string]*color.Color
+	buffer   []task.LogLine
+}
+
+func newLogsPrinter(dingoadm *cli.DingoAdm, dcs []*topology.DeployConfig, grep *regexp.Regexp, since time.Time) *logsPrinter {
+	p := &logsPrinter{
+		dingoadm: dingoadm,
+		grep:     grep,
+		since:    since,
+		colors:   map[string]*color.Color{},
+	}
+	for i, dc := range dcs {
+		prefix := task.GetLogPrefix(dc)
+		if len(prefix) > p.width {
+			p.width = len(prefix)
+		}
+		p.colors[prefix] = color.New(LOGS_PREFIX_COLORS[i%len(LOGS_PREFIX_COLORS)])
+	}
+	return p
+}
+
+// the line without timestamp is kept for since, it maybe a part of previous line
+func (p *logsPrinter) add(line task.LogLine) {
+	if !line.Time.IsZero() && line.Time.Before(p.since) {
+		return
+	} else if !p.grep.MatchString(line.Text) {
+		return
+	}
+	p.buffer = append(p.buffer, line)
+}
+
+func (p *logsPrinter) flush() {
+	task.SortLogLines(p.buffer)
+	for _, line := range p.buffer {
+		prefix := fmt.Sprintf("%-*s |", p.width, line.Prefix)
+		if c, ok := p.colors[line.Prefix]; ok {
+			prefix = c.Sprint(prefix)
+		}
+		p.dingoadm.WriteOutln("%s %s", prefix, line.Text)
+	}
+	p.buffer = p.buffer[:0]
+}
+
+/*
+ * logs are streamed from all matched services concurrently:
+ *   1) without follow, all lines are sorted by timestamp and displayed at the end
+ *   2) with follow, lines are buffered for LOGS_FLUSH_INTERVAL and displayed
+ *      in timestamp order, until interrupted
+ */
+func runLogs(dingoadm *cli.DingoAdm, options logsOptions) error {
+	// 1) parse cluster topology
+	dcs, err := dingoadm.ParseTopology()
+	if err != nil {
+		return err
+	}
+
+	dcs = dingoadm.FilterDeployConfig(dcs, topology.FilterOption{
+		Id:   options.id,
+		Role: options.role,
+		Host: options.host,
+	})
+	if len(dcs) == 0 {
+		return errno.ERR_NO_SERVICES_MATCHED
+	}
+
+	// 2) parse options
+	since := time.Time{}
+	if len(options.since) > 0 {
+		if since, err = task.ParseSince(options.since, time.Now()); err != nil {
+			return err
+		}
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	lines := make(chan task.LogLine, LOGS_BUFFER_SIZE)
+	logOptions := task.LogOptions{
+		Context: ctx,
+		Lines:   lines,
+		Source:  options.source,
+		Files:   options.files,
+		Since:   since,
+		Tail:    options.tail,
+		Follow:  options.follow,
+	}
+
+	// 3) generate logs playbook
+	pb, err := genLogsPlaybook(dingoadm, dcs, logOptions)
+	if err != nil {
+		return err
+	}
+
+	// 4) run playbook in background, lines are closed after all tasks finished
+	done := make(chan error, 1)
+	go func() {
+		done <- pb.Run()
+		close(lines)
+	}()
+
+	// 5) display lines until all tasks finished or interrupted
+	sigs := make(chan os.Signal, 1)
+	signal.Notify(sigs, os.Interrupt, syscall.SIGTERM)
+	defer signal.Stop(sigs)
+	ticker := time.NewTicker(LOGS_FLUSH_INTERVAL)
+	defer ticker.Stop()
+
+	printer := newLogsPrinter(dingoadm, dcs, regexp.MustCompile(options.grep), since)
+	for {
+		select {
+		case line, ok := <-lines:
+			if !ok {
+				printer.flush()
+				if err := <-done; err != nil && ctx.Err() == nil {
+					return err
+				}
+				return nil
+			}
+			printer.add(line)
+		case <-ticker.C:
+			if options.follow {
+				printer.flush()
+			}
+		case <-sigs:
+			cancel()
+		}
+	}
+}
@@ -97,12 +97,54 @@ var (
 		playbook.CREATE_LOGICAL_POOL,
 	}
 
+	// coordinator (dingo-store), add the new member before the old one removed
+	// to keep the majority of raft group
+	MIGRATE_COORDINATOR_STEPS = []int{
+		playbook.PULL_IMAGE,
+		playbook.CREATE_CONTAINER,
+		playbook.SYNC_CONFIG,
+		playbook.START_COORDINATOR,
+		playbook.ADD_COORDINATOR_PEER,
+		playbook.REMOVE_COORDINATOR_PEER, // wait catch up and transfer leader
+		playbook.STOP_SERVICE,
+		playbook.CLEAN_SERVICE,
+		playbook.UPDATE_TOPOLOGY,
+	}
+
+	// store (dingo-store), regions are migrated to the new store by drain
+	MIGRATE_STORE_STEPS = []int{
+		playbook.PULL_IMAGE,
+		playbook.CREATE_CONTAINER,
+		playbook.SYNC_CONFIG,
+		playbook.START_STORE,
+		playbook.WAIT_STORE_REGISTERED,
+		playbook.CHECK_STORE_HEALTH,
+		playbook.DRAIN_SERVICE,
+		playbook.STOP_SERVICE,
+		playbook.CLEAN_SERVICE,
+		playbook.UPDATE_TOPOLOGY,
+	}
+
+	// mds v2 (dingofs)
+	MIGRATE_MDSV2_STEPS = []int{
+		playbook.PULL_IMAGE,
+		playbook.CREATE_CONTAINER,
+		playbook.SYNC_CONFIG,
+		playbook.START_FS_MDS,
+		playbook.DRAIN_SERVICE,
+		playbook.STOP_SERVICE,
+		playbook.CLEAN_SERVICE,
+		playbook.UPDATE_TOPOLOGY,
+	}
+
 	MIGRATE_ROLE_STEPS = map[string][]int{
 		topology.ROLE_ETCD:          MIGRATE_ETCD_STEPS,
 		topology.ROLE_FS_MDS:        MIGRATE_MDS_STEPS,
 		topology.ROLE_CHUNKSERVER:   MIGRATE_CHUNKSERVER_STEPS,
 		topology.ROLE_SNAPSHOTCLONE: MIGRATE_SNAPSHOTCLONE_STEPS,
 		topology.ROLE_METASERVER:    MIGRATE_METASERVER_STEPS,
+		topology.ROLE_COORDINATOR:   MIGRATE_COORDINATOR_STEPS,
+		topology.ROLE_STORE:         MIGRATE_STORE_STEPS,
 	}
 )
 
@@ -166,13 +208,32 @@ func checkMigrateTopology(curveadm *cli.DingoAdm, data string) error {
 		return errno.ERR_REQUIRE_WHOLE_HOST_SERVICES_FOR_MIGRATING
 	}
 
+	// the new service runs together with the old one before it removed,
+	// so they can't share the same instance id
+	if isDingoStoreMigrate(dcs2add[0]) {
+		return checkInstanceIdConflict(curveadm, dcs2add)
+	}
 	return nil
 }
 
+func isDingoStoreMigrate(dc *topology.DeployConfig) bool {
+	role := dc.GetRole()
+	return role == topology.ROLE_COORDINATOR || role == topology.ROLE_STORE || isMdsv2(dc)
+}
+
+func getMigrateSteps(dc *topology.DeployConfig) []int {
+	if isMdsv2(dc) {
+		return MIGRATE_MDSV2_STEPS
+	}
+	return MIGRATE_ROLE_STEPS[dc.GetRole()]
+}
+
 func getMigrates(curveadm *cli.DingoAdm, data string) []*configure.MigrateServer {
 	diffs, _ := diffTopology(curveadm, data)
-	dcs2add := diffs[topology.DIFF_ADD]
-	dcs2del := diffs[topology.DIFF_DELETE]
+	return pairMigrates(diffs[topology.DIFF_ADD], diffs[topology.DIFF_DELETE])
+}
+
+func pairMigrates(dcs2add, dcs2del []*topology.DeployConfig) []*configure.MigrateServer {
 	configure.SortDeployConfigs(dcs2add)
 	configure.SortDeployConfigs(dcs2del)
 
@@ -188,13 +249,18 @@ func getMigrates(curveadm *cli.DingoAdm, data string) []*configure.MigrateServer
 }
 
 func genMigratePlaybook(curveadm *cli.DingoAdm,
-	dcs []*topology.DeployConfig, options migrateOptions, data string) (*playbook.Playbook, error) {
-	diffs, _ := diffTopology(curveadm, data)
-	dcs2add := diffs[topology.DIFF_ADD]
-	dcs2del := diffs[topology.DIFF_DELETE]
-	migrates := getMigrates(curveadm, data)
-	role := migrates[0].From.GetRole()
-	steps := MIGRATE_ROLE_STEPS[role]
+	dcs []*topology.DeployConfig,
+	migrates []*configure.MigrateServer,
+	options migrateOptions,
+	data string) (*playbook.Playbook, error) {
+	dcs2add := []*topology.DeployConfig{}
+	dcs2del := []*topology.DeployConfig{}
+	for _, migrate := range migrates {
+		dcs2add = append(dcs2add, migrate.To)
+		dcs2del = append(dcs2del, migrate.From)
+	}
+	steps := getMigrateSteps(migrates[0].From)
+	dingoStore := isDingoStoreMigrate(migrates[0].From)
 	poolset := options.poolset
 	poolsetDiskType := options.poolsetDiskType
 
@@ -204,13 +270,17 @@ func genMigratePlaybook(curveadm *cli.DingoAdm,
 		config := dcs2add
 		switch step {
 		case playbook.STOP_SERVICE,
-			playbook.CLEAN_SERVICE:
+			playbook.CLEAN_SERVICE,
+			playbook.DRAIN_SERVICE,
+			playbook.REMOVE_COORDINATOR_PEER:
 			config = dcs2del
 		case playbook.BACKUP_ETCD_DATA:
 			config = curveadm.FilterDeployConfigByRole(dcs, topology.ROLE_ETCD)
 		case CREATE_PHYSICAL_POOL,
 			CREATE_LOGICAL_POOL:
 			config = curveadm.FilterDeployConfigByRole(dcs, topology.ROLE_FS_MDS)[:1]
+		case playbook.CHECK_STORE_HEALTH:
+			config = dcs2add[:1]
 		}
 
 		// options
@@ -219,6 +289,22 @@ func genMigratePlaybook(curveadm *cli.DingoAdm,
 		case playbook.CLEAN_SERVICE:
 			options[comm.KEY_CLEAN_ITEMS] = []string{comm.CLEAN_ITEM_CONTAINER}
 			options[comm.KEY_CLEAN_BY_RECYCLE] = true
+			if dingoStore { // data already moved to the new service
+				options[comm.KEY_CLEAN_ITEMS] = getRemovedServiceCleanItems(migrates[0].From.GetRole())
+				options[comm.KEY_CLEAN_BY_RECYCLE] = false
+			}
+		case playbook.CREATE_CONTAINER:
+			// the new mds v2 joins a running cluster like scale-out,
+			// MUST NOT set it for others because the pool creation depends on it
+			if isMdsv2(migrates[0].From) {
+				options[comm.KEY_SCALE_OUT_CLUSTER] = dcs2add
+			}
+		case playbook.ADD_COORDINATOR_PEER:
+			options[comm.KEY_EXISTING_COORDINATORS] = curveadm.FilterDeployConfigByRole(dcs, topology.ROLE_COORDINATOR)
+		case playbook.REMOVE_COORDINATOR_PEER:
+			options[comm.KEY_MIGRATE_SERVERS] = migrates
+		case playbook.DRAIN_SERVICE:
+			options[comm.KEY_ALL_DEPLOY_CONFIGS] = dcs
 		case playbook.CREATE_PHYSICAL_POOL:
 			options[comm.KEY_CREATE_POOL_TYPE] = comm.POOL_TYPE_PHYSICAL
 			options[comm.KEY_MIGRATE_SERVERS] = migrates
@@ -234,13 +320,21 @@ func genMigratePlaybook(curveadm *cli.DingoAdm,
 			options[comm.KEY_NEW_TOPOLOGY_DATA] = data
 		}
 
+		// exec options
+		execOptions := playbook.ExecOptions{
+			SilentSubBar: step == playbook.UPDATE_TOPOLOGY,
+		}
+		switch step {
+		case playbook.ADD_COORDINATOR_PEER,
+			playbook.REMOVE_COORDINATOR_PEER,
+			playbook.DRAIN_SERVICE:
+			execOptions.Concurrency = 1 // change membership one by one
+		}
 		pb.AddStep(&playbook.PlaybookStep{
-			Type:    step,
-			Configs: config,
-			Options: options,
-			ExecOptions: playbook.ExecOptions{
-				SilentSubBar: step == playbook.UPDATE_TOPOLOGY,
-			},
+			Type:        step,
+			Configs:     config,
+			Options:     options,
+			ExecOptions: execOptions,
 		})
 	}
 	return pb, nil
@@ -286,18 +380,25 @@ func runMigrate(curveadm *cli.DingoAdm, options migrateOptions) error {
 	}
 
 	// 6) generate migrate playbook
-	pb, err := genMigratePlaybook(curveadm, dcs, options, data)
+	pb, err := genMigratePlaybook(curveadm, dcs, getMigrates(curveadm, data), options, data)
 	if err != nil {
 		return err
 	}
 
 	// 8) run playground
+	diffs, _ := diffTopology(curveadm, data)
+	dcs2del := diffs[topology.DIFF_DELETE] // topology will be updated by playbook
 	err = pb.Run()
 	if err != nil {
 		return err
 	}
 
-	// 9) print success prompt
+	// 9) delete migrated services from database
+	if err = deleteRemovedServices(curveadm, dcs2del); err != nil {
+		return err
+	}
+
+	// 10) print success prompt
 	curveadm.WriteOutln("")
 	curveadm.WriteOutln(color.GreenString("Services successfully migrateed ^_^."))
 	// TODO(P1): warning iff there is changed configs
@@ -0,0 +1,128 @@
+package command
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/dingodb/dingoadm/cli/cli"
+	comm "github.com/dingodb/dingoadm/internal/common"
+	"github.com/dingodb/dingoadm/internal/configure"
+	"github.com/dingodb/dingoadm/internal/configure/topology"
+	"github.com/dingodb/dingoadm/internal/playbook"
+	"github.com/stretchr/testify/assert"
+)
+
+func getTestMigrates(t *testing.T, oldData, data string) []*configure.MigrateServer {
+	return pairMigrates(getAddedServices(t, oldData, data), getAddedServices(t, data, oldData))
+}
+
+func TestGenMigratePlaybookCoordinator(t *testing.T) {
+	assert := assert.New(t)
+	curveadm := &cli.DingoAdm{}
+
+	data := strings.Replace(SCALE_OUT_OLD_TOPOLOGY,
+		"coordinator_services:\n  deploy:\n    - host: host1\n    - host: host2\n    - host: host3\n",
+		"coordinator_services:\n  deploy:\n    - host: host1\n    - host: host2\n    - host: host4\n"+
+			"      config:\n        instance_start_id: 1004\n", 1)
+	dcs := parseTestTopology(t, SCALE_OUT_OLD_TOPOLOGY)
+	migrates := getTestMigrates(t, SCALE_OUT_OLD_TOPOLOGY, data)
+	assert.Len(migrates, 1)
+	from, to := migrates[0].From, migrates[0].To
+	assert.Equal("host3", from.GetHost())
+	assert.Equal("host4", to.GetHost())
+	assert.Nil(checkInstanceIdUnique(curveadm, dcs, []*topology.DeployConfig{to}))
+
+	pb, err := genMigratePlaybook(curveadm, dcs, migrates, migrateOptions{}, data)
+	assert.Nil(err)
+	assert.Equal(MIGRATE_COORDINATOR_STEPS, getStepTypes(pb))
+
+	// new coordinator joins before the old one removed
+	step := getStep(pb, playbook.ADD_COORDINATOR_PEER)
+	assert.Equal([]*topology.DeployConfig{to}, step.Configs)
+	assert.Equal(uint(1), step.ExecOptions.Concurrency)
+	assert.Equal(curveadm.FilterDeployConfigByRole(dcs, topology.ROLE_COORDINATOR),
+		step.Options[comm.KEY_EXISTING_COORDINATORS])
+
+	step = getStep(pb, playbook.REMOVE_COORDINATOR_PEER)
+	assert.Equal([]*topology.DeployConfig{from}, step.Configs)
+	assert.Equal(uint(1), step.ExecOptions.Concurrency)
+	assert.Equal(migrates, step.Options[comm.KEY_MIGRATE_SERVERS])
+
+	for _, stepType := range []int{playbook.STOP_SERVICE, playbook.CLEAN_SERVICE} {
+		assert.Equal([]*topology.DeployConfig{from}, getStep(pb, stepType).Configs)
+	}
+	step = getStep(pb, playbook.CLEAN_SERVICE)
+	assert.Equal(getRemovedServiceCleanItems(topology.ROLE_COORDINATOR), step.Options[comm.KEY_CLEAN_ITEMS])
+	assert.Equal(false, step.Options[comm.KEY_CLEAN_BY_RECYCLE])
+	assert.Equal(data, getStep(pb, playbook.UPDATE_TOPOLOGY).Options[comm.KEY_NEW_TOPOLOGY_DATA])
+}
+
+func TestGenMigratePlaybookStore(t *testing.T) {
+	assert := assert.New(t)
+	curveadm := &cli.DingoAdm{}
+
+	data := strings.Replace(SCALE_OUT_OLD_TOPOLOGY,
+		"store_services:\n  deploy:\n    - host: host1\n    - host: host2\n    - host: host3\n",
+		"store_services:\n  deploy:\n    - host: host1\n    - host: host2\n    - host: host4\n"+
+			"      config:\n        instance_start_id: 1004\n", 1)
+	dcs := parseTestTopology(t, SCALE_OUT_OLD_TOPOLOGY)
+	migrates := getTestMigrates(t, SCALE_OUT_OLD_TOPOLOGY, data)
+	assert.Len(migrates, 1)
+	from, to := migrates[0].From, migrates[0].To
+
+	pb, err := genMigratePlaybook(curveadm, dcs, migrates, migrateOptions{}, data)
+	assert.Nil(err)
+	assert.Equal(MIGRATE_STORE_STEPS, getStepTypes(pb))
+
+	// regions move to the new store which is healthy
+	assert.Equal([]*topology.DeployConfig{to}, getStep(pb, playbook.WAIT_STORE_REGISTERED).Configs)
+	assert.Equal([]*topology.DeployConfig{to}, getStep(pb, playbook.CHECK_STORE_HEALTH).Configs)
+	step := getStep(pb, playbook.DRAIN_SERVICE)
+	assert.Equal([]*topology.DeployConfig{from}, step.Configs)
+	assert.Equal(dcs, step.Options[comm.KEY_ALL_DEPLOY_CONFIGS])
+	assert.Equal(uint(1), step.ExecOptions.Concurrency)
+	assert.Equal(getRemovedServiceCleanItems(topology.ROLE_STORE),
+		getStep(pb, playbook.CLEAN_SERVICE).Options[comm.KEY_CLEAN_ITEMS])
+}
+
+func TestGenMigratePlaybookMdsv2(t *testing.T) {
+	assert := assert.New(t)
+	curveadm := &cli.DingoAdm{}
+
+	oldData := `
+kind: dingofs
+coordinator_services:
+  deploy:
+    - host: host1
+store_services:
+  deploy:
+    - host: host1
+    - host: host2
+    - host: host3
+mds_services:
+  deploy:
+    - host: host1
+    - host: host2
+executor_services:
+  deploy:
+    - host: host1
+`
+	data := strings.Replace(oldData, "    - host: host1\n    - host: host2\nexecutor_services",
+		"    - host: host1\n    - host: host3\n      config:\n        instance_start_id: 1003\nexecutor_services", 1)
+	dcs := parseTestTopology(t, oldData)
+	migrates := getTestMigrates(t, oldData, data)
+	assert.Len(migrates, 1)
+	from, to := migrates[0].From, migrates[0].To
+	assert.True(isMdsv2(from))
+	assert.Equal("host3", to.GetHost())
+
+	pb, err := genMigratePlaybook(curveadm, dcs, migrates, migrateOptions{}, data)
+	assert.Nil(err)
+	assert.Equal(MIGRATE_MDSV2_STEPS, getStepTypes(pb))
+	assert.Equal([]*topology.DeployConfig{to}, getStep(pb, playbook.START_FS_MDS).Configs)
+	assert.Equal([]*topology.DeployConfig{from}, getStep(pb, playbook.DRAIN_SERVICE).Configs)
+	assert.Equal([]*topology.DeployConfig{to},
+		getStep(pb, playbook.CREATE_CONTAINER).Options[comm.KEY_SCALE_OUT_CLUSTER])
+	assert.Equal(getRemovedServiceCleanItems(topology.ROLE_FS_MDS),
+		getStep(pb, playbook.CLEAN_SERVICE).Options[comm.KEY_CLEAN_ITEMS])
+}
@@ -28,6 +28,8 @@ package monitor
 
 import (
 	"fmt"
+	"sort"
+	"strings"
 
 	"github.com/dingodb/dingoadm/cli/cli"
 	comm "github.com/dingodb/dingoadm/internal/common"
@@ -35,6 +37,7 @@ import (
 	"github.com/dingodb/dingoadm/internal/errno"
 	"github.com/dingodb/dingoadm/internal/playbook"
 	"github.com/dingodb/dingoadm/internal/task/task/monitor"
+	"github.com/dingodb/dingoadm/internal/tui/output"
 	tui "github.com/dingodb/dingoadm/internal/tui/service"
 	cliutil "github.com/dingodb/dingoadm/internal/utils"
 	"github.com/spf13/cobra"
@@ -52,6 +55,7 @@ type statusOptions struct {
 	role    string
 	host    string
 	verbose bool
+	format  string
 }
 
 func NewStatusCommand(dingoadm *cli.DingoAdm) *cobra.Command {
@@ -60,6 +64,9 @@ func NewStatusCommand(dingoadm *cli.DingoAdm) *cobra.Command {
 		Use:   "status [OPTIONS]",
 		Short: "Display monitor services status",
 		Args:  cliutil.NoArgs,
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			return output.CheckFormat(options.format)
+		},
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return runStatus(dingoadm, options)
 		},
@@ -71,6 +78,7 @@ func NewStatusCommand(dingoadm *cli.DingoAdm) *cobra.Command {
 	flags.StringVar(&options.role, "role", "*", "Specify monitor service role")
 	flags.StringVar(&options.host, "host", "*", "Specify monitor service host")
 	flags.BoolVarP(&options.verbose, "verbose", "v", false, "Verbose output for status")
+	flags.StringVar(&options.format, "format", output.FORMAT_TABLE, output.FLAG_FORMAT_USAGE)
 	return cmd
 }
 
@@ -94,7 +102,7 @@ func genStatusPlaybook(dingoadm *cli.DingoAdm,
 			Configs: mcs,
 			ExecOptions: playbook.ExecOptions{
 				SilentSubBar:  true,
-				SilentMainBar: step == playbook.INIT_MONITOR_STATUS,
+				SilentMainBar: step == playbook.INIT_MONITOR_STATUS || output.IsStructured(options.format),
 				SkipError:     true,
 			},
 		})
@@ -102,7 +110,7 @@ func genStatusPlaybook(dingoadm *cli.DingoAdm,
 	return pb, nil
 }
 
-func displayStatus(dingoadm *cli.DingoAdm, mcs []*configure.MonitorConfig, options statusOptions) {
+func getMonitorStatuses(dingoadm *cli.DingoAdm) []monitor.MonitorStatus {
 	statuses := []monitor.MonitorStatus{}
 	value := dingoadm.MemStorage().Get(comm.KEY_MONITOR_STATUS)
 	if value != nil {
@@ -111,6 +119,39 @@ func displayStatus(dingoadm *cli.DingoAdm, mcs []*configure.MonitorConfig, optio
 			statuses = append(statuses, status)
 		}
 	}
+	return statuses
+}
+
+func checkMonitorStatuses(statuses []monitor.MonitorStatus) error {
+	unhealthy := []string{}
+	for _, status := range statuses {
+		if !strings.HasPrefix(status.Status, "Up") {
+			unhealthy = append(unhealthy, status.Id)
+		}
+	}
+	if len(unhealthy) > 0 {
+		sort.Strings(unhealthy)
+		return errno.ERR_UNHEALTHY_SERVICES_FOUND.F("services: %s", strings.Join(unhealthy, ","))
+	}
+	return nil
+}
+
+func displayStructuredStatus(dingoadm *cli.DingoAdm, statuses []monitor.MonitorStatus, options statusOptions) error {
+	sort.Slice(statuses, func(i, j int) bool {
+		return statuses[i].Id < statuses[j].Id
+	})
+	out, err := output.Marshal(options.format, statuses)
+	if err != nil {
+		return err
+	}
+	dingoadm.WriteOut("%s", out)
+	return nil
+}
+
+func displayStatus(dingoadm *cli.DingoAdm,
+	mcs []*configure.MonitorConfig,
+	statuses []monitor.MonitorStatus,
+	options statusOptions) {
 	// flite grafana role monitor config
 	var grafanaAddr string
 	for _, mc := range mcs {
@@ -123,13 +164,13 @@ func displayStatus(dingoadm *cli.DingoAdm, mcs []*configure.MonitorConfig, optio
 		}
 	}
 
-	output := tui.FormatMonitorStatus(statuses, options.verbose)
+	out := tui.FormatMonitorStatus(statuses, options.verbose)
 	dingoadm.WriteOutln("")
 	dingoadm.WriteOutln("cluster name    : %s", dingoadm.ClusterName())
 	dingoadm.WriteOutln("cluster kind    : %s", mcs[0].GetKind())
 	dingoadm.WriteOutln("grafana address : %s", grafanaAddr)
 	dingoadm.WriteOutln("")
-	dingoadm.WriteOut("%s", output)
+	dingoadm.WriteOut("%s", out)
 }
 
 func runStatus(dingoadm *cli.DingoAdm, options statusOptions) error {
@@ -149,7 +190,22 @@ func runStatus(dingoadm *cli.DingoAdm, options statusOptions) error {
 	err = pb.Run()
 
 	// 4) display service status
-	displayStatus(dingoadm, mcs, options)
-	return err
+	statuses := getMonitorStatuses(dingoadm)
+	if output.IsStructured(options.format) {
+		if derr := displayStructuredStatus(dingoadm, statuses, options); derr != nil {
+			return derr
+		}
+	} else {
+		displayStatus(dingoadm, mcs, statuses, options)
+	}
+	if err != nil {
+		return err
+	}
 
+	// 5) exit with error if any service not running, only for structured
+	//    output which is consumed by automation
+	if !output.IsStructured(options.format) {
+		return nil
+	}
+	return checkMonitorStatuses(statuses)
 }
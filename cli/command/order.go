@@ -0,0 +1,241 @@
+/*
+ *  Copyright (c) 2025 dingodb.com Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: dingoadm
+ * Author: dongwei (jackblack369)
+ */
+
+package command
+
+import (
+	"github.com/dingodb/dingoadm/cli/cli"
+	comm "github.com/dingodb/dingoadm/internal/common"
+	"github.com/dingodb/dingoadm/internal/configure/topology"
+	"github.com/dingodb/dingoadm/internal/playbook"
+	"github.com/dingodb/dingoadm/internal/utils"
+)
+
+/*
+ * Dependency-aware ordering for start/stop/restart:
+ *
+ * The role order is taken from deploy steps of cluster, e.g.
+ *   coordinator -> store -> (check store health) -> mds -> executor -> web/proxy
+ * services are started/restarted stage by stage in this order, and stopped
+ * in reverse order. Roles which not in deploy steps are put at the end.
+ *
+ * Services which hold leaders (coordinator, store, mds v2) transfer their
+ * leaders away before restarted or stopped, and they are operated one by one.
+ * In rolling restart, each of them must be healthy again before the leaders
+ * of next one transferred.
+ */
+
+var (
+	// deploy steps which start services of one role
+	ORDER_START_STEPS = map[int]bool{
+		START_ETCD:                true,
+		START_MDS:                 true,
+		START_CHUNKSERVER:         true,
+		START_SNAPSHOTCLONE:       true,
+		START_METASERVER:          true,
+		START_MDSV2:               true,
+		START_COORDINATOR:         true,
+		START_STORE:               true,
+		START_MDSV2_CLI_CONTAINER: true,
+		START_DINGODB_EXECUTOR:    true,
+		START_DINGODB_DOCUMENT:    true,
+		START_DINGODB_INDEX:       true,
+		START_DINGODB_DISKANN:     true,
+		START_DINGODB_WEB:         true,
+		START_DINGODB_PROXY:       true,
+	}
+
+	// roles which hold leaders, the mds only for v2
+	DRAIN_LEADER_ROLES = map[string]bool{
+		topology.ROLE_COORDINATOR: true,
+		topology.ROLE_STORE:       true,
+		topology.ROLE_FS_MDS:      true,
+	}
+)
+
+type orderStage struct {
+	role        string
+	healthCheck bool // check store health instead of operating services
+}
+
+// return stages in start order for roles by deploy steps
+func getOrderStages(deploySteps []int, roles []string) []orderStage {
+	stages := []orderStage{}
+	ordered := map[string]bool{}
+	for _, step := range deploySteps {
+		if step == CHECK_STORE_HEALTH {
+			stages = append(stages, orderStage{healthCheck: true})
+			continue
+		} else if !ORDER_START_STEPS[step] {
+			continue
+		}
+
+		role := DEPLOY_FILTER_ROLE[step]
+		if !ordered[role] && utils.Contains(roles, role) {
+			stages = append(stages, orderStage{role: role})
+			ordered[role] = true
+		}
+	}
+
+	for _, role := range roles {
+		if !ordered[role] {
+			stages = append(stages, orderStage{role: role})
+			ordered[role] = true
+		}
+	}
+	return stages
+}
+
+func reverseOrderStages(stages []orderStage) []orderStage {
+	out := []orderStage{}
+	for i := len(stages) - 1; i >= 0; i-- {
+		if !stages[i].healthCheck {
+			out = append(out, stages[i])
+		}
+	}
+	return out
+}
+
+/*
+ * return services which should transfer leaders before operated, leaders can
+ * be transferred only if other services of the role keep alive:
+ *   rolling:     services are operated one by one, e.g. restart
+ *   not rolling: services are operated at once, e.g. stop, upgrade
+ */
+func getDrainServices(dingoadm *cli.DingoAdm,
+	dcs, all []*topology.DeployConfig,
+	rolling bool) []*topology.DeployConfig {
+	out := []*topology.DeployConfig{}
+	for _, dc := range dcs {
+		role := dc.GetRole()
+		if !DRAIN_LEADER_ROLES[role] {
+			continue
+		} else if role == topology.ROLE_FS_MDS && !isMdsv2(dc) { // mds v1 elects leader by etcd
+			continue
+		}
+
+		total := len(dingoadm.FilterDeployConfigByRole(all, role))
+		operated := len(dingoadm.FilterDeployConfigByRole(dcs, role))
+		if (rolling && total > 1) || (!rolling && operated < total) {
+			out = append(out, dc)
+		}
+	}
+	return out
+}
+
+// add steps which operate services, the services which hold leaders are
+// operated one by one after their leaders transferred if drain is enabled,
+// and leaders are never transferred to the services operated at once
+func addOperateSteps(dingoadm *cli.DingoAdm,
+	pb *playbook.Playbook,
+	step int,
+	dcs, all []*topology.DeployConfig,
+	drain bool,
+	options map[string]interface{}) {
+	rolling := step == playbook.RESTART_SERVICE
+	drains := []*topology.DeployConfig{}
+	if drain {
+		drains = getDrainServices(dingoadm, dcs, all, rolling)
+	}
+	drained := map[string]bool{}
+	for _, dc := range drains {
+		drained[dc.GetId()] = true
+	}
+
+	others := []*topology.DeployConfig{}
+	for _, dc := range dcs {
+		if !drained[dc.GetId()] {
+			others = append(others, dc)
+		}
+	}
+	if len(others) > 0 {
+		pb.AddStep(&playbook.PlaybookStep{
+			Type:    step,
+			Configs: others,
+			Options: options,
+		})
+	}
+
+	transferOptions := map[string]interface{}{
+		comm.KEY_ALL_DEPLOY_CONFIGS: all,
+	}
+	if !rolling {
+		transferOptions[comm.KEY_LEADER_EXCLUDE_CONFIGS] = dcs
+	}
+	for _, dc := range drains {
+		pb.AddStep(&playbook.PlaybookStep{
+			Type:    playbook.TRANSFER_LEADER,
+			Configs: []*topology.DeployConfig{dc},
+			Options: transferOptions,
+		})
+		pb.AddStep(&playbook.PlaybookStep{
+			Type:    step,
+			Configs: []*topology.DeployConfig{dc},
+			Options: options,
+		})
+		if rolling { // the next one transfers leaders to it
+			pb.AddStep(&playbook.PlaybookStep{
+				Type:    playbook.WAIT_SERVICE_HEALTHY,
+				Configs: []*topology.DeployConfig{dc},
+			})
+		}
+	}
+}
+
+/*
+ * generate ordered playbook for operating services, e.g. START_SERVICE:
+ *   dcs:  the filtered services which will be operated
+ *   all:  all services of cluster, for deciding deploy steps
+ *   reverse: stop services in reverse order
+ *   drain:   transfer leaders before services operated
+ */
+func genOrderedPlaybook(dingoadm *cli.DingoAdm,
+	step int,
+	dcs, all []*topology.DeployConfig,
+	reverse, drain bool) (*playbook.Playbook, error) {
+	deploySteps, err := getDeploySteps(dingoadm, all)
+	if err != nil {
+		return nil, err
+	}
+
+	stages := getOrderStages(deploySteps, dingoadm.GetRoles(dcs))
+	if reverse {
+		stages = reverseOrderStages(stages)
+	}
+
+	pb := playbook.NewPlaybook(dingoadm)
+	for _, stage := range stages {
+		if stage.healthCheck { // only after stores operated
+			stores := dingoadm.FilterDeployConfigByRole(dcs, topology.ROLE_STORE)
+			if len(stores) == 0 {
+				continue
+			}
+			pb.AddStep(&playbook.PlaybookStep{
+				Type:    playbook.CHECK_STORE_HEALTH,
+				Configs: stores[:1],
+			})
+			continue
+		}
+
+		addOperateSteps(dingoadm, pb, step, dingoadm.FilterDeployConfigByRole(dcs, stage.role), all, drain, nil)
+	}
+	return pb, nil
+}
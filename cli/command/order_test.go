@@ -0,0 +1,96 @@
+package command
+
+import (
+	"testing"
+
+	"github.com/dingodb/dingoadm/cli/cli"
+	"github.com/dingodb/dingoadm/internal/configure/topology"
+	"github.com/dingodb/dingoadm/internal/playbook"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetOrderStages(t *testing.T) {
+	assert := assert.New(t)
+
+	roles := []string{ROLE_DINGODB_EXECUTOR, ROLE_STORE, ROLE_COORDINATOR, ROLE_FS_MDS, ROLE_MDSV2_CLI}
+	stages := getOrderStages(DINGOFS_MDSV2_FOLLOW_DEPLOY_STEPS, roles)
+	assert.Equal([]orderStage{
+		{role: ROLE_COORDINATOR},
+		{role: ROLE_STORE},
+		{healthCheck: true},
+		{role: ROLE_MDSV2_CLI},
+		{role: ROLE_FS_MDS},
+		{role: ROLE_DINGODB_EXECUTOR},
+	}, stages)
+
+	assert.Equal([]orderStage{
+		{role: ROLE_DINGODB_EXECUTOR},
+		{role: ROLE_FS_MDS},
+		{role: ROLE_MDSV2_CLI},
+		{role: ROLE_STORE},
+		{role: ROLE_COORDINATOR},
+	}, reverseOrderStages(stages))
+}
+
+func TestGetOrderStagesUnknownRole(t *testing.T) {
+	assert := assert.New(t)
+
+	// roles which not in deploy steps are put at the end
+	stages := getOrderStages(DINGOSTORE_DEPLOY_STEPS, []string{ROLE_DINGODB_WEB, ROLE_STORE})
+	assert.Equal([]orderStage{
+		{role: ROLE_STORE},
+		{healthCheck: true},
+		{role: ROLE_DINGODB_WEB},
+	}, stages)
+}
+
+// services which hold leaders are restarted one by one, and each of them
+// is healthy before leaders of next one transferred
+func TestAddOperateStepsRolling(t *testing.T) {
+	assert := assert.New(t)
+	curveadm := &cli.DingoAdm{}
+
+	dcs := parseTestTopology(t, `
+kind: dingo-store
+coordinator_services:
+  deploy:
+    - host: host1
+    - host: host2
+executor_services:
+  deploy:
+    - host: host1
+`)
+	coordinators := curveadm.FilterDeployConfigByRole(dcs, topology.ROLE_COORDINATOR)
+	pb := playbook.NewPlaybook(curveadm)
+	addOperateSteps(curveadm, pb, playbook.RESTART_SERVICE, coordinators, dcs, true, nil)
+	assert.Equal([]int{
+		playbook.TRANSFER_LEADER, playbook.RESTART_SERVICE, playbook.WAIT_SERVICE_HEALTHY,
+		playbook.TRANSFER_LEADER, playbook.RESTART_SERVICE, playbook.WAIT_SERVICE_HEALTHY,
+	}, getStepTypes(pb))
+	for i, step := range pb.Steps() {
+		assert.Equal([]*topology.DeployConfig{coordinators[i/3]}, step.Configs)
+	}
+}
+
+// mds v1 elects leader by etcd, nothing to transfer
+func TestGetDrainServicesMdsv1(t *testing.T) {
+	assert := assert.New(t)
+	curveadm := &cli.DingoAdm{}
+
+	dcs := parseTestTopology(t, `
+kind: dingofs
+etcd_services:
+  deploy:
+    - host: host1
+mds_services:
+  deploy:
+    - host: host1
+    - host: host2
+metaserver_services:
+  deploy:
+    - host: host1
+`)
+	mds := curveadm.FilterDeployConfigByRole(dcs, topology.ROLE_FS_MDS)
+	assert.Len(mds, 2)
+	assert.Empty(getDrainServices(curveadm, mds, dcs, true))
+}
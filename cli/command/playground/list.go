@@ -27,6 +27,8 @@
 package playground
 
 import (
+	"sort"
+
 	"github.com/dingodb/dingoadm/cli/cli"
 	comm "github.com/dingodb/dingoadm/internal/common"
 	"github.com/dingodb/dingoadm/internal/errno"
@@ -34,11 +36,14 @@ import (
 	"github.com/dingodb/dingoadm/internal/storage"
 	pg "github.com/dingodb/dingoadm/internal/task/task/playground"
 	"github.com/dingodb/dingoadm/internal/tui"
+	"github.com/dingodb/dingoadm/internal/tui/output"
 	cliutil "github.com/dingodb/dingoadm/internal/utils"
 	"github.com/spf13/cobra"
 )
 
-type listOptions struct{}
+type listOptions struct {
+	format string
+}
 
 var GET_PLAYGROUND_STATUS_PLAYBOOK_STEPS = []int{
 	playbook.GET_PLAYGROUND_STATUS,
@@ -48,21 +53,28 @@ func NewListCommand(curveadm *cli.DingoAdm) *cobra.Command {
 	var options listOptions
 
 	cmd := &cobra.Command{
-		Use:     "ls",
+		Use:     "ls [OPTIONS]",
 		Aliases: []string{"list"},
 		Short:   "List playgrounds",
 		Args:    cliutil.NoArgs,
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			return output.CheckFormat(options.format)
+		},
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return runList(curveadm, options)
 		},
 		DisableFlagsInUseLine: true,
 	}
 
+	flags := cmd.Flags()
+	flags.StringVar(&options.format, "format", output.FORMAT_TABLE, output.FLAG_FORMAT_USAGE)
+
 	return cmd
 }
 
 func genListPlaybook(curveadm *cli.DingoAdm,
-	playgrounds []storage.Playground) (*playbook.Playbook, error) {
+	playgrounds []storage.Playground,
+	options listOptions) (*playbook.Playbook, error) {
 	configs := []interface{}{}
 	for _, playground := range playgrounds {
 		configs = append(configs, playground)
@@ -74,14 +86,15 @@ func genListPlaybook(curveadm *cli.DingoAdm,
 			Type:    step,
 			Configs: configs,
 			ExecOptions: playbook.ExecOptions{
-				SilentSubBar: true,
+				SilentSubBar:  true,
+				SilentMainBar: output.IsStructured(options.format),
 			},
 		})
 	}
 	return pb, nil
 }
 
-func displayPlaygrounds(curveadm *cli.DingoAdm) {
+func displayPlaygrounds(curveadm *cli.DingoAdm, options listOptions) error {
 	statuses := []pg.PlaygroundStatus{}
 	value := curveadm.MemStorage().Get(comm.KEY_ALL_PLAYGROUNDS_STATUS)
 	if value != nil {
@@ -91,8 +104,21 @@ func displayPlaygrounds(curveadm *cli.DingoAdm) {
 		}
 	}
 
-	output := tui.FormatPlayground(statuses)
-	curveadm.WriteOut(output)
+	if output.IsStructured(options.format) {
+		sort.Slice(statuses, func(i, j int) bool {
+			return statuses[i].Id < statuses[j].Id
+		})
+		out, err := output.Marshal(options.format, statuses)
+		if err != nil {
+			return err
+		}
+		curveadm.WriteOut("%s", out)
+		return nil
+	}
+
+	out := tui.FormatPlayground(statuses)
+	curveadm.WriteOut(out)
+	return nil
 }
 
 func runList(curveadm *cli.DingoAdm, options listOptions) error {
@@ -103,7 +129,7 @@ func runList(curveadm *cli.DingoAdm, options listOptions) error {
 	}
 
 	// 2) gen list playground
-	pb, err := genListPlaybook(curveadm, playgrounds)
+	pb, err := genListPlaybook(curveadm, playgrounds, options)
 	if err != nil {
 		return err
 	}
@@ -115,6 +141,5 @@ func runList(curveadm *cli.DingoAdm, options listOptions) error {
 	}
 
 	// 4) print playgrounds
-	displayPlaygrounds(curveadm)
-	return nil
+	return displayPlaygrounds(curveadm, options)
 }
@@ -0,0 +1,135 @@
+/*
+ *  Copyright (c) 2025 dingodb.com Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: dingoadm
+ * Author: dongwei (jackblack369)
+ */
+package command
+
+import (
+	"time"
+
+	"github.com/dingodb/dingoadm/cli/cli"
+	comm "github.com/dingodb/dingoadm/internal/common"
+	"github.com/dingodb/dingoadm/internal/configure/topology"
+	"github.com/dingodb/dingoadm/internal/errno"
+	"github.com/dingodb/dingoadm/internal/playbook"
+	task "github.com/dingodb/dingoadm/internal/task/task/common"
+	cliutil "github.com/dingodb/dingoadm/internal/utils"
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+const (
+	PROFILE_EXAMPLE = `Examples:
+  $ dingoadm profile --id <id>                                 # Fetch 30s cpu profile of service
+  $ dingoadm profile --id <id> --type heap                     # Fetch heap profile of service
+  $ dingoadm profile --id <id> --type contention --duration 1m # Fetch 1 minute contention profile of service
+  $ dingoadm profile --id <id> --flamegraph --dir /tmp         # Fetch cpu profile and flamegraph into /tmp`
+)
+
+type profileOptions struct {
+	id         string
+	typ        string
+	duration   time.Duration
+	dir        string
+	flamegraph bool
+}
+
+func NewProfileCommand(dingoadm *cli.DingoAdm) *cobra.Command {
+	var options profileOptions
+
+	cmd := &cobra.Command{
+		Use:     "profile [OPTIONS]",
+		Short:   "Profile service by brpc builtin services",
+		Args:    cliutil.NoArgs,
+		Example: PROFILE_EXAMPLE,
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			if !task.IsValidProfileType(options.typ) {
+				return errno.ERR_UNSUPPORT_PROFILE_TYPE.F("type: %s", options.typ)
+			} else if options.duration < time.Second {
+				return errno.ERR_INVALID_PROFILE_DURATION.F("duration: %s", options.duration)
+			}
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runProfile(dingoadm, options)
+		},
+		DisableFlagsInUseLine: true,
+	}
+
+	flags := cmd.Flags()
+	flags.StringVar(&options.id, "id", "", "Specify service id")
+	flags.StringVar(&options.typ, "type", task.PROFILE_TYPE_CPU, "Specify profile type: cpu, heap or contention")
+	flags.DurationVar(&options.duration, "duration", 30*time.Second, "Specify sampling duration for cpu and contention profile")
+	flags.StringVar(&options.dir, "dir", ".", "Specify directory to save profile")
+	flags.BoolVar(&options.flamegraph, "flamegraph", false, "Render flamegraph SVG by brpc hotspots service as well, which samples again for the duration")
+	cmd.MarkFlagRequired("id")
+
+	return cmd
+}
+
+func runProfile(dingoadm *cli.DingoAdm, options profileOptions) error {
+	// 1) parse cluster topology
+	dcs, err := dingoadm.ParseTopology()
+	if err != nil {
+		return err
+	}
+
+	// 2) filter service, profile one service at a time
+	dcs = dingoadm.FilterDeployConfig(dcs, topology.FilterOption{
+		Id:   options.id,
+		Role: "*",
+		Host: "*",
+	})
+	if len(dcs) == 0 {
+		return errno.ERR_NO_SERVICES_MATCHED
+	} else if len(dcs) > 1 {
+		return errno.ERR_PROFILE_REQUIRES_ONE_SERVICE.F("matched services: %d", len(dcs))
+	}
+	dir := cliutil.AbsPath(options.dir)
+	if !cliutil.PathExist(dir) {
+		return errno.ERR_PROFILE_SAVE_DIR_NOT_EXIST.F("dir: %s", dir)
+	}
+
+	// 3) fetch profile
+	pb := playbook.NewPlaybook(dingoadm)
+	pb.AddStep(&playbook.PlaybookStep{
+		Type:    playbook.PROFILE_SERVICE,
+		Configs: dcs,
+		Options: map[string]interface{}{
+			comm.KEY_PROFILE_OPTIONS: task.ProfileOptions{
+				Type:       options.typ,
+				Duration:   options.duration,
+				SaveDir:    dir,
+				Flamegraph: options.flamegraph,
+				Timestamp:  time.Now(),
+			},
+		},
+	})
+	if err := pb.Run(); err != nil {
+		return err
+	}
+
+	// 4) print saved files
+	files := dingoadm.MemStorage().Get(comm.KEY_PROFILE_FILES).([]string)
+	for _, file := range files {
+		dingoadm.WriteOutln("%s", color.GreenString("Profile saved to %s", file))
+	}
+	dingoadm.WriteOutln("Analyze it with: pprof --text <binary> %s", files[0])
+	return nil
+}
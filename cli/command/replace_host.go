@@ -0,0 +1,382 @@
+/*
+ *  Copyright (c) 2025 dingodb.com Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: dingoadm
+ * Author: dongwei (jackblack369)
+ */
+
+package command
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+
+	"github.com/dingodb/dingoadm/cli/cli"
+	comm "github.com/dingodb/dingoadm/internal/common"
+	"github.com/dingodb/dingoadm/internal/configure"
+	"github.com/dingodb/dingoadm/internal/configure/topology"
+	"github.com/dingodb/dingoadm/internal/errno"
+	"github.com/dingodb/dingoadm/internal/playbook"
+	tui "github.com/dingodb/dingoadm/internal/tui/common"
+	cliutil "github.com/dingodb/dingoadm/internal/utils"
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+const (
+	REPLACE_HOST_EXAMPLE = `Examples:
+  $ dingoadm replace-host --from server-host1 --to server-host4  # Re-home all services of server-host1 to server-host4`
+)
+
+var (
+	// these steps are done for the whole cluster while deploy
+	REPLACE_HOST_SKIPPED_STEPS = map[int]bool{
+		CLEAN_PRECHECK_ENVIRONMENT: true,
+		ENABLE_ETCD_AUTH:           true,
+		CREATE_META_TABLES:         true,
+		BALANCE_LEADER:             true,
+	}
+
+	// roles which registered in coordinator by instance id, the id of dead
+	// one is still kept in coordinator, so the replacement requires a new one
+	REPLACE_HOST_RENEW_INSTANCE_ID_ROLES = []string{
+		topology.ROLE_STORE,
+		topology.ROLE_DINGODB_DOCUMENT,
+		topology.ROLE_DINGODB_INDEX,
+		topology.ROLE_DINGODB_DISKANN,
+	}
+
+	// roles which membership should be removed through alive member
+	REPLACE_HOST_DEAD_MEMBER_ROLES = []string{
+		topology.ROLE_ETCD,
+		topology.ROLE_COORDINATOR,
+	}
+)
+
+type replaceHostOptions struct {
+	from string
+	to   string
+}
+
+func NewReplaceHostCommand(dingoadm *cli.DingoAdm) *cobra.Command {
+	var options replaceHostOptions
+
+	cmd := &cobra.Command{
+		Use:     "replace-host --from HOST --to HOST",
+		Short:   "Replace a failed host with a new one",
+		Args:    cliutil.NoArgs,
+		Example: REPLACE_HOST_EXAMPLE,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runReplaceHost(dingoadm, options)
+		},
+		DisableFlagsInUseLine: true,
+	}
+
+	flags := cmd.Flags()
+	flags.StringVar(&options.from, "from", "", "Specify the failed host")
+	flags.StringVar(&options.to, "to", "", "Specify the new host")
+	cmd.MarkFlagRequired("from")
+	cmd.MarkFlagRequired("to")
+
+	return cmd
+}
+
+type replaceHostPlan struct {
+	data     string // new topology
+	dcs      []*topology.DeployConfig
+	dcs2del  []*topology.DeployConfig // services on failed host
+	dcs2add  []*topology.DeployConfig // services on new host
+	migrates []*configure.MigrateServer
+}
+
+func filterDeployConfigByHost(dcs []*topology.DeployConfig, host string, exclude bool) []*topology.DeployConfig {
+	out := []*topology.DeployConfig{}
+	for _, dc := range dcs {
+		if (dc.GetHost() == host) != exclude {
+			out = append(out, dc)
+		}
+	}
+	return out
+}
+
+// give the replacement a new instance id which is greater than all existing ones,
+// the deploy items of dead services are located by their order in topology
+func renewInstanceIds(data string, dcs, dcs2del []*topology.DeployConfig) (string, error) {
+	maxId := 0
+	for _, dc := range dcs {
+		if cliutil.Contains(REPLACE_HOST_RENEW_INSTANCE_ID_ROLES, dc.GetRole()) && dc.GetDingoInstanceId() > maxId {
+			maxId = dc.GetDingoInstanceId()
+		}
+	}
+
+	dead := append([]*topology.DeployConfig{}, dcs2del...)
+	sort.SliceStable(dead, func(i, j int) bool {
+		return dead[i].GetHostSequence() < dead[j].GetHostSequence()
+	})
+	var err error
+	nth := map[string]int{}
+	for _, dc := range dead {
+		role := dc.GetRole()
+		if !cliutil.Contains(REPLACE_HOST_RENEW_INSTANCE_ID_ROLES, role) {
+			continue
+		} else if dc.GetInstances() > 1 { // all instances share one instance_start_id
+			return "", errno.ERR_REPLACE_HOST_WITH_MULTIPLE_INSTANCES_IS_DENIED.
+				F("%s.host[%s] instances=%d", role, dc.GetHost(), dc.GetInstances())
+		}
+		maxId++
+		data, err = topology.SetDeployItemConfig(data, role, dc.GetHost(), nth[role],
+			topology.CONFIG_INSTANCE_START_ID.Key(), strconv.Itoa(maxId))
+		if err != nil {
+			return "", err
+		}
+		nth[role]++
+	}
+	return data, nil
+}
+
+func genReplaceHostPlan(dingoadm *cli.DingoAdm,
+	dcs []*topology.DeployConfig, options replaceHostOptions) (*replaceHostPlan, error) {
+	if options.from == options.to {
+		return nil, errno.ERR_REPLACE_HOST_WITH_SAME_HOST_IS_DENIED.F("host: %s", options.from)
+	}
+	if _, err := dingoadm.GetHost(options.to); err != nil {
+		return nil, err
+	}
+	dcs2del := filterDeployConfigByHost(dcs, options.from, false)
+	if len(dcs2del) == 0 {
+		return nil, errno.ERR_NO_SERVICES_ON_REPLACED_HOST.F("host: %s", options.from)
+	}
+
+	// 1) rewrite host of services in topology
+	data, _ := topology.ReplaceHost(dingoadm.ClusterTopologyData(), options.from, options.to)
+	newDcs, err := dingoadm.ParseTopologyData(data)
+	if err != nil {
+		return nil, err
+	}
+	diffs, err := diffTopology(dingoadm, data)
+	if err != nil {
+		return nil, err
+	}
+	if len(diffs[topology.DIFF_DELETE]) != len(dcs2del) ||
+		len(diffs[topology.DIFF_ADD]) != len(dcs2del) {
+		return nil, errno.ERR_REPLACE_HOST_REQUIRES_EXPLICIT_DEPLOY_HOST.
+			F("%d services on host %s, %d replaced", len(dcs2del), options.from, len(diffs[topology.DIFF_ADD]))
+	}
+
+	// 2) renew instance id of stores, the dead one is still registered in coordinator
+	data, err = renewInstanceIds(dingoadm.ClusterTopologyData(), dcs, dcs2del)
+	if err != nil {
+		return nil, err
+	}
+	data, _ = topology.ReplaceHost(data, options.from, options.to)
+	newDcs, err = dingoadm.ParseTopologyData(data)
+	if err != nil {
+		return nil, err
+	}
+
+	// 3) pair the old and new service, the position in topology is kept
+	key := func(dc *topology.DeployConfig) string {
+		return fmt.Sprintf("%s_%d", dc.GetRole(), dc.GetRoleSequence())
+	}
+	added := map[string]*topology.DeployConfig{}
+	for _, dc := range filterDeployConfigByHost(newDcs, options.to, false) {
+		added[key(dc)] = dc
+	}
+	plan := &replaceHostPlan{data: data, dcs: newDcs, dcs2del: dcs2del}
+	for _, dc := range dcs2del {
+		to, ok := added[key(dc)]
+		if !ok {
+			return nil, errno.ERR_REPLACE_HOST_REQUIRES_EXPLICIT_DEPLOY_HOST.
+				F("%s.host[%s]", dc.GetRole(), dc.GetHost())
+		}
+		if to.GetRole() == topology.ROLE_ETCD {
+			to.SetServiceConfig("initial-cluster-state", "existing") // join the alive members
+		}
+		plan.dcs2add = append(plan.dcs2add, to)
+		plan.migrates = append(plan.migrates, &configure.MigrateServer{From: dc, To: to})
+	}
+	return plan, nil
+}
+
+func genReplaceHostPlaybook(dingoadm *cli.DingoAdm,
+	dcs []*topology.DeployConfig, plan *replaceHostPlan) (*playbook.Playbook, error) {
+	deploySteps, err := getDeploySteps(dingoadm, plan.dcs)
+	if err != nil {
+		return nil, err
+	}
+
+	// 1) remove dead members at first, the replacement is added while deploy
+	steps := []int{playbook.REMOVE_DEAD_MEMBER}
+	for _, step := range deploySteps {
+		if REPLACE_HOST_SKIPPED_STEPS[step] {
+			continue
+		}
+		steps = append(steps, step)
+		switch step {
+		case START_COORDINATOR:
+			steps = append(steps, playbook.ADD_COORDINATOR_PEER)
+		case START_STORE:
+			steps = append(steps, playbook.WAIT_STORE_REGISTERED)
+		}
+	}
+	steps = append(steps, playbook.UPDATE_TOPOLOGY)
+
+	// 2) deploy replacements in dependency order
+	alive := filterDeployConfigByHost(dcs, plan.dcs2del[0].GetHost(), true)
+	pb := playbook.NewPlaybook(dingoadm)
+	for _, step := range steps {
+		// configs
+		config := plan.dcs2add
+		switch step {
+		case playbook.REMOVE_DEAD_MEMBER:
+			config = []*topology.DeployConfig{}
+			for _, dc := range plan.dcs2del {
+				if cliutil.Contains(REPLACE_HOST_DEAD_MEMBER_ROLES, dc.GetRole()) {
+					config = append(config, dc)
+				}
+			}
+		case playbook.ADD_COORDINATOR_PEER:
+			config = dingoadm.FilterDeployConfigByRole(config, ROLE_COORDINATOR)
+		case playbook.WAIT_STORE_REGISTERED:
+			config = dingoadm.FilterDeployConfigByRole(config, ROLE_STORE)
+		case playbook.CHECK_STORE_HEALTH:
+			config = dingoadm.FilterDeployConfigByRole(alive, ROLE_STORE)
+			if len(config) == 0 { // all stores are replaced
+				config = dingoadm.FilterDeployConfigByRole(plan.dcs2add, ROLE_STORE)
+			}
+		case CREATE_LOGICAL_POOL:
+			// update pool topology through alive mds iff metaserver replaced
+			config = []*topology.DeployConfig{}
+			if len(dingoadm.FilterDeployConfigByRole(plan.dcs2add, ROLE_METASERVER)) > 0 {
+				config = dingoadm.FilterDeployConfigByRole(alive, ROLE_FS_MDS)
+			}
+		case playbook.UPDATE_TOPOLOGY:
+			config = plan.dcs2add[:1]
+		default:
+			if len(DEPLOY_FILTER_ROLE[step]) > 0 {
+				config = dingoadm.FilterDeployConfigByRole(config, DEPLOY_FILTER_ROLE[step])
+			}
+		}
+		if DEPLOY_LIMIT_SERVICE[step] > 0 && len(config) > DEPLOY_LIMIT_SERVICE[step] {
+			config = config[:DEPLOY_LIMIT_SERVICE[step]]
+		}
+		if len(config) == 0 {
+			continue
+		}
+
+		// options
+		options := map[string]interface{}{}
+		switch step {
+		case playbook.REMOVE_DEAD_MEMBER:
+			options[comm.KEY_ALL_DEPLOY_CONFIGS] = alive
+			options[comm.KEY_MIGRATE_SERVERS] = plan.migrates
+		case playbook.ADD_COORDINATOR_PEER:
+			options[comm.KEY_EXISTING_COORDINATORS] = dingoadm.FilterDeployConfigByRole(alive, ROLE_COORDINATOR)
+		case CREATE_LOGICAL_POOL:
+			options[comm.KEY_CREATE_POOL_TYPE] = comm.POOL_TYPE_LOGICAL
+			options[comm.KEY_MIGRATE_SERVERS] = plan.migrates
+			options[comm.KEY_NEW_TOPOLOGY_DATA] = plan.data
+			options[comm.POOLSET] = "default"
+			options[comm.POOLSET_DISK_TYPE] = "ssd"
+		case playbook.UPDATE_TOPOLOGY:
+			options[comm.KEY_NEW_TOPOLOGY_DATA] = plan.data
+		}
+
+		// exec options
+		execOptions := playbook.ExecOptions{
+			SilentSubBar: step == playbook.UPDATE_TOPOLOGY,
+		}
+		switch step {
+		case playbook.REMOVE_DEAD_MEMBER,
+			playbook.ADD_COORDINATOR_PEER:
+			execOptions.Concurrency = 1 // change membership one by one
+		}
+		pb.AddStep(&playbook.PlaybookStep{
+			Type:        step,
+			Configs:     config,
+			Options:     options,
+			ExecOptions: execOptions,
+		})
+	}
+	return pb, nil
+}
+
+func displayReplaceHostPlan(dingoadm *cli.DingoAdm, plan *replaceHostPlan, options replaceHostOptions) {
+	dingoadm.WriteOutln("")
+	dingoadm.WriteOutln(color.YellowString("NOTICE: cluster '%s' is about to replace host '%s' with '%s':",
+		dingoadm.ClusterName(), options.from, options.to))
+	dingoadm.WriteOutln(color.YellowString("  - Remove dead members (through alive members, without access '%s'):",
+		options.from))
+	for _, dc := range plan.dcs2del {
+		if cliutil.Contains(REPLACE_HOST_DEAD_MEMBER_ROLES, dc.GetRole()) {
+			dingoadm.WriteOutln(color.YellowString("    %s.host[%s] %s", dc.GetRole(), dc.GetHost(), dc.GetListenIp()))
+		}
+	}
+	dingoadm.WriteOutln(color.YellowString("  - Deploy services on '%s':", options.to))
+	for _, migrate := range plan.migrates {
+		dingoadm.WriteOutln(color.YellowString("    %s.host[%s] -> %s.host[%s]",
+			migrate.From.GetRole(), migrate.From.GetHost(), migrate.To.GetRole(), migrate.To.GetHost()))
+	}
+	dingoadm.WriteOutln(color.YellowString("  - Commit topology"))
+}
+
+func runReplaceHost(dingoadm *cli.DingoAdm, options replaceHostOptions) error {
+	// 1) parse cluster topology
+	dcs, err := dingoadm.ParseTopology()
+	if err != nil {
+		return err
+	}
+
+	// 2) generate replace plan
+	plan, err := genReplaceHostPlan(dingoadm, dcs, options)
+	if err != nil {
+		return err
+	}
+	dingoadm.WriteOut("%s", cliutil.Diff(dingoadm.ClusterTopologyData(), plan.data))
+
+	// 3) display plan
+	displayReplaceHostPlan(dingoadm, plan, options)
+
+	// 4) confirm by user
+	if pass := tui.ConfirmYes(tui.DEFAULT_CONFIRM_PROMPT); !pass {
+		dingoadm.WriteOutln(tui.PromptCancelOpetation("replace host"))
+		return nil
+	}
+
+	// 5) generate replace-host playbook
+	pb, err := genReplaceHostPlaybook(dingoadm, dcs, plan)
+	if err != nil {
+		return err
+	}
+
+	// 6) run playground
+	if err = pb.Run(); err != nil {
+		return err
+	}
+
+	// 7) delete replaced services from database
+	if err = deleteRemovedServices(dingoadm, plan.dcs2del); err != nil {
+		return err
+	}
+
+	// 8) print success prompt
+	dingoadm.WriteOutln("")
+	dingoadm.WriteOutln(color.GreenString("Host '%s' successfully replaced with '%s' ^_^."),
+		options.from, options.to)
+	return nil
+}
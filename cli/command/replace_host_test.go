@@ -0,0 +1,67 @@
+package command
+
+import (
+	"testing"
+
+	"github.com/dingodb/dingoadm/cli/cli"
+	"github.com/dingodb/dingoadm/internal/configure/topology"
+	"github.com/dingodb/dingoadm/internal/errno"
+	"github.com/stretchr/testify/assert"
+)
+
+// the replacement of dead store registers in coordinator with a new instance id
+func TestRenewInstanceIds(t *testing.T) {
+	assert := assert.New(t)
+	curveadm := &cli.DingoAdm{}
+
+	data := `
+kind: dingodb
+coordinator_services:
+  deploy:
+    - host: host1
+    - host: host2
+    - host: host3
+store_services:
+  deploy:
+    - host: host1
+    - host: host2
+    - host: host3
+`
+	dcs := parseTestTopology(t, data)
+	dcs2del := filterDeployConfigByHost(dcs, "host2", false)
+	out, err := renewInstanceIds(data, dcs, dcs2del)
+	assert.Nil(err)
+	out, _ = topology.ReplaceHost(out, "host2", "host4")
+
+	maxId := 0
+	for _, dc := range curveadm.FilterDeployConfigByRole(dcs, topology.ROLE_STORE) {
+		if dc.GetDingoInstanceId() > maxId {
+			maxId = dc.GetDingoInstanceId()
+		}
+	}
+	stores := curveadm.FilterDeployConfigByRole(parseTestTopology(t, out), topology.ROLE_STORE)
+	assert.Len(filterDeployConfigByHost(stores, "host4", false), 1)
+	for _, dc := range stores {
+		if dc.GetHost() == "host4" {
+			assert.Equal(maxId+1, dc.GetDingoInstanceId())
+		} else {
+			assert.LessOrEqual(dc.GetDingoInstanceId(), maxId) // others are kept
+		}
+	}
+
+	// instances share one instance_start_id, can't be renewed
+	data = `
+kind: dingodb
+coordinator_services:
+  deploy:
+    - host: host1
+store_services:
+  deploy:
+    - host: host1
+    - host: host2
+      instances: 2
+`
+	dcs = parseTestTopology(t, data)
+	_, err = renewInstanceIds(data, dcs, filterDeployConfigByHost(dcs, "host2", false))
+	assert.Equal(errno.ERR_REPLACE_HOST_WITH_MULTIPLE_INSTANCES_IS_DENIED.GetCode(), err.(*errno.ErrorCode).GetCode())
+}
@@ -47,10 +47,12 @@ var (
 )
 
 type restartOptions struct {
-	id    string
-	role  string
-	host  string
-	force bool
+	id      string
+	role    string
+	host    string
+	force   bool
+	noOrder bool
+	noDrain bool
 }
 
 func NewRestartCommand(dingoadm *cli.DingoAdm) *cobra.Command {
@@ -74,6 +76,8 @@ func NewRestartCommand(dingoadm *cli.DingoAdm) *cobra.Command {
 	flags.StringVar(&options.role, "role", "*", "Specify service role")
 	flags.StringVar(&options.host, "host", "*", "Specify service host")
 	flags.BoolVarP(&options.force, "force", "f", false, "Never prompt")
+	flags.BoolVar(&options.noOrder, "no-order", false, "Restart all services at once without dependency order")
+	flags.BoolVar(&options.noDrain, "no-drain", false, "Restart services without transferring their leaders away")
 
 	return cmd
 }
@@ -81,6 +85,7 @@ func NewRestartCommand(dingoadm *cli.DingoAdm) *cobra.Command {
 func genRestartPlaybook(dingoadm *cli.DingoAdm,
 	dcs []*topology.DeployConfig,
 	options restartOptions) (*playbook.Playbook, error) {
+	all := dcs
 	dcs = dingoadm.FilterDeployConfig(dcs, topology.FilterOption{
 		Id:   options.id,
 		Role: options.role,
@@ -89,14 +94,14 @@ func genRestartPlaybook(dingoadm *cli.DingoAdm,
 	if len(dcs) == 0 {
 		return nil, errno.ERR_NO_SERVICES_MATCHED
 	}
+	if !options.noOrder {
+		return genOrderedPlaybook(dingoadm, playbook.RESTART_SERVICE, dcs, all, false, !options.noDrain)
+	}
 
 	steps := RESTART_PLAYBOOK_STEPS
 	pb := playbook.NewPlaybook(dingoadm)
 	for _, step := range steps {
-		pb.AddStep(&playbook.PlaybookStep{
-			Type:    step,
-			Configs: dcs,
-		})
+		addOperateSteps(dingoadm, pb, step, dcs, all, !options.noDrain, nil)
 	}
 	return pb, nil
 }
@@ -0,0 +1,256 @@
+/*
+ *  Copyright (c) 2025 dingodb.com Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: dingoadm
+ * Author: dongwei (jackblack369)
+ */
+
+package command
+
+import (
+	"github.com/dingodb/dingoadm/cli/cli"
+	comm "github.com/dingodb/dingoadm/internal/common"
+	"github.com/dingodb/dingoadm/internal/configure/topology"
+	"github.com/dingodb/dingoadm/internal/errno"
+	"github.com/dingodb/dingoadm/internal/playbook"
+	tui "github.com/dingodb/dingoadm/internal/tui/common"
+	cliutil "github.com/dingodb/dingoadm/internal/utils"
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+var (
+	SCALE_IN_STEPS = []int{
+		playbook.DRAIN_SERVICE,
+		playbook.STOP_SERVICE,
+		playbook.CLEAN_SERVICE,
+		playbook.UPDATE_TOPOLOGY,
+	}
+
+	// roles which hold raft membership (etcd, coordinator) should be
+	// removed by migrate, the others are drained by role before removed
+	SCALE_IN_SUPPORTED_ROLES = map[string]bool{
+		topology.ROLE_STORE:            true,
+		topology.ROLE_FS_MDS:           true,
+		topology.ROLE_METASERVER:       true,
+		topology.ROLE_DINGODB_EXECUTOR: true,
+		topology.ROLE_DINGODB_DOCUMENT: true,
+		topology.ROLE_DINGODB_INDEX:    true,
+		topology.ROLE_DINGODB_WEB:      true,
+		topology.ROLE_DINGODB_PROXY:    true,
+	}
+)
+
+type scaleInOptions struct {
+	filename string
+}
+
+func NewScaleInCommand(dingoadm *cli.DingoAdm) *cobra.Command {
+	var options scaleInOptions
+
+	cmd := &cobra.Command{
+		Use:   "scale-in TOPOLOGY",
+		Short: "Scale in cluster",
+		Args:  cliutil.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			options.filename = args[0]
+			return runScaleIn(dingoadm, options)
+		},
+		DisableFlagsInUseLine: true,
+	}
+
+	return cmd
+}
+
+// return the deleted services which parsed from cluster topology
+func getScaleInServices(dingoadm *cli.DingoAdm,
+	dcs []*topology.DeployConfig, data string) ([]*topology.DeployConfig, error) {
+	diffs, err := diffTopology(dingoadm, data)
+	if err != nil {
+		return nil, err
+	}
+
+	deleted := map[string]bool{}
+	for _, dc := range diffs[topology.DIFF_DELETE] {
+		deleted[dc.GetId()] = true
+	}
+	dcs2del := []*topology.DeployConfig{}
+	for _, dc := range dcs {
+		if deleted[dc.GetId()] {
+			dcs2del = append(dcs2del, dc)
+		}
+	}
+	return dcs2del, nil
+}
+
+func checkScaleInTopology(dingoadm *cli.DingoAdm, dcs []*topology.DeployConfig, data string) error {
+	diffs, err := diffTopology(dingoadm, data)
+	if err != nil {
+		return err
+	}
+
+	dcs2add := diffs[topology.DIFF_ADD]
+	if len(dcs2add) > 0 {
+		return errno.ERR_ADD_SERVICE_WHILE_SCALE_IN_CLUSTER_IS_DENIED
+	}
+	dcs2del := diffs[topology.DIFF_DELETE]
+	if len(dcs2del) == 0 {
+		return errno.ERR_NO_SERVICES_FOR_SCALE_IN_CLUSTER
+	}
+	if !dingoadm.IsSameRole(dcs2del) {
+		return errno.ERR_REQUIRE_SAME_ROLE_SERVICES_FOR_SCALE_IN_CLUSTER
+	}
+
+	role := dcs2del[0].GetRole()
+	if !SCALE_IN_SUPPORTED_ROLES[role] {
+		return errno.ERR_UNSUPPORT_SCALE_IN_ROLE.F("role: %s", role)
+	}
+	if len(dingoadm.FilterDeployConfigByRole(dcs, role)) <= len(dcs2del) {
+		return errno.ERR_SCALE_IN_ALL_SERVICES_OF_ROLE_IS_DENIED.F("role: %s", role)
+	}
+	return nil
+}
+
+// clean items for the service which removed from cluster (scale-in, migrate)
+func getRemovedServiceCleanItems(role string) []string {
+	items := []string{
+		comm.CLEAN_ITEM_LOG,
+		comm.CLEAN_ITEM_DATA,
+		comm.CLEAN_ITEM_CONTAINER,
+	}
+	switch role {
+	case topology.ROLE_COORDINATOR, topology.ROLE_STORE:
+		items = append(items, comm.CLEAN_ITEM_RAFT)
+	case topology.ROLE_DINGODB_DOCUMENT:
+		items = append(items, comm.CLEAN_ITEM_RAFT, comm.CLEAN_ITEM_DOC)
+	case topology.ROLE_DINGODB_INDEX:
+		items = append(items, comm.CLEAN_ITEM_RAFT, comm.CLEAN_ITEM_VECTOR)
+	}
+	return items
+}
+
+func genScaleInPlaybook(dingoadm *cli.DingoAdm,
+	dcs []*topology.DeployConfig,
+	dcs2del []*topology.DeployConfig,
+	data string) (*playbook.Playbook, error) {
+	pb := playbook.NewPlaybook(dingoadm)
+	for _, step := range SCALE_IN_STEPS {
+		// options
+		options := map[string]interface{}{}
+		switch step {
+		case playbook.DRAIN_SERVICE:
+			options[comm.KEY_ALL_DEPLOY_CONFIGS] = dcs
+		case playbook.CLEAN_SERVICE:
+			options[comm.KEY_CLEAN_ITEMS] = getRemovedServiceCleanItems(dcs2del[0].GetRole())
+			options[comm.KEY_CLEAN_BY_RECYCLE] = false
+		case playbook.UPDATE_TOPOLOGY:
+			options[comm.KEY_NEW_TOPOLOGY_DATA] = data
+		}
+
+		// exec options
+		execOptions := playbook.ExecOptions{
+			SilentSubBar: step == playbook.UPDATE_TOPOLOGY,
+		}
+		if step == playbook.DRAIN_SERVICE {
+			execOptions.Concurrency = 1 // drain services one by one to keep data safe
+		}
+		pb.AddStep(&playbook.PlaybookStep{
+			Type:        step,
+			Configs:     dcs2del,
+			Options:     options,
+			ExecOptions: execOptions,
+		})
+	}
+	return pb, nil
+}
+
+func displayScaleInTitle(dingoadm *cli.DingoAdm, dcs2del []*topology.DeployConfig) {
+	dingoadm.WriteOutln("")
+	dingoadm.WriteOutln(color.YellowString("NOTICE: cluster '%s' is about to scale in:",
+		dingoadm.ClusterName()))
+	dingoadm.WriteOutln(color.YellowString("  - Scale in services: %s*%d",
+		dcs2del[0].GetRole(), len(dcs2del)))
+	for _, dc := range dcs2del {
+		dingoadm.WriteOutln(color.YellowString("    %s.host[%s]", dc.GetRole(), dc.GetHost()))
+	}
+	dingoadm.WriteOutln(color.YellowString("  - The data of these services will be removed after drained"))
+}
+
+func deleteRemovedServices(dingoadm *cli.DingoAdm, dcs2del []*topology.DeployConfig) error {
+	for _, dc := range dcs2del {
+		serviceId := dingoadm.GetServiceId(dc.GetId())
+		if err := dingoadm.Storage().DeleteService(serviceId); err != nil {
+			return errno.ERR_DELETE_SERVICE_FAILED.E(err)
+		}
+	}
+	return nil
+}
+
+func runScaleIn(dingoadm *cli.DingoAdm, options scaleInOptions) error {
+	// 1) parse cluster topology
+	dcs, err := dingoadm.ParseTopology()
+	if err != nil {
+		return err
+	}
+
+	// 2) read topology from file
+	data, err := readTopology(dingoadm, options.filename)
+	if err != nil {
+		return err
+	}
+
+	// 3) check topology
+	err = checkScaleInTopology(dingoadm, dcs, data)
+	if err != nil {
+		return err
+	}
+	dcs2del, err := getScaleInServices(dingoadm, dcs, data)
+	if err != nil {
+		return err
+	}
+
+	// 4) display title
+	displayScaleInTitle(dingoadm, dcs2del)
+
+	// 5) confirm by user
+	if pass := tui.ConfirmYes(tui.DEFAULT_CONFIRM_PROMPT); !pass {
+		dingoadm.WriteOutln(tui.PromptCancelOpetation("scale-in"))
+		return nil
+	}
+
+	// 6) generate scale-in playbook
+	pb, err := genScaleInPlaybook(dingoadm, dcs, dcs2del, data)
+	if err != nil {
+		return err
+	}
+
+	// 7) run playground
+	if err = pb.Run(); err != nil {
+		return err
+	}
+
+	// 8) delete services from database
+	if err = deleteRemovedServices(dingoadm, dcs2del); err != nil {
+		return err
+	}
+
+	// 9) print success prompt
+	dingoadm.WriteOutln("")
+	dingoadm.WriteOutln(color.GreenString("Cluster '%s' successfully scaled in ^_^."),
+		dingoadm.ClusterName())
+	return nil
+}
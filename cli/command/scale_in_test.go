@@ -0,0 +1,49 @@
+package command
+
+import (
+	"testing"
+
+	"github.com/dingodb/dingoadm/cli/cli"
+	comm "github.com/dingodb/dingoadm/internal/common"
+	"github.com/dingodb/dingoadm/internal/configure/topology"
+	"github.com/dingodb/dingoadm/internal/playbook"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGenScaleInPlaybook(t *testing.T) {
+	assert := assert.New(t)
+	curveadm := &cli.DingoAdm{}
+
+	dcs := parseTestTopology(t, SCALE_OUT_OLD_TOPOLOGY)
+	dcs2del := curveadm.FilterDeployConfigByRole(dcs, topology.ROLE_STORE)[2:]
+	data := "new topology"
+
+	pb, err := genScaleInPlaybook(curveadm, dcs, dcs2del, data)
+	assert.Nil(err)
+	assert.Equal(SCALE_IN_STEPS, getStepTypes(pb))
+	for _, step := range pb.Steps() {
+		assert.Equal(dcs2del, step.Configs) // only removed store involved
+	}
+
+	// drain one by one with all services to locate coordinator
+	step := getStep(pb, playbook.DRAIN_SERVICE)
+	assert.Equal(uint(1), step.ExecOptions.Concurrency)
+	assert.Equal(dcs, step.Options[comm.KEY_ALL_DEPLOY_CONFIGS])
+
+	step = getStep(pb, playbook.CLEAN_SERVICE)
+	assert.Equal([]string{comm.CLEAN_ITEM_LOG, comm.CLEAN_ITEM_DATA, comm.CLEAN_ITEM_CONTAINER, comm.CLEAN_ITEM_RAFT},
+		step.Options[comm.KEY_CLEAN_ITEMS])
+	assert.Equal(false, step.Options[comm.KEY_CLEAN_BY_RECYCLE])
+	assert.Equal(data, getStep(pb, playbook.UPDATE_TOPOLOGY).Options[comm.KEY_NEW_TOPOLOGY_DATA])
+}
+
+func TestGetRemovedServiceCleanItems(t *testing.T) {
+	assert := assert.New(t)
+
+	items := []string{comm.CLEAN_ITEM_LOG, comm.CLEAN_ITEM_DATA, comm.CLEAN_ITEM_CONTAINER}
+	assert.Equal(items, getRemovedServiceCleanItems(topology.ROLE_DINGODB_EXECUTOR))
+	assert.Equal(append(items, comm.CLEAN_ITEM_RAFT, comm.CLEAN_ITEM_DOC),
+		getRemovedServiceCleanItems(topology.ROLE_DINGODB_DOCUMENT))
+	assert.Equal(append(items, comm.CLEAN_ITEM_RAFT, comm.CLEAN_ITEM_VECTOR),
+		getRemovedServiceCleanItems(topology.ROLE_DINGODB_INDEX))
+}
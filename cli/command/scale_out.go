@@ -91,12 +91,90 @@ var (
 		playbook.CREATE_LOGICAL_POOL,
 	}
 
+	// coordinator (dingo-store)
+	SCALE_OUT_COORDINATOR_STEPS = []int{
+		playbook.PULL_IMAGE,
+		playbook.CREATE_CONTAINER,
+		playbook.SYNC_CONFIG,
+		playbook.START_COORDINATOR,
+		playbook.ADD_COORDINATOR_PEER,
+		playbook.UPDATE_TOPOLOGY,
+	}
+
+	// store (dingo-store)
+	SCALE_OUT_STORE_STEPS = []int{
+		playbook.PULL_IMAGE,
+		playbook.CREATE_CONTAINER,
+		playbook.SYNC_CONFIG,
+		playbook.START_STORE,
+		playbook.WAIT_STORE_REGISTERED,
+		playbook.CHECK_STORE_HEALTH,
+		playbook.UPDATE_TOPOLOGY,
+	}
+
+	// mds v2 (dingofs), meta tables already created by deploy
+	SCALE_OUT_MDSV2_STEPS = []int{
+		playbook.PULL_IMAGE,
+		playbook.CREATE_CONTAINER,
+		playbook.SYNC_CONFIG,
+		playbook.CHECK_STORE_HEALTH,
+		playbook.START_FS_MDS,
+		playbook.UPDATE_TOPOLOGY,
+	}
+
+	// executor (dingodb)
+	SCALE_OUT_EXECUTOR_STEPS = []int{
+		playbook.PULL_IMAGE,
+		playbook.CREATE_CONTAINER,
+		playbook.SYNC_CONFIG,
+		playbook.SYNC_JAVA_OPTS,
+		playbook.START_DINGODB_EXECUTOR,
+		playbook.UPDATE_TOPOLOGY,
+	}
+
+	// document (dingodb)
+	SCALE_OUT_DOCUMENT_STEPS = []int{
+		playbook.PULL_IMAGE,
+		playbook.CREATE_CONTAINER,
+		playbook.SYNC_CONFIG,
+		playbook.CHECK_STORE_HEALTH,
+		playbook.START_DINGODB_DOCUMENT,
+		playbook.UPDATE_TOPOLOGY,
+	}
+
+	// diskann (dingodb)
+	SCALE_OUT_DISKANN_STEPS = []int{
+		playbook.PULL_IMAGE,
+		playbook.CREATE_CONTAINER,
+		playbook.SYNC_CONFIG,
+		playbook.CHECK_STORE_HEALTH,
+		playbook.START_DINGODB_DISKANN,
+		playbook.UPDATE_TOPOLOGY,
+	}
+
+	// index (dingodb)
+	SCALE_OUT_INDEX_STEPS = []int{
+		playbook.PULL_IMAGE,
+		playbook.CREATE_CONTAINER,
+		playbook.SYNC_CONFIG,
+		playbook.CHECK_STORE_HEALTH,
+		playbook.START_DINGODB_INDEX,
+		playbook.UPDATE_TOPOLOGY,
+	}
+
 	SCALE_OUT_ROLE_STEPS = map[string][]int{
 		topology.ROLE_ETCD:          SCALE_OUT_ETCD_STEPS,
 		topology.ROLE_FS_MDS:        SCALE_OUT_MDS_STEPS,
 		topology.ROLE_CHUNKSERVER:   SCALE_OUT_CHUNKSERVER_STEPS,
 		topology.ROLE_SNAPSHOTCLONE: SCALE_OUT_SNAPSHOTCLONE_STEPS,
 		topology.ROLE_METASERVER:    SCALE_OUT_METASERVER_STEPS,
+		topology.ROLE_COORDINATOR:   SCALE_OUT_COORDINATOR_STEPS,
+		topology.ROLE_STORE:         SCALE_OUT_STORE_STEPS,
+
+		topology.ROLE_DINGODB_EXECUTOR: SCALE_OUT_EXECUTOR_STEPS,
+		topology.ROLE_DINGODB_DOCUMENT: SCALE_OUT_DOCUMENT_STEPS,
+		topology.ROLE_DINGODB_DISKANN:  SCALE_OUT_DISKANN_STEPS,
+		topology.ROLE_DINGODB_INDEX:    SCALE_OUT_INDEX_STEPS,
 	}
 
 	SCALE_OUT_SCALE_OUT_FILTER_ROLE = map[int]string{
@@ -211,7 +289,20 @@ func checkScaleOutTopology(curveadm *cli.DingoAdm, data string) error {
 
 	role := dcs2add[0].GetRole()
 	num := getHostNum(dcs2add)
+	if len(getScaleOutSteps(dcs2add)) == 0 {
+		return errno.ERR_UNSUPPORT_SCALE_OUT_ROLE.F("role: %s", role)
+	}
 	switch role {
+	case topology.ROLE_COORDINATOR,
+		topology.ROLE_STORE,
+		topology.ROLE_DINGODB_DOCUMENT,
+		topology.ROLE_DINGODB_INDEX,
+		topology.ROLE_DINGODB_DISKANN:
+		return checkInstanceIdConflict(curveadm, dcs2add)
+	case topology.ROLE_FS_MDS:
+		if isMdsv2(dcs2add[0]) {
+			return checkInstanceIdConflict(curveadm, dcs2add)
+		}
 	case topology.ROLE_CHUNKSERVER:
 		if num < 3 {
 			return errno.ERR_CHUNKSERVER_REQUIRES_3_HOSTS_WHILE_SCALE_OUT.
@@ -227,6 +318,46 @@ func checkScaleOutTopology(curveadm *cli.DingoAdm, data string) error {
 	return nil
 }
 
+func isMdsv2(dc *topology.DeployConfig) bool {
+	return dc.GetRole() == topology.ROLE_FS_MDS &&
+		dc.GetCtx().Lookup(topology.CTX_KEY_MDS_VERSION) == topology.CTX_VAL_MDS_V2
+}
+
+// the steps mirror the order of deploy, e.g. DINGODB_DEPLOY_STEPS
+func getScaleOutSteps(dcs2add []*topology.DeployConfig) []int {
+	if isMdsv2(dcs2add[0]) {
+		return SCALE_OUT_MDSV2_STEPS
+	}
+	return SCALE_OUT_ROLE_STEPS[dcs2add[0].GetRole()]
+}
+
+// instance id is the identity of dingo-store service in coordinator,
+// so the new service MUST NOT reuse the id of existing service
+func checkInstanceIdConflict(curveadm *cli.DingoAdm, dcs2add []*topology.DeployConfig) error {
+	dcs, err := curveadm.ParseTopology()
+	if err != nil {
+		return err
+	}
+	return checkInstanceIdUnique(curveadm, dcs, dcs2add)
+}
+
+func checkInstanceIdUnique(curveadm *cli.DingoAdm, dcs, dcs2add []*topology.DeployConfig) error {
+	role := dcs2add[0].GetRole()
+	exist := map[int]string{}
+	for _, dc := range curveadm.FilterDeployConfigByRole(dcs, role) {
+		exist[dc.GetDingoInstanceId()] = dc.GetHost()
+	}
+	for _, dc := range dcs2add {
+		id := dc.GetDingoInstanceId()
+		if host, ok := exist[id]; ok {
+			return errno.ERR_INSTANCE_ID_CONFLICT_WHILE_SCALE_OUT.
+				F("%s.host[%s] instance id %d already used by %s.host[%s]", role, dc.GetHost(), id, role, host)
+		}
+		exist[id] = dc.GetHost()
+	}
+	return nil
+}
+
 func genScaleOutPrecheckPlaybook(curveadm *cli.DingoAdm, data string) (*playbook.Playbook, error) {
 	dcsAll, _ := curveadm.ParseTopologyData(data)
 	// kind := dcsAll[0].GetKind()
@@ -313,12 +444,10 @@ func precheckBeforeScaleOut(curveadm *cli.DingoAdm, options scaleOutOptions, dat
 
 func genScaleOutPlaybook(curveadm *cli.DingoAdm,
 	dcs []*topology.DeployConfig,
+	dcs2scaleOut []*topology.DeployConfig,
 	data string,
 	options scaleOutOptions) (*playbook.Playbook, error) {
-	diffs, _ := diffTopology(curveadm, data)
-	dcs2scaleOut := diffs[topology.DIFF_ADD]
-	role := dcs2scaleOut[0].GetRole()
-	steps := SCALE_OUT_ROLE_STEPS[role]
+	steps := getScaleOutSteps(dcs2scaleOut)
 	poolset := configure.Poolset{Name: options.poolset, Type: options.poolsetDiskType}
 
 	pb := playbook.NewPlaybook(curveadm)
@@ -331,6 +460,17 @@ func genScaleOutPlaybook(curveadm *cli.DingoAdm,
 		case CREATE_PHYSICAL_POOL,
 			CREATE_LOGICAL_POOL:
 			config = curveadm.FilterDeployConfigByRole(dcs, topology.ROLE_FS_MDS)[:1]
+		case playbook.CHECK_STORE_HEALTH:
+			// check store map once, through existing store if the new one isn't store
+			if dcs2scaleOut[0].GetRole() != topology.ROLE_STORE {
+				config = curveadm.FilterDeployConfigByRole(dcs, topology.ROLE_STORE)
+			}
+			if len(config) == 0 {
+				continue // no store in cluster yet
+			}
+			config = config[:1]
+		case playbook.SYNC_JAVA_OPTS:
+			config = filterWithJavaOpts(dcs2scaleOut)
 		}
 
 		// options
@@ -350,23 +490,41 @@ func genScaleOutPlaybook(curveadm *cli.DingoAdm,
 			options[comm.KEY_NUMBER_OF_CHUNKSERVER] = calcNumOfChunkserver(curveadm, dcs) +
 				calcNumOfChunkserver(curveadm, dcs2scaleOut)
 			options[comm.KEY_POOLSET] = poolset
+		case playbook.CREATE_CONTAINER:
+			options[comm.KEY_SCALE_OUT_CLUSTER] = dcs2scaleOut
+		case playbook.ADD_COORDINATOR_PEER:
+			options[comm.KEY_EXISTING_COORDINATORS] = curveadm.FilterDeployConfigByRole(dcs, topology.ROLE_COORDINATOR)
 		case playbook.UPDATE_TOPOLOGY:
 			options[comm.KEY_NEW_TOPOLOGY_DATA] = data
 		}
 
 		// exec options
+		execOptions := playbook.ExecOptions{
+			SilentSubBar: step == playbook.UPDATE_TOPOLOGY,
+		}
+		if step == playbook.ADD_COORDINATOR_PEER {
+			execOptions.Concurrency = 1 // raft changes membership one by one
+		}
 		pb.AddStep(&playbook.PlaybookStep{
-			Type:    step,
-			Configs: config,
-			Options: options,
-			ExecOptions: playbook.ExecOptions{
-				SilentSubBar: step == playbook.UPDATE_TOPOLOGY,
-			},
+			Type:        step,
+			Configs:     config,
+			Options:     options,
+			ExecOptions: execOptions,
 		})
 	}
 	return pb, nil
 }
 
+func filterWithJavaOpts(dcs []*topology.DeployConfig) []*topology.DeployConfig {
+	out := []*topology.DeployConfig{}
+	for _, dc := range dcs {
+		if len(dc.GetDingoExecutorJavaOpts()) > 0 {
+			out = append(out, dc)
+		}
+	}
+	return out
+}
+
 func displayScaleOutTitle(curveadm *cli.DingoAdm, data string) {
 	diffs, _ := diffTopology(curveadm, data)
 	dcs := diffs[topology.DIFF_ADD]
@@ -412,7 +570,11 @@ func runScaleOut(curveadm *cli.DingoAdm, options scaleOutOptions) error {
 	}
 
 	// 7) generate scale-out playbook
-	pb, err := genScaleOutPlaybook(curveadm, dcs, data, options)
+	diffs, err := diffTopology(curveadm, data)
+	if err != nil {
+		return err
+	}
+	pb, err := genScaleOutPlaybook(curveadm, dcs, diffs[topology.DIFF_ADD], data, options)
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,291 @@
+package command
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/dingodb/dingoadm/cli/cli"
+	comm "github.com/dingodb/dingoadm/internal/common"
+	"github.com/dingodb/dingoadm/internal/configure/topology"
+	"github.com/dingodb/dingoadm/internal/errno"
+	"github.com/dingodb/dingoadm/internal/playbook"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	SCALE_OUT_OLD_TOPOLOGY = `
+kind: dingo-store
+coordinator_services:
+  deploy:
+    - host: host1
+    - host: host2
+    - host: host3
+store_services:
+  deploy:
+    - host: host1
+    - host: host2
+    - host: host3
+`
+)
+
+func parseTestTopology(t *testing.T, data string) []*topology.DeployConfig {
+	ctx := topology.NewContext()
+	for _, host := range []string{"host1", "host2", "host3", "host4", "host5"} {
+		ctx.Add(host, host)
+	}
+	dcs, err := topology.ParseTopology(data, ctx)
+	assert.Nil(t, err)
+	return dcs
+}
+
+// returns services in new topology which not exist in old one
+func getAddedServices(t *testing.T, oldData, newData string) []*topology.DeployConfig {
+	exist := map[string]bool{}
+	for _, dc := range parseTestTopology(t, oldData) {
+		exist[dc.GetId()] = true
+	}
+	out := []*topology.DeployConfig{}
+	for _, dc := range parseTestTopology(t, newData) {
+		if !exist[dc.GetId()] {
+			out = append(out, dc)
+		}
+	}
+	return out
+}
+
+func getStepTypes(pb *playbook.Playbook) []int {
+	types := []int{}
+	for _, step := range pb.Steps() {
+		types = append(types, step.Type)
+	}
+	return types
+}
+
+func getStep(pb *playbook.Playbook, stepType int) *playbook.PlaybookStep {
+	for _, step := range pb.Steps() {
+		if step.Type == stepType {
+			return step
+		}
+	}
+	return nil
+}
+
+func TestGenScaleOutPlaybookStore(t *testing.T) {
+	assert := assert.New(t)
+	curveadm := &cli.DingoAdm{}
+
+	data := SCALE_OUT_OLD_TOPOLOGY + "    - host: host4\n"
+	dcs := parseTestTopology(t, SCALE_OUT_OLD_TOPOLOGY)
+	dcs2add := getAddedServices(t, SCALE_OUT_OLD_TOPOLOGY, data)
+	assert.Len(dcs2add, 1)
+	assert.Equal(topology.ROLE_STORE, dcs2add[0].GetRole())
+	assert.Equal(1004, dcs2add[0].GetDingoInstanceId())
+	assert.Nil(checkInstanceIdUnique(curveadm, dcs, dcs2add))
+
+	pb, err := genScaleOutPlaybook(curveadm, dcs, dcs2add, data, scaleOutOptions{})
+	assert.Nil(err)
+	assert.Equal(SCALE_OUT_STORE_STEPS, getStepTypes(pb))
+	for _, step := range pb.Steps() {
+		assert.Equal(dcs2add, step.Configs) // only new store involved
+	}
+	assert.Equal(data, getStep(pb, playbook.UPDATE_TOPOLOGY).Options[comm.KEY_NEW_TOPOLOGY_DATA])
+}
+
+func TestGenScaleOutPlaybookCoordinator(t *testing.T) {
+	assert := assert.New(t)
+	curveadm := &cli.DingoAdm{}
+
+	data := `
+kind: dingo-store
+coordinator_services:
+  deploy:
+    - host: host1
+    - host: host2
+    - host: host3
+    - host: host4
+    - host: host5
+store_services:
+  deploy:
+    - host: host1
+    - host: host2
+    - host: host3
+`
+	dcs := parseTestTopology(t, SCALE_OUT_OLD_TOPOLOGY)
+	dcs2add := getAddedServices(t, SCALE_OUT_OLD_TOPOLOGY, data)
+	assert.Len(dcs2add, 2)
+
+	pb, err := genScaleOutPlaybook(curveadm, dcs, dcs2add, data, scaleOutOptions{})
+	assert.Nil(err)
+	assert.Equal(SCALE_OUT_COORDINATOR_STEPS, getStepTypes(pb))
+
+	step := getStep(pb, playbook.ADD_COORDINATOR_PEER)
+	assert.Equal(dcs2add, step.Configs)
+	assert.Equal(uint(1), step.ExecOptions.Concurrency)
+	assert.Equal(curveadm.FilterDeployConfigByRole(dcs, topology.ROLE_COORDINATOR),
+		step.Options[comm.KEY_EXISTING_COORDINATORS])
+}
+
+func TestGenScaleOutPlaybookWithoutStore(t *testing.T) {
+	assert := assert.New(t)
+	curveadm := &cli.DingoAdm{}
+
+	old := `
+kind: dingofs
+coordinator_services:
+  deploy:
+    - host: host1
+executor_services:
+  deploy:
+    - host: host1
+mds_services:
+  deploy:
+    - host: host1
+`
+	data := old + "    - host: host2\n"
+	dcs := parseTestTopology(t, old)
+	dcs2add := getAddedServices(t, old, data)
+	assert.Len(dcs2add, 1)
+	assert.Equal(topology.ROLE_FS_MDS, dcs2add[0].GetRole())
+
+	// no store to check health
+	pb, err := genScaleOutPlaybook(curveadm, dcs, dcs2add, data, scaleOutOptions{})
+	assert.Nil(err)
+	assert.Nil(getStep(pb, playbook.CHECK_STORE_HEALTH))
+	assert.NotNil(getStep(pb, playbook.START_FS_MDS))
+}
+
+func TestCheckInstanceIdUnique(t *testing.T) {
+	assert := assert.New(t)
+	curveadm := &cli.DingoAdm{}
+
+	data := SCALE_OUT_OLD_TOPOLOGY + "    - host: host4\n      config:\n        instance_start_id: 1002\n"
+	dcs := parseTestTopology(t, SCALE_OUT_OLD_TOPOLOGY)
+	dcs2add := getAddedServices(t, SCALE_OUT_OLD_TOPOLOGY, data)
+	assert.Len(dcs2add, 1)
+
+	err := checkInstanceIdUnique(curveadm, dcs, dcs2add)
+	assert.Equal(errno.ERR_INSTANCE_ID_CONFLICT_WHILE_SCALE_OUT.GetCode(), err.(*errno.ErrorCode).GetCode())
+}
+
+func TestGenScaleOutPlaybookMdsv2(t *testing.T) {
+	assert := assert.New(t)
+	curveadm := &cli.DingoAdm{}
+
+	oldData := `
+kind: dingofs
+coordinator_services:
+  deploy:
+    - host: host1
+store_services:
+  deploy:
+    - host: host1
+    - host: host2
+    - host: host3
+mds_services:
+  deploy:
+    - host: host1
+executor_services:
+  deploy:
+    - host: host1
+`
+	data := strings.Replace(oldData, "mds_services:\n  deploy:\n    - host: host1\n",
+		"mds_services:\n  deploy:\n    - host: host1\n    - host: host2\n", 1)
+	dcs := parseTestTopology(t, oldData)
+	dcs2add := getAddedServices(t, oldData, data)
+	assert.Len(dcs2add, 1)
+	assert.True(isMdsv2(dcs2add[0]))
+
+	pb, err := genScaleOutPlaybook(curveadm, dcs, dcs2add, data, scaleOutOptions{})
+	assert.Nil(err)
+	assert.Equal(SCALE_OUT_MDSV2_STEPS, getStepTypes(pb))
+
+	// create container with scale-out envs
+	step := getStep(pb, playbook.CREATE_CONTAINER)
+	assert.Equal(dcs2add, step.Configs)
+	assert.Equal(dcs2add, step.Options[comm.KEY_SCALE_OUT_CLUSTER])
+
+	// check store map through one existing store
+	step = getStep(pb, playbook.CHECK_STORE_HEALTH)
+	assert.Equal(curveadm.FilterDeployConfigByRole(dcs, topology.ROLE_STORE)[:1], step.Configs)
+	assert.Equal(dcs2add, getStep(pb, playbook.START_FS_MDS).Configs)
+}
+
+func TestGenScaleOutPlaybookExecutor(t *testing.T) {
+	assert := assert.New(t)
+	curveadm := &cli.DingoAdm{}
+
+	oldData := `
+kind: dingodb
+coordinator_services:
+  deploy:
+    - host: host1
+store_services:
+  deploy:
+    - host: host1
+executor_services:
+  deploy:
+    - host: host1
+`
+	data := oldData + "    - host: host2\n    - host: host3\n      config:\n        java.Xmx: 2g\n"
+	dcs := parseTestTopology(t, oldData)
+	dcs2add := getAddedServices(t, oldData, data)
+	assert.Len(dcs2add, 2)
+
+	pb, err := genScaleOutPlaybook(curveadm, dcs, dcs2add, data, scaleOutOptions{})
+	assert.Nil(err)
+	assert.Equal(SCALE_OUT_EXECUTOR_STEPS, getStepTypes(pb))
+
+	// only sync java opts for executor which has
+	configs := getStep(pb, playbook.SYNC_JAVA_OPTS).Configs.([]*topology.DeployConfig)
+	assert.Len(configs, 1)
+	assert.Equal("host3", configs[0].GetHost())
+	assert.Equal(dcs2add, getStep(pb, playbook.START_DINGODB_EXECUTOR).Configs)
+}
+
+func TestGenScaleOutPlaybookDingodbRoles(t *testing.T) {
+	assert := assert.New(t)
+	curveadm := &cli.DingoAdm{}
+
+	oldData := `
+kind: dingodb
+coordinator_services:
+  deploy:
+    - host: host1
+store_services:
+  deploy:
+    - host: host1
+    - host: host2
+document_services:
+  deploy:
+    - host: host1
+index_services:
+  deploy:
+    - host: host1
+diskann_services:
+  deploy:
+    - host: host1
+`
+	dcs := parseTestTopology(t, oldData)
+	stores := curveadm.FilterDeployConfigByRole(dcs, topology.ROLE_STORE)
+	for _, test := range []struct {
+		services string
+		steps    []int
+		start    int
+	}{
+		{"document_services", SCALE_OUT_DOCUMENT_STEPS, playbook.START_DINGODB_DOCUMENT},
+		{"index_services", SCALE_OUT_INDEX_STEPS, playbook.START_DINGODB_INDEX},
+		{"diskann_services", SCALE_OUT_DISKANN_STEPS, playbook.START_DINGODB_DISKANN},
+	} {
+		old := fmt.Sprintf("%s:\n  deploy:\n    - host: host1\n", test.services)
+		data := strings.Replace(oldData, old, old+"    - host: host2\n", 1)
+		dcs2add := getAddedServices(t, oldData, data)
+		assert.Len(dcs2add, 1)
+
+		pb, err := genScaleOutPlaybook(curveadm, dcs, dcs2add, data, scaleOutOptions{})
+		assert.Nil(err)
+		assert.Equal(test.steps, getStepTypes(pb))
+		assert.Equal(stores[:1], getStep(pb, playbook.CHECK_STORE_HEALTH).Configs)
+		assert.Equal(dcs2add, getStep(pb, test.start).Configs)
+	}
+}
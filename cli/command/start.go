@@ -47,10 +47,11 @@ var (
 )
 
 type startOptions struct {
-	id    string
-	role  string
-	host  string
-	force bool
+	id      string
+	role    string
+	host    string
+	force   bool
+	noOrder bool
 }
 
 func checkCommonOptions(dingoadm *cli.DingoAdm, id, role, host string) error {
@@ -96,6 +97,7 @@ func NewStartCommand(dingoadm *cli.DingoAdm) *cobra.Command {
 	flags.StringVar(&options.role, "role", "*", "Specify service role")
 	flags.StringVar(&options.host, "host", "*", "Specify service host")
 	flags.BoolVarP(&options.force, "force", "f", false, "Never prompt")
+	flags.BoolVar(&options.noOrder, "no-order", false, "Start all services at once without dependency order")
 
 	return cmd
 }
@@ -103,6 +105,7 @@ func NewStartCommand(dingoadm *cli.DingoAdm) *cobra.Command {
 func genStartPlaybook(dingoadm *cli.DingoAdm,
 	dcs []*topology.DeployConfig,
 	options startOptions) (*playbook.Playbook, error) {
+	all := dcs
 	dcs = dingoadm.FilterDeployConfig(dcs, topology.FilterOption{
 		Id:   options.id,
 		Role: options.role,
@@ -111,6 +114,9 @@ func genStartPlaybook(dingoadm *cli.DingoAdm,
 	if len(dcs) == 0 {
 		return nil, errno.ERR_NO_SERVICES_MATCHED
 	}
+	if !options.noOrder {
+		return genOrderedPlaybook(dingoadm, playbook.START_SERVICE, dcs, all, false, false)
+	}
 
 	steps := START_PLAYBOOK_STEPS
 	pb := playbook.NewPlaybook(dingoadm)
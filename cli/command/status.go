@@ -30,7 +30,12 @@ package command
 
 import (
 	"fmt"
+	"os"
+	"os/signal"
+	"sort"
 	"strings"
+	"syscall"
+	"time"
 
 	"github.com/dingodb/dingoadm/cli/cli"
 	comm "github.com/dingodb/dingoadm/internal/common"
@@ -38,13 +43,25 @@ import (
 	"github.com/dingodb/dingoadm/internal/errno"
 	"github.com/dingodb/dingoadm/internal/playbook"
 	task "github.com/dingodb/dingoadm/internal/task/task/common"
+	tuicomm "github.com/dingodb/dingoadm/internal/tui/common"
+	"github.com/dingodb/dingoadm/internal/tui/output"
 	tui "github.com/dingodb/dingoadm/internal/tui/service"
 	"github.com/dingodb/dingoadm/internal/utils"
 	cliutil "github.com/dingodb/dingoadm/internal/utils"
+	"github.com/dingodb/dingoadm/pkg/module"
 	"github.com/fatih/color"
 	"github.com/spf13/cobra"
 )
 
+const (
+	STATUS_EXAMPLE = `Examples:
+  $ dingoadm status                                   # Display status of all services
+  $ dingoadm status --history --id c0a0d6dbc8c5       # Display state transitions of service in last 24 hours
+  $ dingoadm status --history --since 168h            # Display state transitions and flapping services in last 7 days
+  $ dingoadm status --record                          # Collect and record status silently, e.g. by cron on control node:
+                                                      #   */5 * * * * dingoadm status --record >/dev/null 2>&1`
+)
+
 var (
 	GET_STATUS_PLAYBOOK_STEPS = []int{
 		playbook.INIT_SERVIE_STATUS,
@@ -60,15 +77,46 @@ type statusOptions struct {
 	showInstances bool
 	withCluster   string
 	dir           string
+	format        string
+	watch         bool
+	interval      time.Duration
+	history       bool
+	since         string
+	flapThreshold int
+	record        bool
+}
+
+type statusHistory struct {
+	Transitions []task.StatusTransition `json:"transitions" yaml:"transitions"`
+	Services    []task.FlapSummary      `json:"services" yaml:"services"`
 }
 
 func NewStatusCommand(dingoadm *cli.DingoAdm) *cobra.Command {
 	var options statusOptions
 
 	cmd := &cobra.Command{
-		Use:   "status [OPTIONS]",
-		Short: "Display service status",
-		Args:  cliutil.NoArgs,
+		Use:     "status [OPTIONS]",
+		Short:   "Display service status",
+		Args:    cliutil.NoArgs,
+		Example: STATUS_EXAMPLE,
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			if err := output.CheckFormat(options.format); err != nil {
+				return err
+			} else if options.history && (options.watch || len(options.withCluster) > 0 || options.record) {
+				return errno.ERR_UNSUPPORT_STATUS_HISTORY
+			} else if options.history && options.flapThreshold <= 0 {
+				return errno.ERR_INVALID_FLAP_THRESHOLD.F("flap threshold: %d", options.flapThreshold)
+			} else if _, err := task.ParseSince(options.since, time.Now()); options.history && err != nil {
+				return err
+			} else if output.IsStructured(options.format) && len(options.withCluster) > 0 {
+				return errno.ERR_UNSUPPORT_OUTPUT_FORMAT.F("--with-cluster only supports table format")
+			} else if output.IsStructured(options.format) && options.watch {
+				return errno.ERR_UNSUPPORT_OUTPUT_FORMAT.F("--watch only supports table format")
+			} else if options.watch && options.interval <= 0 {
+				return errno.ERR_INVALID_WATCH_INTERVAL.F("interval: %s", options.interval)
+			}
+			return nil
+		},
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return runStatus(dingoadm, options)
 		},
@@ -83,6 +131,13 @@ func NewStatusCommand(dingoadm *cli.DingoAdm) *cobra.Command {
 	flags.BoolVarP(&options.showInstances, "show-instances", "s", false, "Display service num")
 	flags.StringVarP(&options.withCluster, "with-cluster", "w", "", "Display status of specified cluster with current default cluster")
 	flags.StringVar(&options.dir, "dir", "", "Only display services which data/raft/doc/vector dirs contain specified string")
+	flags.StringVar(&options.format, "format", output.FORMAT_TABLE, output.FLAG_FORMAT_USAGE)
+	flags.BoolVar(&options.watch, "watch", false, "Refresh status periodically in full screen until interrupted")
+	flags.DurationVar(&options.interval, "interval", 5*time.Second, "Specify refresh interval for watch")
+	flags.BoolVar(&options.history, "history", false, "Display recorded state transitions and flapping services")
+	flags.StringVar(&options.since, "since", "24h", "Display history since relative duration (e.g. 24h) or time (e.g. '2025-01-01 12:00:00')")
+	flags.IntVar(&options.flapThreshold, "flap-threshold", 3, "Specify the number of restarts and state changes which service is considered as flapping")
+	flags.BoolVar(&options.record, "record", false, "Collect and record status silently without display, e.g. for cron")
 
 	return cmd
 }
@@ -144,7 +199,7 @@ func getClusterCoorRaftAddr(dcs []*topology.DeployConfig) string {
 	return value
 }
 
-func displayStatus(dingoadm *cli.DingoAdm, dcs []*topology.DeployConfig, options statusOptions) int {
+func getServiceStatuses(dingoadm *cli.DingoAdm) []task.ServiceStatus {
 	statuses := []task.ServiceStatus{}
 	value := dingoadm.MemStorage().Get(comm.KEY_ALL_SERVICE_STATUS)
 	if value != nil {
@@ -153,6 +208,37 @@ func displayStatus(dingoadm *cli.DingoAdm, dcs []*topology.DeployConfig, options
 			statuses = append(statuses, status)
 		}
 	}
+	return statuses
+}
+
+func checkServiceStatuses(statuses []task.ServiceStatus) error {
+	unhealthy := []string{}
+	for _, status := range statuses {
+		if !strings.HasPrefix(status.Status, "Up") {
+			unhealthy = append(unhealthy, status.Id)
+		}
+	}
+	if len(unhealthy) > 0 {
+		sort.Strings(unhealthy)
+		return errno.ERR_UNHEALTHY_SERVICES_FOUND.F("services: %s", strings.Join(unhealthy, ","))
+	}
+	return nil
+}
+
+func displayStructuredStatus(dingoadm *cli.DingoAdm, statuses []task.ServiceStatus, options statusOptions) error {
+	sort.Slice(statuses, func(i, j int) bool {
+		return statuses[i].Id < statuses[j].Id
+	})
+	out, err := output.Marshal(options.format, statuses)
+	if err != nil {
+		return err
+	}
+	dingoadm.WriteOut("%s", out)
+	return nil
+}
+
+func displayStatus(dingoadm *cli.DingoAdm, dcs []*topology.DeployConfig, options statusOptions) int {
+	statuses := getServiceStatuses(dingoadm)
 	excludeCols := []string{}
 	roles := dingoadm.GetRoles(dcs)
 	isMdsv2 := dcs[0].GetCtx().Lookup(topology.CTX_KEY_MDS_VERSION) == topology.CTX_VAL_MDS_V2
@@ -226,6 +312,30 @@ func displayStatus(dingoadm *cli.DingoAdm, dcs []*topology.DeployConfig, options
 	return width
 }
 
+func displayAttachClusterStatus(dingoadm *cli.DingoAdm, width int, options statusOptions) {
+	dingoadm.WriteOutln("\n%s\n", strings.Repeat("-", width))
+	storage := dingoadm.Storage()
+	attachCluster, err := storage.GetClusterByName(options.withCluster)
+	if err != nil || attachCluster.Id <= 0 {
+		dingoadm.WriteOutln("Not Found cluster: %s ", options.withCluster)
+		return
+	}
+
+	err = dingoadm.SwitchCluster(attachCluster)
+	if err != nil {
+		dingoadm.WriteOutln("Switch cluster: %s failed ", options.withCluster)
+		return
+	}
+	dcs, err := dingoadm.ParseTopology()
+	if err == nil {
+		pb, err := genStatusPlaybook(dingoadm, dcs, options)
+		if err == nil {
+			pb.Run()
+			displayStatus(dingoadm, dcs, options)
+		}
+	}
+}
+
 func genStatusPlaybook(dingoadm *cli.DingoAdm,
 	dcs []*topology.DeployConfig,
 	options statusOptions) (*playbook.Playbook, error) {
@@ -256,7 +366,7 @@ func genStatusPlaybook(dingoadm *cli.DingoAdm,
 			ExecOptions: playbook.ExecOptions{
 				//Concurrency:   10,
 				SilentSubBar:  true,
-				SilentMainBar: step == playbook.INIT_SERVIE_STATUS,
+				SilentMainBar: step == playbook.INIT_SERVIE_STATUS || output.IsStructured(options.format) || options.watch || options.record,
 				SkipError:     true,
 			},
 		})
@@ -264,46 +374,163 @@ func genStatusPlaybook(dingoadm *cli.DingoAdm,
 	return pb, nil
 }
 
+func refreshFailedMessage(err error) string {
+	if e, ok := err.(*errno.ErrorCode); ok {
+		return fmt.Sprintf("refresh failed: %s (%06d)", e.GetDescription(), e.GetCode())
+	}
+	return fmt.Sprintf("refresh failed: %s", err)
+}
+
+/*
+ * refresh status periodically until interrupted:
+ *   1) ssh clients are kept open between refreshes
+ *   2) only the changed rows are redrawn
+ *   3) the transitions (e.g. Up -> Exited, leader changed) are highlighted
+ *      and recorded in event log at the bottom
+ */
+func watchStatus(dingoadm *cli.DingoAdm, dcs []*topology.DeployConfig, options statusOptions) error {
+	pool := module.NewSSHClientPool()
+	dingoadm.SetSSHClientPool(pool)
+	defer func() {
+		dingoadm.SetSSHClientPool(nil)
+		pool.Close()
+	}()
+
+	sigs := make(chan os.Signal, 1)
+	signal.Notify(sigs, os.Interrupt, syscall.SIGTERM)
+	defer signal.Stop(sigs)
+
+	screen := tuicomm.NewScreen(dingoadm.Out())
+	screen.Open()
+	defer screen.Close()
+
+	ticker := time.NewTicker(options.interval)
+	defer ticker.Stop()
+	last := []task.ServiceStatus{}
+	events := []tui.WatchEvent{}
+	for {
+		// 1) refresh service status
+		pb, err := genStatusPlaybook(dingoadm, dcs, options)
+		if err != nil {
+			return err
+		}
+		err = pb.Run()
+		now := time.Now()
+		statuses := getServiceStatuses(dingoadm)
+		changed := tui.DiffServiceStatus(last, statuses, now)
+		events = append(events, changed...)
+		if err != nil {
+			events = append(events, tui.WatchEvent{Time: now, Kind: tui.EVENT_ERROR, Message: refreshFailedMessage(err)})
+		}
+		if len(events) > tui.WATCH_MAX_EVENTS {
+			events = events[len(events)-tui.WATCH_MAX_EVENTS:]
+		}
+		last = statuses
+
+		// 2) redraw screen
+		header := fmt.Sprintf("Every %s: dingoadm status (cluster: %s)    %s    press Ctrl+C to exit",
+			options.interval, dingoadm.ClusterName(), now.Format("2006-01-02 15:04:05"))
+		screen.Render(tui.FormatWatchStatus(header, statuses, changed, events))
+
+		// 3) wait for next refresh
+		select {
+		case <-sigs:
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+/*
+ * display status history which recorded by status/health collection:
+ *   1) state transitions (e.g. running -> exited, restart count, leader changed)
+ *   2) the flapping services whose restarts and state changes reach threshold
+ */
+func runStatusHistory(dingoadm *cli.DingoAdm, dcs []*topology.DeployConfig, options statusOptions) error {
+	// 1) filter service ids
+	dcs = dingoadm.FilterDeployConfig(dcs, topology.FilterOption{
+		Id:   options.id,
+		Role: options.role,
+		Host: options.host,
+	})
+	if len(dcs) == 0 {
+		return errno.ERR_NO_SERVICES_MATCHED
+	}
+	ids := []string{}
+	for _, dc := range dcs {
+		ids = append(ids, dingoadm.GetServiceId(dc.GetId()))
+	}
+
+	// 2) get status history
+	since, _ := task.ParseSince(options.since, time.Now())
+	histories, err := dingoadm.Storage().GetStatusHistory(dingoadm.ClusterId(), since)
+	if err != nil {
+		return errno.ERR_GET_STATUS_HISTORY_FAILED.E(err)
+	}
+	histories = task.FilterStatusHistory(histories, ids)
+	transitions := task.GetStatusTransitions(histories)
+	summaries := task.GetFlapSummaries(histories, options.flapThreshold)
+
+	// 3) display transitions and flapping services
+	if output.IsStructured(options.format) {
+		out, err := output.Marshal(options.format, statusHistory{
+			Transitions: transitions,
+			Services:    summaries,
+		})
+		if err != nil {
+			return err
+		}
+		dingoadm.WriteOut("%s", out)
+		return nil
+	}
+	dingoadm.WriteOutln("")
+	dingoadm.WriteOut("%s", tui.FormatStatusHistory(transitions, summaries))
+	return nil
+}
+
 func runStatus(dingoadm *cli.DingoAdm, options statusOptions) error {
 	// 1) parse cluster topology
 	dcs, err := dingoadm.ParseTopology()
 	if err != nil {
 		return err
+	} else if options.history {
+		return runStatusHistory(dingoadm, dcs, options)
 	}
 
 	// 2) generate get status playbook
 	pb, err := genStatusPlaybook(dingoadm, dcs, options)
 	if err != nil {
 		return err
+	} else if options.watch {
+		return watchStatus(dingoadm, dcs, options)
 	}
 
-	// 3) run playground
+	// 3) run playground, the status is recorded into history by the way
 	err = pb.Run()
+	if options.record {
+		return err
+	}
 
 	// 4) display service status
-	width := displayStatus(dingoadm, dcs, options)
-	if options.withCluster != "" {
-
-		dingoadm.WriteOutln("\n%s\n", strings.Repeat("-", width))
-		storage := dingoadm.Storage()
-		attachCluster, err := storage.GetClusterByName(options.withCluster)
-		if err != nil || attachCluster.Id <= 0 {
-			dingoadm.WriteOutln("Not Found cluster: %s ", options.withCluster)
-		} else {
-			err = dingoadm.SwitchCluster(attachCluster)
-			if err != nil {
-				dingoadm.WriteOutln("Switch cluster: %s failed ", options.withCluster)
-			} else {
-				dcs, err := dingoadm.ParseTopology()
-				if err == nil {
-					pb, err := genStatusPlaybook(dingoadm, dcs, options)
-					if err == nil {
-						pb.Run()
-						displayStatus(dingoadm, dcs, options)
-					}
-				}
-			}
+	statuses := getServiceStatuses(dingoadm)
+	if output.IsStructured(options.format) {
+		if derr := displayStructuredStatus(dingoadm, statuses, options); derr != nil {
+			return derr
 		}
+	} else {
+		width := displayStatus(dingoadm, dcs, options)
+		if options.withCluster != "" {
+			displayAttachClusterStatus(dingoadm, width, options)
+		}
+	}
+	if err != nil {
+		return err
+	}
+
+	// 5) exit with error if any service not running, only for structured
+	//    output which is consumed by automation
+	if !output.IsStructured(options.format) {
+		return nil
 	}
-	return err
+	return checkServiceStatuses(statuses)
 }
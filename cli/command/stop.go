@@ -47,10 +47,12 @@ var (
 )
 
 type stopOptions struct {
-	id    string
-	role  string
-	host  string
-	force bool
+	id      string
+	role    string
+	host    string
+	force   bool
+	noOrder bool
+	noDrain bool
 }
 
 func NewStopCommand(dingoadm *cli.DingoAdm) *cobra.Command {
@@ -74,6 +76,8 @@ func NewStopCommand(dingoadm *cli.DingoAdm) *cobra.Command {
 	flags.StringVar(&options.role, "role", "*", "Specify service role")
 	flags.StringVar(&options.host, "host", "*", "Specify service host")
 	flags.BoolVarP(&options.force, "force", "f", false, "Never prompt")
+	flags.BoolVar(&options.noOrder, "no-order", false, "Stop all services at once without dependency order")
+	flags.BoolVar(&options.noDrain, "no-drain", false, "Stop services without transferring their leaders away")
 
 	return cmd
 }
@@ -81,6 +85,7 @@ func NewStopCommand(dingoadm *cli.DingoAdm) *cobra.Command {
 func genStopPlaybook(dingoadm *cli.DingoAdm,
 	dcs []*topology.DeployConfig,
 	options stopOptions) (*playbook.Playbook, error) {
+	all := dcs
 	dcs = dingoadm.FilterDeployConfig(dcs, topology.FilterOption{
 		Id:   options.id,
 		Role: options.role,
@@ -89,14 +94,14 @@ func genStopPlaybook(dingoadm *cli.DingoAdm,
 	if len(dcs) == 0 {
 		return nil, errno.ERR_NO_SERVICES_MATCHED
 	}
+	if !options.noOrder {
+		return genOrderedPlaybook(dingoadm, playbook.STOP_SERVICE, dcs, all, true, !options.noDrain)
+	}
 
 	steps := STOP_PLAYBOOK_STEPS
 	pb := playbook.NewPlaybook(dingoadm)
 	for _, step := range steps {
-		pb.AddStep(&playbook.PlaybookStep{
-			Type:    step,
-			Configs: dcs,
-		})
+		addOperateSteps(dingoadm, pb, step, dcs, all, !options.noDrain, nil)
 	}
 	return pb, nil
 }
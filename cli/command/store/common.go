@@ -0,0 +1,95 @@
+/*
+ *  Copyright (c) 2025 dingodb.com Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: dingoadm
+ * Author: dongwei (jackblack369)
+ */
+package store
+
+import (
+	"github.com/dingodb/dingoadm/cli/cli"
+	comm "github.com/dingodb/dingoadm/internal/common"
+	"github.com/dingodb/dingoadm/internal/configure/topology"
+	"github.com/dingodb/dingoadm/internal/errno"
+	"github.com/dingodb/dingoadm/internal/playbook"
+	"github.com/dingodb/dingoadm/internal/tui/output"
+)
+
+// coordinators are preferred, dingodb_cli in store container also works
+func getDingodbCliServices(dingoadm *cli.DingoAdm, dcs []*topology.DeployConfig) []*topology.DeployConfig {
+	services := dingoadm.FilterDeployConfigByRole(dcs, topology.ROLE_COORDINATOR)
+	return append(services, dingoadm.FilterDeployConfigByRole(dcs, topology.ROLE_STORE)...)
+}
+
+/*
+ * execute dingodb_cli commands through the first service which succeed,
+ * return outputs keyed by command, the service without outputs (e.g. its
+ * task skipped) is treated as failure
+ */
+func runDingodbCli(dingoadm *cli.DingoAdm, commands []string, format string) (map[string]string, error) {
+	dcs, err := dingoadm.ParseTopology()
+	if err != nil {
+		return nil, err
+	}
+
+	services := getDingodbCliServices(dingoadm, dcs)
+	if len(services) == 0 {
+		return nil, errno.ERR_NO_SERVICE_FOR_DINGODB_CLI
+	}
+
+	for _, dc := range services {
+		pb := playbook.NewPlaybook(dingoadm)
+		pb.AddStep(&playbook.PlaybookStep{
+			Type:    playbook.RUN_DINGODB_CLI,
+			Configs: []*topology.DeployConfig{dc},
+			Options: map[string]interface{}{
+				comm.KEY_DINGODB_CLI_COMMANDS: commands,
+			},
+			ExecOptions: playbook.ExecOptions{
+				SilentSubBar:  true,
+				SilentMainBar: output.IsStructured(format),
+			},
+		})
+		// the task is skipped if service container cleaned, which leaves
+		// no outputs, so fall through to the next service
+		dingoadm.MemStorage().Set(comm.KEY_DINGODB_CLI_OUTPUTS, nil)
+		if err = pb.Run(); err != nil {
+			continue
+		} else if v := dingoadm.MemStorage().Get(comm.KEY_DINGODB_CLI_OUTPUTS); v != nil {
+			return v.(map[string]string), nil
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	return nil, errno.ERR_NO_RUNNING_SERVICE_FOR_DINGODB_CLI
+}
+
+func display(dingoadm *cli.DingoAdm, format string, v interface{}, table func() string) error {
+	if output.IsStructured(format) {
+		out, err := output.Marshal(format, v)
+		if err != nil {
+			return err
+		}
+		dingoadm.WriteOut("%s", out)
+		return nil
+	}
+
+	dingoadm.WriteOutln("")
+	dingoadm.WriteOut("%s", table())
+	return nil
+}
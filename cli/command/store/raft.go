@@ -0,0 +1,152 @@
+/*
+ *  Copyright (c) 2025 dingodb.com Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: dingoadm
+ * Author: dongwei (jackblack369)
+ */
+package store
+
+import (
+	"sort"
+	"strconv"
+
+	"github.com/dingodb/dingoadm/cli/cli"
+	comm "github.com/dingodb/dingoadm/internal/common"
+	"github.com/dingodb/dingoadm/internal/configure/topology"
+	"github.com/dingodb/dingoadm/internal/errno"
+	"github.com/dingodb/dingoadm/internal/playbook"
+	task "github.com/dingodb/dingoadm/internal/task/task/common"
+	"github.com/dingodb/dingoadm/internal/tui/output"
+	tui "github.com/dingodb/dingoadm/internal/tui/service"
+	cliutil "github.com/dingodb/dingoadm/internal/utils"
+	"github.com/spf13/cobra"
+)
+
+const (
+	RAFT_STATE_UNREACHABLE = "UNREACHABLE"
+)
+
+type raftOptions struct {
+	storeId int
+	format  string
+}
+
+func NewRaftCommand(dingoadm *cli.DingoAdm) *cobra.Command {
+	var options raftOptions
+
+	cmd := &cobra.Command{
+		Use:   "raft [OPTIONS]",
+		Short: "Display raft groups on stores",
+		Args:  cliutil.NoArgs,
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			return output.CheckFormat(options.format)
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runRaft(dingoadm, options)
+		},
+		DisableFlagsInUseLine: true,
+	}
+
+	flags := cmd.Flags()
+	flags.IntVar(&options.storeId, "store-id", 0, "Only display raft groups on specified store")
+	flags.StringVar(&options.format, "format", output.FORMAT_TABLE, output.FLAG_FORMAT_USAGE)
+
+	return cmd
+}
+
+func genRaftPlaybook(dingoadm *cli.DingoAdm,
+	dcs []*topology.DeployConfig,
+	options raftOptions) (*playbook.Playbook, []*topology.DeployConfig, error) {
+	stores := []*topology.DeployConfig{}
+	for _, dc := range dingoadm.FilterDeployConfigByRole(dcs, topology.ROLE_STORE) {
+		if options.storeId == 0 || options.storeId == dc.GetDingoInstanceId() {
+			stores = append(stores, dc)
+		}
+	}
+	if len(stores) == 0 {
+		return nil, nil, errno.ERR_NO_SERVICES_MATCHED
+	}
+
+	pb := playbook.NewPlaybook(dingoadm)
+	pb.AddStep(&playbook.PlaybookStep{
+		Type:    playbook.GET_RAFT_STAT,
+		Configs: stores,
+		ExecOptions: playbook.ExecOptions{
+			SilentSubBar:  true,
+			SilentMainBar: output.IsStructured(options.format),
+			SkipError:     true,
+		},
+	})
+	return pb, stores, nil
+}
+
+// the store which raft_stat failed is displayed as UNREACHABLE
+func getRaftNodes(dingoadm *cli.DingoAdm, stores []*topology.DeployConfig) []task.RaftNode {
+	stats := map[string]string{}
+	if v := dingoadm.MemStorage().Get(comm.KEY_ALL_RAFT_STATS); v != nil {
+		stats = v.(map[string]string)
+	}
+
+	nodes := []task.RaftNode{}
+	for _, dc := range stores {
+		storeId := strconv.Itoa(dc.GetDingoInstanceId())
+		out, ok := stats[dingoadm.GetServiceId(dc.GetId())]
+		if !ok {
+			nodes = append(nodes, task.RaftNode{
+				StoreId: storeId,
+				Host:    dc.GetHost(),
+				Group:   "-",
+				State:   RAFT_STATE_UNREACHABLE,
+				Peers:   []string{},
+			})
+			continue
+		}
+		for _, node := range task.ParseRaftNodes(out) {
+			node.StoreId = storeId
+			node.Host = dc.GetHost()
+			nodes = append(nodes, node)
+		}
+	}
+
+	sort.SliceStable(nodes, func(i, j int) bool {
+		return nodes[i].StoreId < nodes[j].StoreId
+	})
+	return nodes
+}
+
+func runRaft(dingoadm *cli.DingoAdm, options raftOptions) error {
+	// 1) parse cluster topology
+	dcs, err := dingoadm.ParseTopology()
+	if err != nil {
+		return err
+	}
+
+	// 2) generate raft playbook
+	pb, stores, err := genRaftPlaybook(dingoadm, dcs, options)
+	if err != nil {
+		return err
+	}
+
+	// 3) run playground, the unreachable store is displayed rather than failed
+	pb.Run()
+
+	// 4) display raft nodes
+	nodes := getRaftNodes(dingoadm, stores)
+	return display(dingoadm, options.format, nodes, func() string {
+		return tui.FormatRaftNodes(nodes)
+	})
+}
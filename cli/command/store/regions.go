@@ -0,0 +1,104 @@
+/*
+ *  Copyright (c) 2025 dingodb.com Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: dingoadm
+ * Author: dongwei (jackblack369)
+ */
+package store
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/dingodb/dingoadm/cli/cli"
+	task "github.com/dingodb/dingoadm/internal/task/task/common"
+	"github.com/dingodb/dingoadm/internal/tui/output"
+	tui "github.com/dingodb/dingoadm/internal/tui/service"
+	cliutil "github.com/dingodb/dingoadm/internal/utils"
+	"github.com/spf13/cobra"
+)
+
+const (
+	REGIONS_EXAMPLE = `Examples:
+  $ dingoadm store regions                    # Display all regions
+  $ dingoadm store regions --table t_user     # Display regions which name contains t_user
+  $ dingoadm store regions --store-id 1001    # Display regions which has peer on store 1001
+  $ dingoadm store regions --unhealthy        # Display regions without leader or peer`
+)
+
+type regionsOptions struct {
+	table     string
+	storeId   int
+	unhealthy bool
+	format    string
+}
+
+func NewRegionsCommand(dingoadm *cli.DingoAdm) *cobra.Command {
+	var options regionsOptions
+
+	cmd := &cobra.Command{
+		Use:     "regions [OPTIONS]",
+		Short:   "Display region distribution and leaders",
+		Args:    cliutil.NoArgs,
+		Example: REGIONS_EXAMPLE,
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			return output.CheckFormat(options.format)
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runRegions(dingoadm, options)
+		},
+		DisableFlagsInUseLine: true,
+	}
+
+	flags := cmd.Flags()
+	flags.StringVar(&options.table, "table", "", "Only display regions which name contains specified table")
+	flags.IntVar(&options.storeId, "store-id", 0, "Only display regions which has peer on specified store")
+	flags.BoolVar(&options.unhealthy, "unhealthy", false, "Only display unhealthy regions")
+	flags.StringVar(&options.format, "format", output.FORMAT_TABLE, output.FLAG_FORMAT_USAGE)
+
+	return cmd
+}
+
+func filterRegions(regions []task.Region, options regionsOptions) []task.Region {
+	filtered := []task.Region{}
+	storeId := strconv.Itoa(options.storeId)
+	for _, region := range regions {
+		if len(options.table) > 0 && !strings.Contains(region.Name, options.table) {
+			continue
+		} else if options.storeId > 0 && !cliutil.Contains(region.StoreIds, storeId) {
+			continue
+		} else if options.unhealthy && len(region.Unhealthy) == 0 {
+			continue
+		}
+		filtered = append(filtered, region)
+	}
+	return filtered
+}
+
+func runRegions(dingoadm *cli.DingoAdm, options regionsOptions) error {
+	// 1) get region map
+	outputs, err := runDingodbCli(dingoadm, []string{task.DINGODB_CLI_GET_REGION_MAP}, options.format)
+	if err != nil {
+		return err
+	}
+
+	// 2) display regions
+	regions := filterRegions(task.ParseRegionMap(outputs[task.DINGODB_CLI_GET_REGION_MAP]), options)
+	return display(dingoadm, options.format, regions, func() string {
+		return tui.FormatRegions(regions)
+	})
+}
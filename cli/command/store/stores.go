@@ -0,0 +1,74 @@
+/*
+ *  Copyright (c) 2025 dingodb.com Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: dingoadm
+ * Author: dongwei (jackblack369)
+ */
+package store
+
+import (
+	"github.com/dingodb/dingoadm/cli/cli"
+	task "github.com/dingodb/dingoadm/internal/task/task/common"
+	"github.com/dingodb/dingoadm/internal/tui/output"
+	tui "github.com/dingodb/dingoadm/internal/tui/service"
+	cliutil "github.com/dingodb/dingoadm/internal/utils"
+	"github.com/spf13/cobra"
+)
+
+type storesOptions struct {
+	format string
+}
+
+func NewStoresCommand(dingoadm *cli.DingoAdm) *cobra.Command {
+	var options storesOptions
+
+	cmd := &cobra.Command{
+		Use:   "stores [OPTIONS]",
+		Short: "Display stores with region and leader count",
+		Args:  cliutil.NoArgs,
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			return output.CheckFormat(options.format)
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runStores(dingoadm, options)
+		},
+		DisableFlagsInUseLine: true,
+	}
+
+	flags := cmd.Flags()
+	flags.StringVar(&options.format, "format", output.FORMAT_TABLE, output.FLAG_FORMAT_USAGE)
+
+	return cmd
+}
+
+func runStores(dingoadm *cli.DingoAdm, options storesOptions) error {
+	// 1) get store map and region map
+	outputs, err := runDingodbCli(dingoadm, []string{
+		task.DINGODB_CLI_GET_STORE_MAP,
+		task.DINGODB_CLI_GET_REGION_MAP,
+	}, options.format)
+	if err != nil {
+		return err
+	}
+
+	// 2) display stores
+	stores := task.ParseStoreMap(outputs[task.DINGODB_CLI_GET_STORE_MAP])
+	task.CountStoreRegions(stores, task.ParseRegionMap(outputs[task.DINGODB_CLI_GET_REGION_MAP]))
+	return display(dingoadm, options.format, stores, func() string {
+		return tui.FormatStores(stores)
+	})
+}
@@ -27,11 +27,14 @@
 package target
 
 import (
+	"sort"
+
 	"github.com/dingodb/dingoadm/cli/cli"
 	comm "github.com/dingodb/dingoadm/internal/common"
 	"github.com/dingodb/dingoadm/internal/playbook"
 	"github.com/dingodb/dingoadm/internal/task/task/bs"
 	"github.com/dingodb/dingoadm/internal/tui"
+	"github.com/dingodb/dingoadm/internal/tui/output"
 	cliutil "github.com/dingodb/dingoadm/internal/utils"
 	"github.com/spf13/cobra"
 )
@@ -43,7 +46,8 @@ var (
 )
 
 type listOptions struct {
-	host string
+	host   string
+	format string
 }
 
 func NewListCommand(curveadm *cli.DingoAdm) *cobra.Command {
@@ -54,6 +58,9 @@ func NewListCommand(curveadm *cli.DingoAdm) *cobra.Command {
 		Aliases: []string{"list"},
 		Short:   "List targets",
 		Args:    cliutil.NoArgs,
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			return output.CheckFormat(options.format)
+		},
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return runList(curveadm, options)
 		},
@@ -62,6 +69,7 @@ func NewListCommand(curveadm *cli.DingoAdm) *cobra.Command {
 
 	flags := cmd.Flags()
 	flags.StringVar(&options.host, "host", "localhost", "Specify target host")
+	flags.StringVar(&options.format, "format", output.FORMAT_TABLE, output.FLAG_FORMAT_USAGE)
 
 	return cmd
 }
@@ -73,6 +81,10 @@ func genListPlaybook(curveadm *cli.DingoAdm, options listOptions) (*playbook.Pla
 		pb.AddStep(&playbook.PlaybookStep{
 			Type:    step,
 			Configs: nil,
+			ExecOptions: playbook.ExecOptions{
+				SilentSubBar:  output.IsStructured(options.format),
+				SilentMainBar: output.IsStructured(options.format),
+			},
 			Options: map[string]interface{}{
 				comm.KEY_TARGET_OPTIONS: bs.TargetOption{
 					Host: options.host,
@@ -83,7 +95,7 @@ func genListPlaybook(curveadm *cli.DingoAdm, options listOptions) (*playbook.Pla
 	return pb, nil
 }
 
-func displayTargets(curveadm *cli.DingoAdm) {
+func displayTargets(curveadm *cli.DingoAdm, options listOptions) error {
 	targets := []bs.Target{}
 	value := curveadm.MemStorage().Get(comm.KEY_ALL_TARGETS)
 	if value != nil {
@@ -93,9 +105,22 @@ func displayTargets(curveadm *cli.DingoAdm) {
 		}
 	}
 
-	output := tui.FormatTargets(targets)
+	if output.IsStructured(options.format) {
+		sort.Slice(targets, func(i, j int) bool {
+			return targets[i].Tid < targets[j].Tid
+		})
+		out, err := output.Marshal(options.format, targets)
+		if err != nil {
+			return err
+		}
+		curveadm.WriteOut("%s", out)
+		return nil
+	}
+
+	out := tui.FormatTargets(targets)
 	curveadm.WriteOutln("")
-	curveadm.WriteOut(output)
+	curveadm.WriteOut(out)
+	return nil
 }
 
 func runList(curveadm *cli.DingoAdm, options listOptions) error {
@@ -112,6 +137,5 @@ func runList(curveadm *cli.DingoAdm, options listOptions) error {
 	}
 
 	// 3) print targets
-	displayTargets(curveadm)
-	return nil
+	return displayTargets(curveadm, options)
 }
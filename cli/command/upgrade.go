@@ -29,8 +29,12 @@
 package command
 
 import (
+	"fmt"
+	"time"
+
 	"github.com/dingodb/dingoadm/cli/cli"
 	comm "github.com/dingodb/dingoadm/internal/common"
+	"github.com/dingodb/dingoadm/internal/configure"
 	"github.com/dingodb/dingoadm/internal/configure/topology"
 	"github.com/dingodb/dingoadm/internal/errno"
 	"github.com/dingodb/dingoadm/internal/playbook"
@@ -44,6 +48,7 @@ import (
 var (
 	UPGRADE_PLAYBOOK_STEPS = []int{
 		// TODO(P0): we can skip it for upgrade one service more than once
+		playbook.RECORD_IMAGE,
 		playbook.PULL_IMAGE,
 		playbook.STOP_SERVICE,
 		playbook.CLEAN_SERVICE,
@@ -53,6 +58,7 @@ var (
 	}
 
 	UPGRADE_STORE_FS_STEPS = []int{
+		playbook.RECORD_IMAGE,
 		playbook.PULL_IMAGE,
 		playbook.STOP_SERVICE,
 		playbook.CLEAN_SERVICE,
@@ -72,6 +78,11 @@ type upgradeOptions struct {
 	host          string
 	force         bool
 	useLocalImage bool
+	rollback      bool
+	canary        int
+	observe       time.Duration
+	queries       []string
+	noDrain       bool
 }
 
 func NewUpgradeCommand(dingoadm *cli.DingoAdm) *cobra.Command {
@@ -82,6 +93,9 @@ func NewUpgradeCommand(dingoadm *cli.DingoAdm) *cobra.Command {
 		Short: "Upgrade service",
 		Args:  cliutil.NoArgs,
 		PreRunE: func(cmd *cobra.Command, args []string) error {
+			if cmd.Flags().Changed("canary") && options.canary <= 0 {
+				return errno.ERR_INVALID_CANARY_NUMBER
+			}
 			return checkCommonOptions(dingoadm, options.id, options.role, options.host)
 		},
 		RunE: func(cmd *cobra.Command, args []string) error {
@@ -96,12 +110,17 @@ func NewUpgradeCommand(dingoadm *cli.DingoAdm) *cobra.Command {
 	flags.StringVar(&options.host, "host", "*", "Specify service host")
 	flags.BoolVarP(&options.force, "force", "f", false, "Never prompt")
 	flags.BoolVar(&options.useLocalImage, "local", false, "Use local image")
+	flags.BoolVar(&options.rollback, "rollback", false, "Rollback services to the image before last upgrade")
+	flags.IntVar(&options.canary, "canary", 0, "Upgrade specified number of services first and observe them")
+	flags.DurationVar(&options.observe, "observe", 10*time.Minute, "Specify observation window for canary services")
+	flags.StringSliceVar(&options.queries, "query", []string{}, "Specify prometheus query which returns result on regression")
+	flags.BoolVar(&options.noDrain, "no-drain", false, "Upgrade services without transferring their leaders away")
 
 	return cmd
 }
 
 func genUpgradePlaybook(dingoadm *cli.DingoAdm,
-	dcs []*topology.DeployConfig,
+	all, dcs []*topology.DeployConfig,
 	options upgradeOptions) (*playbook.Playbook, error) {
 	dcs = dingoadm.FilterDeployConfig(dcs, topology.FilterOption{
 		Id:   options.id,
@@ -111,11 +130,11 @@ func genUpgradePlaybook(dingoadm *cli.DingoAdm,
 	if len(dcs) == 0 {
 		return nil, errno.ERR_NO_SERVICES_MATCHED
 	}
-	steps := UPGRADE_PLAYBOOK_STEPS
+	steps := append([]int{}, UPGRADE_PLAYBOOK_STEPS...)
 	roles := dingoadm.GetRoles(dcs)
 	if utils.Contains(roles, topology.ROLE_FS_MDS_CLI) {
 		// upgrade mds v2
-		steps = UPGRADE_STORE_FS_STEPS
+		steps = append([]int{}, UPGRADE_STORE_FS_STEPS...)
 	}
 
 	if options.useLocalImage {
@@ -145,15 +164,23 @@ func genUpgradePlaybook(dingoadm *cli.DingoAdm,
 			stepDcs = stepDcs[:n]
 		}
 
+		stepOptions := map[string]interface{}{
+			comm.KEY_CLEAN_ITEMS:      []string{comm.CLEAN_ITEM_CONTAINER},
+			comm.KEY_CLEAN_BY_RECYCLE: true,
+			comm.KEY_SKIP_MDSV2_CLI:   true,
+			comm.KEY_UPGRADE_FLAG:     true,
+		}
+
+		// transfer leaders away just before each service stopped
+		if step == playbook.STOP_SERVICE {
+			addOperateSteps(dingoadm, pb, step, stepDcs, all, !options.noDrain, stepOptions)
+			continue
+		}
+
 		pb.AddStep(&playbook.PlaybookStep{
 			Type:    step,
 			Configs: stepDcs,
-			Options: map[string]interface{}{
-				comm.KEY_CLEAN_ITEMS:      []string{comm.CLEAN_ITEM_CONTAINER},
-				comm.KEY_CLEAN_BY_RECYCLE: true,
-				comm.KEY_SKIP_MDSV2_CLI:   true,
-				comm.KEY_UPGRADE_FLAG:     true,
-			},
+			Options: stepOptions,
 		})
 	}
 	return pb, nil
@@ -161,6 +188,9 @@ func genUpgradePlaybook(dingoadm *cli.DingoAdm,
 
 func displayTitle(dingoadm *cli.DingoAdm, dcs []*topology.DeployConfig, options upgradeOptions) {
 	total := len(dcs)
+	if options.rollback {
+		dingoadm.WriteOutln(color.YellowString("Rollback services to the image before last upgrade"))
+	}
 	if options.force {
 		dingoadm.WriteOutln(color.YellowString("Upgrade %d services at once", total))
 	} else {
@@ -169,12 +199,12 @@ func displayTitle(dingoadm *cli.DingoAdm, dcs []*topology.DeployConfig, options
 	dingoadm.WriteOutln(color.YellowString("Upgrade services: %s", serviceStats(dingoadm, dcs)))
 }
 
-func upgradeAtOnce(dingoadm *cli.DingoAdm, dcs []*topology.DeployConfig, options upgradeOptions) error {
+func upgradeAtOnce(dingoadm *cli.DingoAdm, all, dcs []*topology.DeployConfig, options upgradeOptions) error {
 	// 1) display upgrade title
 	displayTitle(dingoadm, dcs, options)
 
 	// 2) generate upgrade playbook
-	pb, err := genUpgradePlaybook(dingoadm, dcs, options)
+	pb, err := genUpgradePlaybook(dingoadm, all, dcs, options)
 	if err != nil {
 		return err
 	}
@@ -191,7 +221,7 @@ func upgradeAtOnce(dingoadm *cli.DingoAdm, dcs []*topology.DeployConfig, options
 	return nil
 }
 
-func upgradeOneByOne(dingoadm *cli.DingoAdm, dcs []*topology.DeployConfig, options upgradeOptions) error {
+func upgradeOneByOne(dingoadm *cli.DingoAdm, all, dcs []*topology.DeployConfig, options upgradeOptions) error {
 	// 1) display upgrade title
 	displayTitle(dingoadm, dcs, options)
 
@@ -208,7 +238,7 @@ func upgradeOneByOne(dingoadm *cli.DingoAdm, dcs []*topology.DeployConfig, optio
 		}
 
 		// 2.2) generate upgrade playbook
-		pb, err := genUpgradePlaybook(dingoadm, []*topology.DeployConfig{dc}, options)
+		pb, err := genUpgradePlaybook(dingoadm, all, []*topology.DeployConfig{dc}, options)
 		if err != nil {
 			return err
 		}
@@ -226,15 +256,232 @@ func upgradeOneByOne(dingoadm *cli.DingoAdm, dcs []*topology.DeployConfig, optio
 	return nil
 }
 
+// return address of prometheus which deployed by monitor, e.g. 10.0.0.1:9090
+func getPrometheusAddress(dingoadm *cli.DingoAdm) (string, error) {
+	mcs, err := configure.ParseMonitor(dingoadm)
+	if err != nil {
+		return "", err
+	}
+	for _, mc := range mcs {
+		if mc.GetRole() == configure.ROLE_PROMETHEUS {
+			ip := mc.GetContext().Lookup(mc.GetHost())
+			return fmt.Sprintf("%s:%d", ip, mc.GetListenPort()), nil
+		}
+	}
+	return "", errno.ERR_NO_PROMETHEUS_FOR_CANARY_QUERY
+}
+
+func genCanaryPlaybook(dingoadm *cli.DingoAdm,
+	all, canaries []*topology.DeployConfig,
+	prometheus string,
+	options upgradeOptions) (*playbook.Playbook, error) {
+	pb, err := genUpgradePlaybook(dingoadm, all, canaries, options)
+	if err != nil {
+		return nil, err
+	}
+
+	observeOptions := map[string]interface{}{
+		comm.KEY_OBSERVE_DURATION: options.observe,
+	}
+	if len(prometheus) > 0 {
+		observeOptions[comm.KEY_PROMETHEUS_ADDRESS] = prometheus
+		observeOptions[comm.KEY_PROMETHEUS_QUERIES] = options.queries
+	}
+	pb.AddStep(&playbook.PlaybookStep{
+		Type:    playbook.OBSERVE_SERVICE,
+		Configs: canaries,
+		Options: observeOptions,
+	})
+	return pb, nil
+}
+
+/*
+ * upgrade canary services first and observe them for a while, then:
+ *   1) proceed with the rest services if no regression found
+ *   2) OR halt and report the regression, the rest services are untouched
+ */
+func upgradeCanary(dingoadm *cli.DingoAdm, all, dcs []*topology.DeployConfig, options upgradeOptions) error {
+	n := options.canary
+	if n > len(dcs) {
+		n = len(dcs)
+	}
+	canaries, rest := dcs[:n], dcs[n:]
+
+	// 1) get prometheus address for queries
+	prometheus := ""
+	if len(options.queries) > 0 {
+		address, err := getPrometheusAddress(dingoadm)
+		if err != nil {
+			return err
+		}
+		prometheus = address
+	}
+
+	// 2) display canary title
+	dingoadm.WriteOutln(color.YellowString("Upgrade %d canary services and observe them for %s",
+		len(canaries), options.observe))
+	dingoadm.WriteOutln(color.YellowString("Canary services: %s", serviceStats(dingoadm, canaries)))
+	for _, dc := range canaries {
+		dingoadm.WriteOutln("  + host=%s  role=%s  image=%s", dc.GetHost(), dc.GetRole(), dc.GetContainerImage())
+	}
+	if len(prometheus) > 0 {
+		dingoadm.WriteOutln(color.YellowString("Prometheus queries (%s): %v", prometheus, options.queries))
+	}
+	if !options.force {
+		if pass := tui.ConfirmYes(tui.DEFAULT_CONFIRM_PROMPT); !pass {
+			dingoadm.WriteOut(tui.PromptCancelOpetation("upgrade service"))
+			return errno.ERR_CANCEL_OPERATION
+		}
+	}
+
+	// 3) upgrade and observe canary services
+	pb, err := genCanaryPlaybook(dingoadm, all, canaries, prometheus, options)
+	if err != nil {
+		return err
+	}
+	err = pb.Run()
+	if err != nil {
+		dingoadm.WriteOutln("")
+		dingoadm.WriteOutln(color.RedString("Canary upgrade halted, %d services are not upgraded", len(rest)))
+		dingoadm.WriteOutln(color.RedString("Run 'dingoadm upgrade --rollback --id ID' to rollback canary service if needed"))
+		return err
+	}
+	dingoadm.WriteOutln("")
+	dingoadm.WriteOutln(color.GreenString("Canary %d services observed without regression :)", len(canaries)))
+
+	// 4) proceed with the rest services
+	if len(rest) == 0 {
+		return nil
+	} else if options.force {
+		return upgradeAtOnce(dingoadm, all, rest, options)
+	}
+	return upgradeOneByOne(dingoadm, all, rest, options)
+}
+
+// replace the image of service with the one recorded before last upgrade,
+// the rollback itself records the current image, so it can be rolled forward
+func setRollbackImage(dingoadm *cli.DingoAdm, dcs []*topology.DeployConfig) error {
+	for _, dc := range dcs {
+		serviceId := dingoadm.GetServiceId(dc.GetId())
+		images, err := dingoadm.Storage().GetServiceImage(serviceId)
+		if err != nil {
+			return errno.ERR_GET_SERVICE_IMAGE_FAILED.E(err)
+		} else if len(images) == 0 {
+			return errno.ERR_NO_PREVIOUS_IMAGE_FOR_ROLLBACK.
+				F("%s.host[%s]", dc.GetRole(), dc.GetHost())
+		}
+
+		image := images[0]
+		dc.SetContainerImage(image.Image)
+		dingoadm.WriteOutln("Rollback %s.host[%s] to image %s (%s, recorded at %s)",
+			dc.GetRole(), dc.GetHost(), image.Image, image.Digest,
+			image.RecordTime.Format("2006-01-02 15:04:05"))
+	}
+	return nil
+}
+
+/*
+ * returns the topology which services use the rollback image, the image is set
+ * in services config of role, so the role is skipped if only part of its
+ * services are rolled back or they are rolled back to different images
+ */
+func genRollbackTopology(dingoadm *cli.DingoAdm,
+	data string, all, dcs []*topology.DeployConfig) (string, error) {
+	roles := []string{}
+	images := map[string]map[string]bool{}
+	count := map[string]int{}
+	for _, dc := range dcs {
+		role := dc.GetRole()
+		if role == topology.ROLE_FS_MDS_CLI { // same as mds
+			continue
+		} else if _, ok := images[role]; !ok {
+			roles = append(roles, role)
+			images[role] = map[string]bool{}
+		}
+		images[role][dc.GetContainerImage()] = true
+		count[role]++
+	}
+
+	for _, role := range roles {
+		if len(images[role]) != 1 || count[role] != len(dingoadm.FilterDeployConfigByRole(all, role)) {
+			continue
+		}
+		for image := range images[role] {
+			out, err := topology.SetServicesConfig(data, role, topology.CONFIG_CONTAINER_IMAGE.Key(), image)
+			if err != nil {
+				return "", err
+			}
+			data = out
+		}
+	}
+	return data, nil
+}
+
+// returns roles whose services not use the rollback image in new topology,
+// e.g. part of services rolled back or the image specified in deploy config
+func getUncommittedRollbackRoles(dcs, newDcs []*topology.DeployConfig) []string {
+	images := map[string]string{}
+	for _, dc := range newDcs {
+		images[dc.GetId()] = dc.GetContainerImage()
+	}
+	roles := []string{}
+	for _, dc := range dcs {
+		if images[dc.GetId()] != dc.GetContainerImage() && !utils.Contains(roles, dc.GetRole()) {
+			roles = append(roles, dc.GetRole())
+		}
+	}
+	return roles
+}
+
+// persist the rollback image into cluster topology, otherwise the services
+// will be upgraded to the new image again when their containers recreated
+func commitRollbackImage(dingoadm *cli.DingoAdm, all, dcs []*topology.DeployConfig) error {
+	oldData := dingoadm.ClusterTopologyData()
+	data, err := genRollbackTopology(dingoadm, oldData, all, dcs)
+	if err != nil {
+		return err
+	}
+	newDcs, err := dingoadm.ParseTopologyData(data)
+	if err != nil {
+		return err
+	}
+	if data != oldData {
+		err = dingoadm.Storage().SetClusterTopology(dingoadm.ClusterId(), data)
+		if err != nil {
+			return errno.ERR_UPDATE_CLUSTER_TOPOLOGY_FAILED.E(err)
+		}
+	}
+	for _, role := range getUncommittedRollbackRoles(dcs, newDcs) {
+		dingoadm.WriteOutln(color.YellowString("WARNING: the rollback image of %s services is not committed, "+
+			"please update their 'container_image' in topology by 'dingoadm config commit'", role))
+	}
+	return nil
+}
+
+func upgradeServices(dingoadm *cli.DingoAdm, all, dcs []*topology.DeployConfig, options upgradeOptions) error {
+	// 1) upgrade canary services first
+	if options.canary > 0 {
+		return upgradeCanary(dingoadm, all, dcs, options)
+	}
+
+	// 2) OR upgrade service at once
+	if options.force {
+		return upgradeAtOnce(dingoadm, all, dcs, options)
+	}
+
+	// 3) OR upgrade service one by one
+	return upgradeOneByOne(dingoadm, all, dcs, options)
+}
+
 func runUpgrade(dingoadm *cli.DingoAdm, options upgradeOptions) error {
 	// 1) parse cluster topology
-	dcs, err := dingoadm.ParseTopology()
+	all, err := dingoadm.ParseTopology()
 	if err != nil {
 		return err
 	}
 
 	// 2) filter deploy config
-	dcs = dingoadm.FilterDeployConfig(dcs, topology.FilterOption{
+	dcs := dingoadm.FilterDeployConfig(all, topology.FilterOption{
 		Id:   options.id,
 		Role: options.role,
 		Host: options.host,
@@ -243,11 +490,21 @@ func runUpgrade(dingoadm *cli.DingoAdm, options upgradeOptions) error {
 		return errno.ERR_NO_SERVICES_MATCHED
 	}
 
-	// 3.1) upgrade service at once
-	if options.force {
-		return upgradeAtOnce(dingoadm, dcs, options)
+	// 3) use the recorded image if rollback
+	if options.rollback {
+		if err = setRollbackImage(dingoadm, dcs); err != nil {
+			return err
+		}
+	}
+
+	// 4) upgrade services
+	if err = upgradeServices(dingoadm, all, dcs, options); err != nil {
+		return err
 	}
 
-	// 3.2) OR upgrade service one by one
-	return upgradeOneByOne(dingoadm, dcs, options)
+	// 5) persist the rollback image
+	if options.rollback {
+		return commitRollbackImage(dingoadm, all, dcs)
+	}
+	return nil
 }
@@ -0,0 +1,199 @@
+package command
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dingodb/dingoadm/cli/cli"
+	comm "github.com/dingodb/dingoadm/internal/common"
+	"github.com/dingodb/dingoadm/internal/configure/topology"
+	"github.com/dingodb/dingoadm/internal/playbook"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	ROLLBACK_TOPOLOGY = `
+kind: dingo-store
+global:
+  container_image: dingodatabase/dingo-store:v2
+coordinator_services:
+  deploy:
+    - host: host1
+store_services:
+  deploy:
+    - host: host1
+    - host: host2
+`
+)
+
+func rollbackServices(dcs []*topology.DeployConfig, image string) []*topology.DeployConfig {
+	for _, dc := range dcs {
+		dc.SetContainerImage(image)
+	}
+	return dcs
+}
+
+func TestGenRollbackTopology(t *testing.T) {
+	assert := assert.New(t)
+	curveadm := &cli.DingoAdm{}
+
+	all := parseTestTopology(t, ROLLBACK_TOPOLOGY)
+	stores := curveadm.FilterDeployConfigByRole(all, topology.ROLE_STORE)
+	dcs := rollbackServices(stores, "dingodatabase/dingo-store:v1")
+
+	data, err := genRollbackTopology(curveadm, ROLLBACK_TOPOLOGY, all, dcs)
+	assert.Nil(err)
+	assert.Contains(data, "store_services:\n  config:\n    container_image: dingodatabase/dingo-store:v1\n")
+
+	newDcs := parseTestTopology(t, data)
+	assert.Empty(getUncommittedRollbackRoles(dcs, newDcs))
+	for _, dc := range newDcs {
+		image := map[bool]string{
+			true:  "dingodatabase/dingo-store:v1",
+			false: "dingodatabase/dingo-store:v2",
+		}[dc.GetRole() == topology.ROLE_STORE]
+		assert.Equal(image, dc.GetContainerImage())
+	}
+}
+
+func TestGenRollbackTopologyPartial(t *testing.T) {
+	assert := assert.New(t)
+	curveadm := &cli.DingoAdm{}
+
+	// part of services rolled back
+	all := parseTestTopology(t, ROLLBACK_TOPOLOGY)
+	stores := curveadm.FilterDeployConfigByRole(all, topology.ROLE_STORE)
+	dcs := rollbackServices(stores[:1], "dingodatabase/dingo-store:v1")
+	data, err := genRollbackTopology(curveadm, ROLLBACK_TOPOLOGY, all, dcs)
+	assert.Nil(err)
+	assert.Equal(ROLLBACK_TOPOLOGY, data)
+	assert.Equal([]string{topology.ROLE_STORE}, getUncommittedRollbackRoles(dcs, parseTestTopology(t, data)))
+
+	// image specified in deploy config takes precedence
+	origin := strings.Replace(ROLLBACK_TOPOLOGY, "    - host: host2\n",
+		"    - host: host2\n      config:\n        container_image: dingodatabase/dingo-store:v2\n", 1)
+	all = parseTestTopology(t, origin)
+	stores = curveadm.FilterDeployConfigByRole(all, topology.ROLE_STORE)
+	dcs = rollbackServices(stores, "dingodatabase/dingo-store:v1")
+	data, err = genRollbackTopology(curveadm, origin, all, dcs)
+	assert.Nil(err)
+	assert.NotEqual(origin, data)
+	assert.Equal([]string{topology.ROLE_STORE}, getUncommittedRollbackRoles(dcs, parseTestTopology(t, data)))
+}
+
+func TestGenCanaryPlaybook(t *testing.T) {
+	assert := assert.New(t)
+	curveadm := &cli.DingoAdm{}
+
+	all := parseTestTopology(t, ROLLBACK_TOPOLOGY)
+	canaries := curveadm.FilterDeployConfigByRole(all, topology.ROLE_STORE)[:1]
+	options := upgradeOptions{
+		id:      "*",
+		role:    "*",
+		host:    "*",
+		canary:  1,
+		observe: 5 * time.Minute,
+		queries: []string{"up == 0"},
+	}
+	pb, err := genCanaryPlaybook(curveadm, all, canaries, "10.0.0.1:9090", options)
+	assert.Nil(err)
+
+	// leader of canary store is transferred before it stopped, then observe it
+	assert.Equal([]int{
+		playbook.RECORD_IMAGE,
+		playbook.PULL_IMAGE,
+		playbook.TRANSFER_LEADER,
+		playbook.STOP_SERVICE,
+		playbook.CLEAN_SERVICE,
+		playbook.CREATE_CONTAINER,
+		playbook.SYNC_CONFIG,
+		playbook.START_SERVICE,
+		playbook.OBSERVE_SERVICE,
+	}, getStepTypes(pb))
+	for _, step := range pb.Steps() {
+		assert.Equal(canaries, step.Configs)
+	}
+	step := getStep(pb, playbook.OBSERVE_SERVICE)
+	assert.Equal(5*time.Minute, step.Options[comm.KEY_OBSERVE_DURATION])
+	assert.Equal("10.0.0.1:9090", step.Options[comm.KEY_PROMETHEUS_ADDRESS])
+	assert.Equal([]string{"up == 0"}, step.Options[comm.KEY_PROMETHEUS_QUERIES])
+
+	// no prometheus queries
+	options.queries = []string{}
+	pb, err = genCanaryPlaybook(curveadm, all, canaries, "", options)
+	assert.Nil(err)
+	step = getStep(pb, playbook.OBSERVE_SERVICE)
+	assert.Equal(5*time.Minute, step.Options[comm.KEY_OBSERVE_DURATION])
+	assert.Nil(step.Options[comm.KEY_PROMETHEUS_ADDRESS])
+}
+
+func TestGenUpgradePlaybookMdsv2(t *testing.T) {
+	assert := assert.New(t)
+	curveadm := &cli.DingoAdm{}
+
+	all := parseTestTopology(t, `
+kind: dingofs
+coordinator_services:
+  deploy:
+    - host: host1
+store_services:
+  deploy:
+    - host: host1
+mds_services:
+  deploy:
+    - host: host1
+    - host: host2
+executor_services:
+  deploy:
+    - host: host1
+`)
+	options := upgradeOptions{id: "*", role: "*", host: "*", noDrain: true}
+	pb, err := genUpgradePlaybook(curveadm, all, all, options)
+	assert.Nil(err)
+	assert.Equal(UPGRADE_STORE_FS_STEPS, getStepTypes(pb))
+	for _, step := range pb.Steps() {
+		assert.Equal(true, step.Options[comm.KEY_UPGRADE_FLAG])
+		assert.Equal(true, step.Options[comm.KEY_SKIP_MDSV2_CLI])
+		assert.Equal([]string{comm.CLEAN_ITEM_CONTAINER}, step.Options[comm.KEY_CLEAN_ITEMS])
+	}
+	assert.Equal(curveadm.FilterDeployConfigByRole(all, topology.ROLE_FS_MDS),
+		getStep(pb, playbook.START_FS_MDS).Configs)
+
+	// skip pull image if use local image
+	options.useLocalImage = true
+	pb, err = genUpgradePlaybook(curveadm, all, all, options)
+	assert.Nil(err)
+	assert.Nil(getStep(pb, playbook.PULL_IMAGE))
+}
+
+func TestGenUpgradePlaybookDrain(t *testing.T) {
+	assert := assert.New(t)
+	curveadm := &cli.DingoAdm{}
+
+	all := parseTestTopology(t, SCALE_OUT_OLD_TOPOLOGY)
+	stores := curveadm.FilterDeployConfigByRole(all, topology.ROLE_STORE)[:2]
+	options := upgradeOptions{id: "*", role: "*", host: "*"}
+	pb, err := genUpgradePlaybook(curveadm, all, stores, options)
+	assert.Nil(err)
+
+	// each store transfers its leaders just before it stopped, and leaders
+	// never go to the other store which is upgraded together
+	steps := []*playbook.PlaybookStep{}
+	for _, step := range pb.Steps() {
+		if step.Type == playbook.TRANSFER_LEADER || step.Type == playbook.STOP_SERVICE {
+			steps = append(steps, step)
+		}
+	}
+	assert.Len(steps, 4)
+	for i, store := range stores {
+		transfer, stop := steps[2*i], steps[2*i+1]
+		assert.Equal(playbook.TRANSFER_LEADER, transfer.Type)
+		assert.Equal([]*topology.DeployConfig{store}, transfer.Configs)
+		assert.Equal(all, transfer.Options[comm.KEY_ALL_DEPLOY_CONFIGS])
+		assert.Equal(stores, transfer.Options[comm.KEY_LEADER_EXCLUDE_CONFIGS])
+		assert.Equal(playbook.STOP_SERVICE, stop.Type)
+		assert.Equal([]*topology.DeployConfig{store}, stop.Configs)
+		assert.Equal(true, stop.Options[comm.KEY_UPGRADE_FLAG])
+	}
+}
@@ -33,6 +33,7 @@ import (
 
 	"github.com/dingodb/dingoadm/cli/cli"
 	"github.com/dingodb/dingoadm/cli/command"
+	"github.com/dingodb/dingoadm/internal/errno"
 )
 
 func Execute() {
@@ -47,6 +48,6 @@ func Execute() {
 	err = cmd.Execute()
 	dingoadm.PostAudit(id, err)
 	if err != nil {
-		os.Exit(1)
+		os.Exit(errno.ExitCode(err))
 	}
 }
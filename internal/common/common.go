@@ -68,9 +68,10 @@ const (
 	KEY_ALL_HOST_DATE            = "ALL_HOST_DATE"
 
 	// scale-out / migrate
-	KEY_SCALE_OUT_CLUSTER = "SCALE_OUT_CLUSTER"
-	KEY_MIGRATE_SERVERS   = "MIGRATE_SERVERS"
-	KEY_NEW_TOPOLOGY_DATA = "NEW_TOPOLOGY_DATA"
+	KEY_SCALE_OUT_CLUSTER     = "SCALE_OUT_CLUSTER"
+	KEY_MIGRATE_SERVERS       = "MIGRATE_SERVERS"
+	KEY_NEW_TOPOLOGY_DATA     = "NEW_TOPOLOGY_DATA"
+	KEY_EXISTING_COORDINATORS = "EXISTING_COORDINATORS"
 
 	// status
 	KEY_ALL_SERVICE_STATUS = "ALL_SERVICE_STATUS"
@@ -152,7 +153,60 @@ const (
 	KEY_SKIP_MDSV2_CLI = "SKIP_MDSV2_CLI"
 
 	// upgrade
-	KEY_UPGRADE_FLAG = "UPGRADE_FLAG"
+	KEY_UPGRADE_FLAG       = "UPGRADE_FLAG"
+	KEY_OBSERVE_DURATION   = "OBSERVE_DURATION"
+	KEY_PROMETHEUS_ADDRESS = "PROMETHEUS_ADDRESS"
+	KEY_PROMETHEUS_QUERIES = "PROMETHEUS_QUERIES"
+
+	// leader
+	KEY_LEADER_BALANCE         = "LEADER_BALANCE"
+	KEY_LEADER_EXCLUDE_CONFIGS = "LEADER_EXCLUDE_CONFIGS"
+
+	// config set
+	KEY_CONFIG_ITEM_KEY      = "CONFIG_ITEM_KEY"
+	KEY_RUNTIME_FLAG_RESULTS = "RUNTIME_FLAG_RESULTS"
+
+	// adopt
+	KEY_ALL_LABELED_CONTAINERS = "ALL_LABELED_CONTAINERS"
+
+	// check health
+	KEY_ALL_HEALTH_CHECKS = "ALL_HEALTH_CHECKS"
+
+	// dingo-store inspection
+	KEY_DINGODB_CLI_COMMANDS = "DINGODB_CLI_COMMANDS"
+	KEY_DINGODB_CLI_OUTPUTS  = "DINGODB_CLI_OUTPUTS"
+	KEY_ALL_RAFT_STATS       = "ALL_RAFT_STATS"
+
+	// logs
+	KEY_LOG_OPTIONS = "LOG_OPTIONS"
+
+	// cores
+	KEY_ALL_CORES      = "ALL_CORES"
+	KEY_CORE           = "CORE"
+	KEY_CORE_SAVE_DIR  = "CORE_SAVE_DIR"
+	KEY_CORE_BACKTRACE = "CORE_BACKTRACE"
+
+	// profile
+	KEY_PROFILE_OPTIONS = "PROFILE_OPTIONS"
+	KEY_PROFILE_FILES   = "PROFILE_FILES"
+
+	// flags
+	KEY_FLAG_NAMES = "FLAG_NAMES"
+	KEY_ALL_FLAGS  = "ALL_FLAGS"
+
+	// drift
+	KEY_ALL_DRIFTS = "ALL_DRIFTS"
+)
+
+// container labels
+const (
+	LABEL_PREFIX           = "com.dingodb.dingoadm"
+	LABEL_CLUSTER_UUID     = LABEL_PREFIX + ".cluster-uuid"
+	LABEL_SERVICE_ID       = LABEL_PREFIX + ".service-id"
+	LABEL_ROLE             = LABEL_PREFIX + ".role"
+	LABEL_KIND             = LABEL_PREFIX + ".kind"
+	LABEL_DINGOADM_VERSION = LABEL_PREFIX + ".version"
+	LABEL_TOPOLOGY_HASH    = LABEL_PREFIX + ".topology-hash"
 )
 
 // others
@@ -78,6 +78,8 @@ func (hc *HostConfig) GetSSHPort() int           { return hc.getInt(CONFIG_SSH_P
 func (hc *HostConfig) GetPrivateKeyFile() string { return hc.getString(CONFIG_PRIVATE_CONFIG_FILE) }
 func (hc *HostConfig) GetForwardAgent() bool     { return hc.getBool(CONFIG_FORWARD_AGENT) }
 func (hc *HostConfig) GetBecomeUser() string     { return hc.getString(CONFIG_BECOME_USER) }
+func (hc *HostConfig) GetZone() string           { return hc.getString(CONFIG_ZONE) }
+func (hc *HostConfig) GetRack() string           { return hc.getString(CONFIG_RACK) }
 func (hc *HostConfig) GetEnvs() []string         { return hc.envs }
 
 func (hc *HostConfig) GetLabels() []string {
@@ -101,4 +101,18 @@ var (
 		false,
 		nil,
 	)
+
+	CONFIG_ZONE = itemset.Insert(
+		"zone",
+		comm.REQUIRE_STRING,
+		false,
+		nil,
+	)
+
+	CONFIG_RACK = itemset.Insert(
+		"rack",
+		comm.REQUIRE_STRING,
+		false,
+		nil,
+	)
 )
@@ -0,0 +1,177 @@
+/*
+ *  Copyright (c) 2025 dingodb.com Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: dingoadm
+ * Author: dongwei (jackblack369)
+ */
+
+package topology
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/dingodb/dingoadm/internal/errno"
+)
+
+const (
+	DEFAULT_TOPOLOGY_INDENT = "  "
+)
+
+func indentOf(line string) string {
+	return line[:len(line)-len(strings.TrimLeft(line, " "))]
+}
+
+func isBlankOrComment(line string) bool {
+	trimed := strings.TrimSpace(line)
+	return len(trimed) == 0 || strings.HasPrefix(trimed, "#")
+}
+
+// return the end (exclusive) of block which starts at line start,
+// the block contains lines which indent is greater than the start line
+func blockEnd(lines []string, start int) int {
+	indent := len(indentOf(lines[start]))
+	end := start + 1
+	for i := start + 1; i < len(lines); i++ {
+		if isBlankOrComment(lines[i]) {
+			continue
+		} else if len(indentOf(lines[i])) <= indent {
+			break
+		}
+		end = i + 1
+	}
+	return end
+}
+
+// return the first line in [start, end) which matches pattern with indent
+func findLine(lines []string, start, end int, indent string, pattern *regexp.Regexp) int {
+	for i := start; i < end; i++ {
+		if indentOf(lines[i]) == indent && pattern.MatchString(lines[i]) {
+			return i
+		}
+	}
+	return -1
+}
+
+// return the indent of first child line in block, or parent indent + default
+func childIndent(lines []string, start, end int) string {
+	for i := start + 1; i < end; i++ {
+		if !isBlankOrComment(lines[i]) {
+			return indentOf(lines[i])
+		}
+	}
+	return indentOf(lines[start]) + DEFAULT_TOPOLOGY_INDENT
+}
+
+// SetServicesConfig sets the config item of role services in topology text,
+// e.g. the 'store_services.config', the comments and layout of topology are
+// kept as it is. The same item which specified in deploy config still takes
+// precedence over the services config.
+func SetServicesConfig(data, role, key, value string) (string, error) {
+	lines := strings.Split(data, "\n")
+	section := regexp.MustCompile(fmt.Sprintf(`^%s_services:\s*(#.*)?$`, regexp.QuoteMeta(role)))
+	start := findLine(lines, 0, len(lines), "", section)
+	if start < 0 {
+		return "", errno.ERR_NO_ROLE_SERVICES_IN_TOPOLOGY.F("%s_services", role)
+	}
+	end := blockEnd(lines, start)
+	indent := childIndent(lines, start, end)
+	item := fmt.Sprintf("%s: %s", key, value)
+
+	// 1) no config block in services, add it
+	config := regexp.MustCompile(`^\s*config:\s*(#.*)?$`)
+	cstart := findLine(lines, start+1, end, indent, config)
+	if cstart < 0 {
+		added := []string{indent + "config:", indent + DEFAULT_TOPOLOGY_INDENT + item}
+		lines = append(lines[:start+1], append(added, lines[start+1:]...)...)
+		return strings.Join(lines, "\n"), nil
+	}
+
+	// 2) replace the existing item
+	cend := blockEnd(lines, cstart)
+	cindent := childIndent(lines, cstart, cend)
+	pattern := regexp.MustCompile(fmt.Sprintf(`^\s*%s:(\s.*)?$`, regexp.QuoteMeta(key)))
+	if i := findLine(lines, cstart+1, cend, cindent, pattern); i >= 0 {
+		lines[i] = cindent + item
+		return strings.Join(lines, "\n"), nil
+	}
+
+	// 3) OR append the item into config block
+	lines = append(lines[:cend], append([]string{cindent + item}, lines[cend:]...)...)
+	return strings.Join(lines, "\n"), nil
+}
+
+// SetDeployItemConfig sets the config item of the nth (start from 0) deploy
+// item whose host is the given one in role services of topology text, e.g.
+// give the replacement of a dead store a new instance id.
+func SetDeployItemConfig(data, role, host string, nth int, key, value string) (string, error) {
+	lines := strings.Split(data, "\n")
+	section := regexp.MustCompile(fmt.Sprintf(`^%s_services:\s*(#.*)?$`, regexp.QuoteMeta(role)))
+	start := findLine(lines, 0, len(lines), "", section)
+	if start < 0 {
+		return "", errno.ERR_NO_ROLE_SERVICES_IN_TOPOLOGY.F("%s_services", role)
+	}
+	end := blockEnd(lines, start)
+
+	// 1) locate the nth deploy item on host
+	pattern := regexp.MustCompile(fmt.Sprintf(`^(\s*)(-\s*)?host:\s*["']?%s["']?\s*(#.*)?$`,
+		regexp.QuoteMeta(host)))
+	pos := -1
+	for i, n := start+1, 0; i < end; i++ {
+		if !pattern.MatchString(lines[i]) {
+			continue
+		} else if n == nth {
+			pos = i
+			break
+		}
+		n++
+	}
+	if pos < 0 {
+		return "", errno.ERR_NO_DEPLOY_ITEM_IN_TOPOLOGY.F("%s_services.deploy[host=%s][%d]", role, host, nth)
+	}
+	mu := pattern.FindStringSubmatch(lines[pos])
+	indent := mu[1] + strings.Repeat(" ", len(mu[2])) // indent of keys in deploy item
+	istart := pos
+	for len(mu[2]) == 0 && istart > start && !strings.HasPrefix(strings.TrimSpace(lines[istart]), "-") {
+		istart--
+	}
+	iend := blockEnd(lines, istart)
+	item := fmt.Sprintf("%s: %s", key, value)
+
+	// 2) no config block in deploy item, add it
+	config := regexp.MustCompile(`^\s*config:\s*(#.*)?$`)
+	cstart := findLine(lines, istart, iend, indent, config)
+	if cstart < 0 {
+		added := []string{indent + "config:", indent + DEFAULT_TOPOLOGY_INDENT + item}
+		lines = append(lines[:pos+1], append(added, lines[pos+1:]...)...)
+		return strings.Join(lines, "\n"), nil
+	}
+
+	// 3) replace the existing item
+	cend := blockEnd(lines, cstart)
+	cindent := childIndent(lines, cstart, cend)
+	pattern = regexp.MustCompile(fmt.Sprintf(`^\s*%s:(\s.*)?$`, regexp.QuoteMeta(key)))
+	if i := findLine(lines, cstart+1, cend, cindent, pattern); i >= 0 {
+		lines[i] = cindent + item
+		return strings.Join(lines, "\n"), nil
+	}
+
+	// 4) OR append the item into config block
+	lines = append(lines[:cend], append([]string{cindent + item}, lines[cend:]...)...)
+	return strings.Join(lines, "\n"), nil
+}
@@ -0,0 +1,106 @@
+package topology
+
+import (
+	"testing"
+
+	"github.com/dingodb/dingoadm/internal/errno"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSetServicesConfig(t *testing.T) {
+	assert := assert.New(t)
+
+	data := `kind: dingo-store
+coordinator_services:
+  config:
+    server.port: 6500
+store_services:
+  config:
+    server.port: 6600 # store port
+    gflags.log_level: 1
+  deploy:
+    - host: server-host1
+      config:
+        instance_start_id: 1001
+`
+	// replace existing item
+	out, err := SetServicesConfig(data, "store", "gflags.log_level", "2")
+	assert.Nil(err)
+	assert.Contains(out, "    gflags.log_level: 2\n  deploy:")
+	assert.Contains(out, "server.port: 6600 # store port")
+
+	// append new item
+	out, err = SetServicesConfig(data, "coordinator", "gflags.log_level", "2")
+	assert.Nil(err)
+	assert.Contains(out, "    server.port: 6500\n    gflags.log_level: 2\nstore_services:")
+
+	// add config block
+	out, err = SetServicesConfig("executor_services:\n  deploy:\n    - host: h1\n", "executor", "a", "b")
+	assert.Nil(err)
+	assert.Equal("executor_services:\n  config:\n    a: b\n  deploy:\n    - host: h1\n", out)
+
+	// role not found
+	_, err = SetServicesConfig(data, "mds", "a", "b")
+	assert.NotNil(err)
+}
+
+func TestSetDeployItemConfig(t *testing.T) {
+	assert := assert.New(t)
+
+	data := `coordinator_services:
+  deploy:
+    - host: host2
+store_services:
+  config:
+    raft.port: 7600
+  deploy:
+    - host: host1
+    - host: host2
+      config:
+        instance_start_id: 1002 # old
+        raft.port: 7601
+    - host: host2
+    - name: store_host2
+      host: host2
+      config:
+        raft.port: 7602
+document_services:
+  deploy:
+    - host: host2
+`
+	out, err := SetDeployItemConfig(data, ROLE_STORE, "host2", 0, "instance_start_id", "1005")
+	assert.Nil(err)
+	out, err = SetDeployItemConfig(out, ROLE_STORE, "host2", 1, "instance_start_id", "1006")
+	assert.Nil(err)
+	out, err = SetDeployItemConfig(out, ROLE_STORE, "host2", 2, "instance_start_id", "1007")
+	assert.Nil(err)
+	assert.Equal(`coordinator_services:
+  deploy:
+    - host: host2
+store_services:
+  config:
+    raft.port: 7600
+  deploy:
+    - host: host1
+    - host: host2
+      config:
+        instance_start_id: 1005
+        raft.port: 7601
+    - host: host2
+      config:
+        instance_start_id: 1006
+    - name: store_host2
+      host: host2
+      config:
+        raft.port: 7602
+        instance_start_id: 1007
+document_services:
+  deploy:
+    - host: host2
+`, out)
+
+	_, err = SetDeployItemConfig(data, ROLE_STORE, "host2", 3, "instance_start_id", "1008")
+	assert.Equal(errno.ERR_NO_DEPLOY_ITEM_IN_TOPOLOGY.GetCode(), err.(*errno.ErrorCode).GetCode())
+	_, err = SetDeployItemConfig(data, ROLE_DINGODB_INDEX, "host2", 0, "instance_start_id", "1008")
+	assert.Equal(errno.ERR_NO_ROLE_SERVICES_IN_TOPOLOGY.GetCode(), err.(*errno.ErrorCode).GetCode())
+}
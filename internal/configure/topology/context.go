@@ -29,11 +29,21 @@
 package topology
 
 type Context struct {
-	m map[string]string
+	m      map[string]string
+	hosts  []string            // hosts with labels, keep the order of hosts.yaml
+	labels map[string][]string // host -> labels
+	zones  map[string]string   // host -> zone
+	racks  map[string]string   // host -> rack
 }
 
 func NewContext() *Context {
-	return &Context{m: map[string]string{}}
+	return &Context{
+		m:      map[string]string{},
+		hosts:  []string{},
+		labels: map[string][]string{},
+		zones:  map[string]string{},
+		racks:  map[string]string{},
+	}
 }
 
 func (ctx *Context) Add(host, hostname string) {
@@ -43,3 +53,46 @@ func (ctx *Context) Add(host, hostname string) {
 func (ctx *Context) Lookup(host string) string {
 	return ctx.m[host]
 }
+
+func (ctx *Context) AddLabels(host string, labels []string) {
+	if _, ok := ctx.labels[host]; !ok {
+		ctx.hosts = append(ctx.hosts, host)
+	}
+	ctx.labels[host] = labels
+}
+
+// return hosts which has all specified labels
+func (ctx *Context) LookupByLabels(labels []string) []string {
+	hosts := []string{}
+	for _, host := range ctx.hosts {
+		has := map[string]bool{}
+		for _, label := range ctx.labels[host] {
+			has[label] = true
+		}
+
+		match := true
+		for _, label := range labels {
+			if !has[label] {
+				match = false
+				break
+			}
+		}
+		if match {
+			hosts = append(hosts, host)
+		}
+	}
+	return hosts
+}
+
+func (ctx *Context) AddLocation(host, zone, rack string) {
+	ctx.zones[host] = zone
+	ctx.racks[host] = rack
+}
+
+func (ctx *Context) LookupZone(host string) string {
+	return ctx.zones[host]
+}
+
+func (ctx *Context) LookupRack(host string) string {
+	return ctx.racks[host]
+}
@@ -69,10 +69,18 @@ const (
 	ROLE_DINGODB_EXECUTOR = "executor"
 
 	// script
-	SCRIPT_CHECK_STORE_HEALTH  = "check_store_health.sh"
-	SCRIPT_SYNC_JAVA_OPTS      = "sync_java_opts.sh"
-	SCRIPT_START_EXECUTOR      = "start-executor.sh"
-	SCRIPT_CREATE_MDSV2_TABLES = "create_mdsv2_tables.sh"
+	SCRIPT_CHECK_STORE_HEALTH      = "check_store_health.sh"
+	SCRIPT_SYNC_JAVA_OPTS          = "sync_java_opts.sh"
+	SCRIPT_START_EXECUTOR          = "start-executor.sh"
+	SCRIPT_CREATE_MDSV2_TABLES     = "create_mdsv2_tables.sh"
+	SCRIPT_WAIT_STORE_REGISTERED   = "wait_store_registered.sh"
+	SCRIPT_ADD_COORDINATOR_PEER    = "add_coordinator_peer.sh"
+	SCRIPT_REMOVE_COORDINATOR_PEER = "remove_coordinator_peer.sh"
+	SCRIPT_DRAIN_STORE             = "drain_store.sh"
+	SCRIPT_DRAIN_MDSV2             = "drain_mdsv2.sh"
+	SCRIPT_DRAIN_METASERVER        = "drain_metaserver.sh"
+	SCRIPT_TRANSFER_LEADER         = "transfer_leader.sh"
+	SCRIPT_REPLACE_ETCD_MEMBER     = "replace_etcd_member.sh"
 
 	// ctx version
 	CTX_KEY_MDS_VERSION = "mds.version"
@@ -101,6 +109,7 @@ type (
 		instances         int
 		hostSequence      int // start with 0
 		instancesSequence int // start with 0
+		roleSequence      int // start with 0, sequence of services in the same role
 
 		config        map[string]interface{}
 		serviceConfig map[string]string
@@ -185,6 +185,7 @@ func (dc *DeployConfig) GetName() string                     { return dc.name }
 func (dc *DeployConfig) GetInstances() int                   { return dc.instances }
 func (dc *DeployConfig) GetHostSequence() int                { return dc.hostSequence }
 func (dc *DeployConfig) GetInstancesSequence() int           { return dc.instancesSequence }
+func (dc *DeployConfig) GetRoleSequence() int                { return dc.roleSequence }
 func (dc *DeployConfig) GetServiceConfig() map[string]string { return dc.serviceConfig }
 func (dc *DeployConfig) GetVariables() *variable.Variables   { return dc.variables }
 func (dc *DeployConfig) GetCtx() *Context                    { return dc.ctx }
@@ -302,10 +303,23 @@ func (dc *DeployConfig) GetDingoStoreReplicaNum() int {
 	return dc.getInt(CONFIG_DINGO_STORE_REPLICA_NUM)
 }
 
+func (dc *DeployConfig) GetZone() string {
+	return dc.getString(CONFIG_ZONE)
+}
+
+func (dc *DeployConfig) GetRack() string {
+	return dc.getString(CONFIG_RACK)
+}
+
 func (dc *DeployConfig) GetDingoInstanceId() int {
 	return dc.getInt(CONFIG_INSTANCE_START_ID)
 }
 
+// the member name of etcd in ${cluster_etcd_http_addr}
+func (dc *DeployConfig) GetEtcdName() string {
+	return fmt.Sprintf("etcd%d%d", dc.GetHostSequence(), dc.GetInstancesSequence())
+}
+
 func (dc *DeployConfig) GetDingoStoreCoordinatorAddr() string {
 	return dc.getString(CONFIG_DINGOSTORE_COORDINATOR_ADDR)
 }
@@ -510,12 +510,39 @@ var (
 		},
 	)
 
+	CONFIG_ZONE = itemset.insert(
+		KIND_DINGO,
+		"zone",
+		REQUIRE_STRING,
+		true,
+		func(dc *DeployConfig) interface{} {
+			if dc.GetCtx() == nil || len(dc.GetCtx().LookupZone(dc.GetHost())) == 0 {
+				return nil
+			}
+			return dc.GetCtx().LookupZone(dc.GetHost()) // zone of host in hosts.yaml
+		},
+	)
+
+	CONFIG_RACK = itemset.insert(
+		KIND_DINGO,
+		"rack",
+		REQUIRE_STRING,
+		true,
+		func(dc *DeployConfig) interface{} {
+			if dc.GetCtx() == nil || len(dc.GetCtx().LookupRack(dc.GetHost())) == 0 {
+				return nil
+			}
+			return dc.GetCtx().LookupRack(dc.GetHost()) // rack of host in hosts.yaml
+		},
+	)
+
 	CONFIG_INSTANCE_START_ID = itemset.insert(
 		KIND_DINGO,
 		"instance_start_id",
 		REQUIRE_POSITIVE_INTEGER,
 		true,
 		func(dc *DeployConfig) interface{} {
+			// the host sequence of label-based deploy is derived from host name
 			if dc.GetInstances() > 0 {
 				return DEFAULT_STORE_INSTANCE_START_ID + dc.GetHostSequence()*dc.GetInstances() + dc.GetInstancesSequence()
 			}
@@ -0,0 +1,51 @@
+package topology
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newInstanceIdContext() *Context {
+	ctx := NewContext()
+	for _, host := range []string{"host1", "host2", "host3"} {
+		ctx.Add(host, host)
+	}
+	return ctx
+}
+
+func getInstanceIds(dcs []*DeployConfig, role string) []int {
+	ids := []int{}
+	for _, dc := range dcs {
+		if dc.GetRole() == role {
+			ids = append(ids, dc.GetDingoInstanceId())
+		}
+	}
+	return ids
+}
+
+// instance id of deployed services must not be changed, it's the identity of service in cluster
+func TestInstanceIdOfExistingTopology(t *testing.T) {
+	assert := assert.New(t)
+
+	data := `
+kind: dingo-store
+coordinator_services:
+  deploy:
+    - host: host1
+      instances: 3
+store_services:
+  deploy:
+    - host: host1
+    - host: host2
+      instances: 2
+    - host: host3
+      instances: 2
+      config:
+        instance_start_id: 2001
+`
+	dcs, err := ParseTopology(data, newInstanceIdContext())
+	assert.Nil(err)
+	assert.Equal([]int{1001, 1002, 1003}, getInstanceIds(dcs, ROLE_COORDINATOR))
+	assert.Equal([]int{1001, 1003, 1004, 2001, 2001}, getInstanceIds(dcs, ROLE_STORE))
+}
@@ -0,0 +1,136 @@
+/*
+ *  Copyright (c) 2025 dingodb.com Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: dingoadm
+ * Author: dongwei (jackblack369)
+ */
+
+package topology
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/dingodb/dingoadm/internal/errno"
+	"github.com/dingodb/dingoadm/internal/utils"
+)
+
+/*
+ * deploy:
+ *   - labels: [nvme, zone-a]
+ *     count: 3
+ *     instances: 2  # optional
+ *
+ * Label-based deploy will be expanded to `count` hosts which have all
+ * specified labels in hosts.yaml, one host one deploy (anti-affinity),
+ * and each of them runs `instances` services (default 1).
+ *
+ * Hosts are ranked by rendezvous hashing of (role, host), so the assignment
+ * is deterministic, and increasing count or adding hosts into hosts.yaml
+ * will keep the previous assignment as much as possible.
+ *
+ * The host sequence of picked host is derived from its name instead of the
+ * position, because it decides the service id and the default instance id,
+ * which must not be changed when count changed or hosts added.
+ *
+ * NOTE: hosts which specified explicitly in the same role will be skipped,
+ * but host with variable (e.g. ${machine1}) is unknown before rendering.
+ */
+
+const (
+	LABEL_HOST_SEQUENCE_START = 10000 // keep away from the sequence of explicit deploys
+	LABEL_HOST_SEQUENCE_RANGE = 90000
+)
+
+func isLabelDeploy(deploy Deploy) bool {
+	return len(deploy.Labels) > 0
+}
+
+func getLabelHostSequence(host string) int {
+	v, _ := strconv.ParseUint(utils.MD5Sum(host)[:8], 16, 32)
+	return LABEL_HOST_SEQUENCE_START + int(v%LABEL_HOST_SEQUENCE_RANGE)
+}
+
+func rankHosts(role string, hosts []string) []string {
+	ranked := append([]string{}, hosts...)
+	score := map[string]string{}
+	for _, host := range ranked {
+		score[host] = utils.MD5Sum(fmt.Sprintf("%s_%s", role, host))
+	}
+	sort.SliceStable(ranked, func(i, j int) bool {
+		if score[ranked[i]] == score[ranked[j]] {
+			return ranked[i] < ranked[j]
+		}
+		return score[ranked[i]] < score[ranked[j]]
+	})
+	return ranked
+}
+
+// return the hosts of each deploy, the index is the deploy sequence
+func placeDeploys(ctx *Context, role string, deploys []Deploy) ([][]string, error) {
+	placement := make([][]string, len(deploys))
+
+	// (1) hosts which specified explicitly are occupied
+	occupied := map[string]bool{}
+	for i, deploy := range deploys {
+		if !isLabelDeploy(deploy) {
+			placement[i] = []string{deploy.Host}
+			occupied[deploy.Host] = true
+		} else if len(deploy.Host) > 0 {
+			return nil, errno.ERR_DEPLOY_HOST_AND_LABELS_CONFLICT.
+				F("%s: host=%s labels=%v", role, deploy.Host, deploy.Labels)
+		} else if deploy.Count <= 0 {
+			return nil, errno.ERR_DEPLOY_COUNT_REQUIRES_POSITIVE.
+				F("%s: labels=%v count=%d", role, deploy.Labels, deploy.Count)
+		}
+	}
+
+	// (2) pick hosts for label-based deploy
+	for i, deploy := range deploys {
+		if !isLabelDeploy(deploy) {
+			continue
+		}
+
+		candidates := []string{}
+		for _, host := range ctx.LookupByLabels(deploy.Labels) {
+			if !occupied[host] {
+				candidates = append(candidates, host)
+			}
+		}
+		if len(candidates) < deploy.Count {
+			return nil, errno.ERR_NO_ENOUGH_HOSTS_MATCH_LABELS.
+				F("%s: labels=[%s] require %d hosts, but only %d available",
+					role, strings.Join(deploy.Labels, ", "), deploy.Count, len(candidates))
+		}
+
+		picked := map[string]bool{}
+		for _, host := range rankHosts(role, candidates)[:deploy.Count] {
+			picked[host] = true
+			occupied[host] = true
+		}
+		// keep the order of hosts.yaml
+		for _, host := range candidates {
+			if picked[host] {
+				placement[i] = append(placement[i], host)
+			}
+		}
+	}
+
+	return placement, nil
+}
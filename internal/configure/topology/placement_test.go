@@ -0,0 +1,126 @@
+package topology
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/dingodb/dingoadm/internal/errno"
+	"github.com/stretchr/testify/assert"
+)
+
+func newLabelContext() *Context {
+	ctx := NewContext()
+	ctx.AddLabels("host1", []string{"nvme", "zone-a"})
+	ctx.AddLabels("host2", []string{"nvme", "zone-a"})
+	ctx.AddLabels("host3", []string{"nvme", "zone-b"})
+	ctx.AddLabels("host4", []string{"nvme", "zone-a"})
+	ctx.AddLabels("host5", []string{"hdd", "zone-a"})
+	return ctx
+}
+
+func TestLookupByLabels(t *testing.T) {
+	assert := assert.New(t)
+	ctx := newLabelContext()
+	assert.Equal([]string{"host1", "host2", "host4"}, ctx.LookupByLabels([]string{"nvme", "zone-a"}))
+	assert.Equal([]string{"host3"}, ctx.LookupByLabels([]string{"zone-b"}))
+	assert.Equal([]string{}, ctx.LookupByLabels([]string{"ssd"}))
+}
+
+func TestPlaceDeploys(t *testing.T) {
+	assert := assert.New(t)
+	ctx := newLabelContext()
+
+	deploys := []Deploy{
+		{Host: "host1"},
+		{Labels: []string{"nvme", "zone-a"}, Count: 2},
+	}
+	placement, err := placeDeploys(ctx, ROLE_STORE, deploys)
+	assert.Nil(err)
+	assert.Equal([]string{"host1"}, placement[0])
+	assert.Len(placement[1], 2)
+	assert.NotContains(placement[1], "host1") // anti-affinity
+
+	// deterministic
+	again, err := placeDeploys(ctx, ROLE_STORE, deploys)
+	assert.Nil(err)
+	assert.Equal(placement, again)
+}
+
+func TestPlaceDeploysStable(t *testing.T) {
+	assert := assert.New(t)
+	ctx := newLabelContext()
+
+	small, err := placeDeploys(ctx, ROLE_STORE, []Deploy{{Labels: []string{"nvme"}, Count: 2}})
+	assert.Nil(err)
+	large, err := placeDeploys(ctx, ROLE_STORE, []Deploy{{Labels: []string{"nvme"}, Count: 3}})
+	assert.Nil(err)
+	for _, host := range small[0] {
+		assert.Contains(large[0], host)
+	}
+}
+
+func TestPlaceDeploysInvalid(t *testing.T) {
+	assert := assert.New(t)
+	ctx := newLabelContext()
+
+	_, err := placeDeploys(ctx, ROLE_STORE, []Deploy{{Host: "host1", Labels: []string{"nvme"}, Count: 1}})
+	assert.Equal(errno.ERR_DEPLOY_HOST_AND_LABELS_CONFLICT.GetCode(), err.(*errno.ErrorCode).GetCode())
+	_, err = placeDeploys(ctx, ROLE_STORE, []Deploy{{Labels: []string{"nvme"}}})
+	assert.Equal(errno.ERR_DEPLOY_COUNT_REQUIRES_POSITIVE.GetCode(), err.(*errno.ErrorCode).GetCode())
+	_, err = placeDeploys(ctx, ROLE_STORE, []Deploy{{Labels: []string{"zone-b"}, Count: 2}})
+	assert.Equal(errno.ERR_NO_ENOUGH_HOSTS_MATCH_LABELS.GetCode(), err.(*errno.ErrorCode).GetCode())
+}
+
+func TestParseTopologyLabelHostSequence(t *testing.T) {
+	assert := assert.New(t)
+	ctx := newLabelContext()
+	for _, host := range []string{"host1", "host2", "host3", "host4", "host5"} {
+		ctx.Add(host, host)
+	}
+
+	parse := func(count int) map[string]*DeployConfig {
+		data := fmt.Sprintf(`
+kind: dingo-store
+coordinator_services:
+  deploy:
+    - host: host1
+store_services:
+  deploy:
+    - labels: [nvme]
+      count: %d
+    - host: host5
+`, count)
+		dcs, err := ParseTopology(data, ctx)
+		assert.Nil(err)
+		stores := map[string]*DeployConfig{}
+		for _, dc := range dcs {
+			if dc.GetRole() == ROLE_STORE {
+				stores[dc.GetHost()] = dc
+			}
+		}
+		return stores
+	}
+
+	// the explicit deploy keeps its sequence
+	stores := parse(3)
+	assert.Equal(1, stores["host5"].GetHostSequence())
+	assert.Equal("store_host5_1_0", stores["host5"].GetId())
+
+	// each host picked by labels has its own instance id
+	ids := map[int]bool{}
+	for _, dc := range stores {
+		assert.False(ids[dc.GetDingoInstanceId()], "duplicate instance id %d", dc.GetDingoInstanceId())
+		ids[dc.GetDingoInstanceId()] = true
+	}
+	assert.Len(ids, 4)
+
+	// service id and instance id are stable when count changed
+	for count := 1; count <= 4; count++ {
+		for host, dc := range parse(count) {
+			if old, ok := stores[host]; ok {
+				assert.Equal(old.GetId(), dc.GetId())
+				assert.Equal(old.GetDingoInstanceId(), dc.GetDingoInstanceId())
+			}
+		}
+	}
+}
@@ -0,0 +1,219 @@
+/*
+ *  Copyright (c) 2025 dingodb.com Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: dingoadm
+ * Author: dongwei (jackblack369)
+ */
+
+package topology
+
+import (
+	"strconv"
+
+	"github.com/dingodb/dingoadm/internal/errno"
+	"github.com/dingodb/dingoadm/internal/utils"
+)
+
+/*
+ * Port allocation:
+ *
+ * The port which omitted in topology will be allocated from the range
+ * [default, default + size) of its role, the first port which not used
+ * by any other service on the same host wins. Ports specified explicitly
+ * are never changed, so they are reserved before allocation.
+ *
+ * The allocation only depends on the order of deploy configs, so the
+ * result is stable as long as the topology is not changed.
+ *
+ * The config was rendered against the default ports before allocation,
+ * so the service whose port changed is rendered again from its original
+ * config, in which the allocated ports are pinned.
+ */
+
+const (
+	DEFAULT_PORT_RANGE_SIZE = 20
+)
+
+var (
+	portRangeSize = map[string]int{
+		ROLE_CHUNKSERVER:      100,
+		ROLE_METASERVER:       100,
+		ROLE_STORE:            100,
+		ROLE_DINGODB_DOCUMENT: 100,
+		ROLE_DINGODB_INDEX:    100,
+		ROLE_DINGODB_DISKANN:  100,
+	}
+
+	// service variable which should be refreshed after port allocated
+	portVariables = map[*item]string{
+		CONFIG_LISTEN_PORT:          "service_port",
+		CONFIG_LISTEN_CLIENT_PORT:   "service_client_port",
+		CONFIG_LISTEN_DUMMY_PORT:    "service_dummy_port",
+		CONFIG_LISTEN_PROXY_PORT:    "service_proxy_port",
+		CONFIG_LISTEN_EXTERNAL_PORT: "service_external_port",
+	}
+)
+
+type ServicePort struct {
+	Name string // config item key, e.g. server.port
+	Port int
+}
+
+// return all port items which the service will listen on
+func getPortItems(dc *DeployConfig) []*item {
+	switch dc.GetRole() {
+	case ROLE_ETCD:
+		return []*item{CONFIG_LISTEN_PORT, CONFIG_LISTEN_CLIENT_PORT}
+	case ROLE_CHUNKSERVER:
+		return []*item{CONFIG_LISTEN_PORT}
+	case ROLE_METASERVER:
+		if dc.GetEnableExternalServer() {
+			return []*item{CONFIG_LISTEN_PORT, CONFIG_LISTEN_EXTERNAL_PORT}
+		}
+		return []*item{CONFIG_LISTEN_PORT}
+	case ROLE_SNAPSHOTCLONE:
+		return []*item{CONFIG_LISTEN_PORT, CONFIG_LISTEN_DUMMY_PORT, CONFIG_LISTEN_PROXY_PORT}
+	case ROLE_FS_MDS:
+		if dc.GetCtx() != nil && dc.GetCtx().Lookup(CTX_KEY_MDS_VERSION) == CTX_VAL_MDS_V2 {
+			return []*item{CONFIG_DINGO_STORE_SERVER_PORT, CONFIG_LISTEN_DUMMY_PORT}
+		}
+		return []*item{CONFIG_LISTEN_PORT, CONFIG_LISTEN_DUMMY_PORT}
+	case ROLE_COORDINATOR,
+		ROLE_STORE,
+		ROLE_DINGODB_DOCUMENT,
+		ROLE_DINGODB_INDEX:
+		return []*item{CONFIG_DINGO_STORE_SERVER_PORT, CONFIG_DINGO_STORE_RAFT_PORT}
+	case ROLE_DINGODB_DISKANN:
+		return []*item{CONFIG_DINGO_STORE_SERVER_PORT}
+	case ROLE_DINGODB_EXECUTOR:
+		return []*item{CONFIG_DINGODB_SERVER_PORT, CONFIG_DINGODB_EXECUTOR_MYSQL_PORT,
+			CONFIG_DINGODB_WEB_EXPORT_PORT}
+	case ROLE_DINGODB_WEB:
+		return []*item{CONFIG_DINGODB_SERVER_PORT, CONFIG_DINGODB_WEB_EXPORT_PORT}
+	case ROLE_DINGODB_PROXY:
+		return []*item{CONFIG_DINGODB_SERVER_PORT}
+	}
+	return []*item{}
+}
+
+func GetServicePorts(dc *DeployConfig) []ServicePort {
+	ports := []ServicePort{}
+	for _, item := range getPortItems(dc) {
+		ports = append(ports, ServicePort{
+			Name: item.key,
+			Port: dc.getInt(item),
+		})
+	}
+	return ports
+}
+
+// return the original config of each deploy config which used to render again,
+// it MUST be invoked before deploy config build
+func getRawConfigs(dcs []*DeployConfig) []map[string]interface{} {
+	raws := []map[string]interface{}{}
+	for _, dc := range dcs {
+		raws = append(raws, utils.DeepCopy(dc.config))
+	}
+	return raws
+}
+
+// return config keys which specified in topology for each deploy config,
+// it MUST be invoked before deploy config build (default value will be filled)
+func getExplicitKeys(dcs []*DeployConfig) []map[string]bool {
+	explicit := []map[string]bool{}
+	for _, dc := range dcs {
+		keys := map[string]bool{}
+		for k := range dc.config {
+			keys[k] = true
+		}
+		explicit = append(explicit, keys)
+	}
+	return explicit
+}
+
+func getPortRange(dc *DeployConfig, defaultPort int) (int, int) {
+	size, ok := portRangeSize[dc.GetRole()]
+	if !ok {
+		size = DEFAULT_PORT_RANGE_SIZE
+	}
+	return defaultPort, defaultPort + size
+}
+
+func (dc *DeployConfig) setPort(i *item, port int) {
+	dc.config[i.key] = port
+	if !i.exclude {
+		dc.serviceConfig[i.key] = strconv.Itoa(port)
+	}
+	if name, ok := portVariables[i]; ok {
+		dc.GetVariables().Set(name, strconv.Itoa(port)) // ignore unregistered variable
+	}
+}
+
+// render config again with allocated ports, e.g. "${service_port}" in other config items
+func (dc *DeployConfig) rebuild(raw map[string]interface{}, ports map[*item]int) error {
+	dc.config = utils.DeepCopy(raw)
+	for item, port := range ports {
+		dc.config[item.key] = strconv.Itoa(port)
+	}
+	return dc.Build()
+}
+
+// return the ports which allocated different from default for each deploy config
+func allocatePorts(dcs []*DeployConfig, explicit []map[string]bool) ([]map[*item]int, error) {
+	// (1) reserve ports which specified explicitly
+	used := map[string]map[int]bool{} // hostname: ports
+	for idx, dc := range dcs {
+		host := dc.GetHostname()
+		if _, ok := used[host]; !ok {
+			used[host] = map[int]bool{}
+		}
+		for _, item := range getPortItems(dc) {
+			if explicit[idx][item.key] {
+				used[host][dc.getInt(item)] = true
+			}
+		}
+	}
+
+	// (2) allocate omitted ports from the range of role
+	allocated := []map[*item]int{}
+	for idx, dc := range dcs {
+		allocated = append(allocated, map[*item]int{})
+		host := dc.GetHostname()
+		for _, item := range getPortItems(dc) {
+			if explicit[idx][item.key] {
+				continue
+			}
+
+			defaultPort := dc.getInt(item)
+			start, end := getPortRange(dc, defaultPort)
+			port := start
+			for ; port < end && used[host][port]; port++ {
+			}
+			if port >= end {
+				return nil, errno.ERR_NO_AVAILABLE_PORT_IN_RANGE.
+					F("%s.host[%s] %s: [%d, %d)", dc.GetRole(), dc.GetHost(), item.key, start, end)
+			}
+
+			used[host][port] = true
+			if port != defaultPort {
+				dc.setPort(item, port)
+				allocated[idx][item] = port
+			}
+		}
+	}
+	return allocated, nil
+}
@@ -0,0 +1,97 @@
+package topology
+
+import (
+	"fmt"
+	"strconv"
+	"testing"
+
+	"github.com/dingodb/dingoadm/internal/errno"
+	"github.com/stretchr/testify/assert"
+)
+
+func newPortsDeployConfigs(t *testing.T, instances int) []*DeployConfig {
+	dcs := []*DeployConfig{}
+	for i := 0; i < instances; i++ {
+		dc, err := NewDeployConfig(nil, KIND_DINGOSTORE, ROLE_STORE, "host1", "", instances, 0, i,
+			map[string]interface{}{})
+		assert.Nil(t, err)
+		assert.Nil(t, dc.ResolveHost())
+		dcs = append(dcs, dc)
+	}
+	return dcs
+}
+
+func TestAllocatePorts(t *testing.T) {
+	assert := assert.New(t)
+
+	dcs := newPortsDeployConfigs(t, 3)
+	dcs[2].config["server.port"] = "6600" // explicit port is never changed
+	explicit := getExplicitKeys(dcs)
+	for _, dc := range dcs {
+		assert.Nil(dc.Build())
+	}
+
+	_, err := allocatePorts(dcs, explicit)
+	assert.Nil(err)
+	assert.Equal(6601, dcs[0].GetDingoServerPort())
+	assert.Equal(7600, dcs[0].GetDingoStoreRaftPort())
+	assert.Equal(6602, dcs[1].GetDingoServerPort())
+	assert.Equal(7601, dcs[1].GetDingoStoreRaftPort())
+	assert.Equal(6600, dcs[2].GetDingoServerPort())
+	assert.Equal(7602, dcs[2].GetDingoStoreRaftPort())
+}
+
+func TestAllocatePortsExhausted(t *testing.T) {
+	assert := assert.New(t)
+
+	dcs := newPortsDeployConfigs(t, DEFAULT_PORT_RANGE_SIZE+1)
+	for _, dc := range dcs {
+		dc.role = ROLE_COORDINATOR
+	}
+	explicit := getExplicitKeys(dcs)
+	for _, dc := range dcs {
+		assert.Nil(dc.Build())
+	}
+
+	_, err := allocatePorts(dcs, explicit)
+	assert.Equal(errno.ERR_NO_AVAILABLE_PORT_IN_RANGE.GetCode(), err.(*errno.ErrorCode).GetCode())
+}
+
+func TestAllocatePortsRenderAgain(t *testing.T) {
+	assert := assert.New(t)
+	ctx := NewContext()
+	ctx.Add("host1", "10.0.0.1")
+
+	data := `
+kind: dingofs
+etcd_services:
+  deploy:
+    - host: host1
+mds_services:
+  config:
+    mds.listen.addr: ${service_addr}:${service_port}
+  deploy:
+    - host: host1
+      instances: 2
+metaserver_services:
+  deploy:
+    - host: host1
+`
+	dcs, err := ParseTopology(data, ctx)
+	assert.Nil(err)
+
+	ports := []int{}
+	for _, dc := range dcs {
+		if dc.GetRole() != ROLE_FS_MDS {
+			continue
+		}
+		ports = append(ports, dc.GetListenPort())
+		value := dc.GetServiceConfig()["mds.listen.addr"]
+		assert.Equal(fmt.Sprintf("10.0.0.1:%d", dc.GetListenPort()), value)
+		port, err := dc.GetVariables().Get("service_port")
+		assert.Nil(err)
+		assert.Equal(strconv.Itoa(dc.GetListenPort()), port)
+	}
+	assert.Len(ports, 2)
+	assert.NotEqual(ports[0], ports[1])
+}
@@ -0,0 +1,58 @@
+/*
+ *  Copyright (c) 2025 dingodb.com Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: dingoadm
+ * Author: dongwei (jackblack369)
+ */
+
+package topology
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// ReplaceHost rewrites the host of deploy items in topology text from one
+// host to another, the comments and layout of topology are kept as it is.
+// It returns the new topology and the number of replaced deploy items,
+// deploy items placed by variable or labels are not replaced.
+func ReplaceHost(data, from, to string) (string, int) {
+	pattern := regexp.MustCompile(fmt.Sprintf(`^(\s*-?\s*host:\s*)(["']?)%s(["']?)(\s*(#.*)?)$`,
+		regexp.QuoteMeta(from)))
+
+	count := 0
+	lines := strings.Split(data, "\n")
+	for i, line := range lines {
+		if pattern.MatchString(line) {
+			lines[i] = pattern.ReplaceAllString(line, fmt.Sprintf("${1}${2}%s${3}${4}", to))
+			count++
+		}
+	}
+	return strings.Join(lines, "\n"), count
+}
+
+// SetServiceConfig overrides the service config which will be synced into
+// the config file of service, e.g. join an existing etcd cluster.
+func (dc *DeployConfig) SetServiceConfig(key, value string) {
+	dc.serviceConfig[strings.ToLower(key)] = value
+}
+
+// SetContainerImage overrides the image of service, e.g. rollback upgrade.
+func (dc *DeployConfig) SetContainerImage(image string) {
+	dc.config[CONFIG_CONTAINER_IMAGE.key] = image
+}
@@ -0,0 +1,66 @@
+package topology
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReplaceHost(t *testing.T) {
+	assert := assert.New(t)
+
+	data := `store_services:
+  deploy:
+    - host: server-host1
+    - host: server-host10 # another host
+    - host: "server-host1"  # quoted
+    - name: store_host1
+      host: server-host1
+`
+	out, count := ReplaceHost(data, "server-host1", "server-host4")
+	assert.Equal(3, count)
+	assert.Equal(`store_services:
+  deploy:
+    - host: server-host4
+    - host: server-host10 # another host
+    - host: "server-host4"  # quoted
+    - name: store_host1
+      host: server-host4
+`, out)
+
+	_, count = ReplaceHost(data, "server-host2", "server-host4")
+	assert.Equal(0, count)
+}
+
+// the replacement joins etcd cluster with the name in ${cluster_etcd_http_addr}
+func TestReplaceHostEtcdName(t *testing.T) {
+	assert := assert.New(t)
+	ctx := NewContext()
+	for _, host := range []string{"host1", "host2", "host3", "host4"} {
+		ctx.Add(host, host)
+	}
+
+	data := `
+kind: dingofs
+etcd_services:
+  config:
+    listen.port: 2380
+  deploy:
+    - host: host1
+    - host: host2
+    - host: host3
+`
+	old, err := ParseTopology(data, ctx)
+	assert.Nil(err)
+	out, count := ReplaceHost(data, "host2", "host4")
+	assert.Equal(1, count)
+	dcs, err := ParseTopology(out, ctx)
+	assert.Nil(err)
+
+	assert.Equal("host2", old[1].GetHost())
+	assert.Equal("host4", dcs[1].GetHost())
+	assert.Equal(old[1].GetEtcdName(), dcs[1].GetEtcdName())
+	value, err := dcs[0].GetVariables().Get("cluster_etcd_http_addr")
+	assert.Nil(err)
+	assert.Equal("etcd00=http://host1:2380,etcd10=http://host4:2380,etcd20=http://host3:2380", value)
+}
@@ -43,6 +43,10 @@ type (
 		Replica   int                    `mapstructure:"replica"`  // old version
 		Replicas  int                    `mapstructure:"replicas"` // old version
 		Instances int                    `mapstructure:"instances"`
+		Labels    []string               `mapstructure:"labels"` // label-based placement
+		Count     int                    `mapstructure:"count"`  // number of hosts for label-based placement
+		Zone      string                 `mapstructure:"zone"`   // failure domain, override zone of host
+		Rack      string                 `mapstructure:"rack"`   // failure domain, override rack of host
 		Config    map[string]interface{} `mapstructure:"config"`
 	}
 
@@ -217,6 +221,14 @@ func ParseTopology(data string, ctx *Context) ([]*DeployConfig, error) {
 			// just keep one deploy config
 			tmpDeploy := topology.MdsServices.Deploy[0]
 			tmpDeploy.Replicas = 0
+			if isLabelDeploy(tmpDeploy) { // the host of first mds
+				placement, err := placeDeploys(ctx, ROLE_FS_MDS, topology.MdsServices.Deploy)
+				if err != nil {
+					return nil, err
+				}
+				tmpDeploy.Host = placement[0][0]
+				tmpDeploy.Labels = nil
+			}
 			services = Service{
 				Config: newIfNil(topology.MdsServices.Config),
 				Deploy: []Deploy{tmpDeploy},
@@ -231,10 +243,24 @@ func ParseTopology(data string, ctx *Context) ([]*DeployConfig, error) {
 		servicesConfig := newIfNil(services.Config)
 		merge(globalConfig, servicesConfig, 1)
 
-		for hostSequence, deploy := range services.Deploy {
+		// expand label-based deploy into hosts
+		placement, err := placeDeploys(ctx, role, services.Deploy)
+		if err != nil {
+			return nil, err // already is error code
+		}
+
+		roleSequence := 0
+		labelHosts := map[int]string{} // host sequence -> host picked by labels
+		for i, deploy := range services.Deploy {
 			// merge services config into deploy config
 			deployConfig := newIfNil(deploy.Config)
 			merge(servicesConfig, deployConfig, 1)
+			if len(deploy.Zone) > 0 {
+				deployConfig[CONFIG_ZONE.key] = deploy.Zone
+			}
+			if len(deploy.Rack) > 0 {
+				deployConfig[CONFIG_RACK.key] = deploy.Rack
+			}
 
 			// create deploy config
 			instances := 1
@@ -255,19 +281,34 @@ func ParseTopology(data string, ctx *Context) ([]*DeployConfig, error) {
 				instances = deploy.Replica
 			}
 
-			for instancesSequence := 0; instancesSequence < instances; instancesSequence++ {
-				dc, err := NewDeployConfig(ctx, kind,
-					role, deploy.Host, deploy.Name, instances,
-					hostSequence, instancesSequence, utils.DeepCopy(deployConfig))
-				if err != nil {
-					return nil, err // already is error code
+			for _, host := range placement[i] {
+				hostSequence := i
+				if isLabelDeploy(deploy) {
+					hostSequence = getLabelHostSequence(host)
+					if other, ok := labelHosts[hostSequence]; ok && other != host {
+						return nil, errno.ERR_LABEL_HOST_SEQUENCE_CONFLICT.
+							F("%s: %s and %s, deploy one of them by host", role, other, host)
+					}
+					labelHosts[hostSequence] = host
+				}
+				for instancesSequence := 0; instancesSequence < instances; instancesSequence++ {
+					dc, err := NewDeployConfig(ctx, kind,
+						role, host, deploy.Name, instances,
+						hostSequence, instancesSequence, utils.DeepCopy(deployConfig))
+					if err != nil {
+						return nil, err // already is error code
+					}
+					dc.roleSequence = roleSequence
+					roleSequence++
+					dcs = append(dcs, dc)
 				}
-				dcs = append(dcs, dc)
 			}
 		}
 	}
 
 	// add service variables
+	explicit := getExplicitKeys(dcs)
+	raws := getRawConfigs(dcs)
 	exist := map[string]bool{}
 	for idx, dc := range dcs {
 		if err = dc.ResolveHost(); err != nil {
@@ -283,6 +324,19 @@ func ParseTopology(data string, ctx *Context) ([]*DeployConfig, error) {
 		}
 	}
 
+	// allocate ports which omitted in topology
+	allocated, err := allocatePorts(dcs, explicit)
+	if err != nil {
+		return nil, err // already is error code
+	}
+	for idx, dc := range dcs {
+		if len(allocated[idx]) == 0 {
+			continue
+		} else if err = dc.rebuild(raws[idx], allocated[idx]); err != nil {
+			return nil, err // already is error code
+		}
+	}
+
 	// add cluster variables
 	for idx, dc := range dcs {
 		if err = AddClusterVariables(dcs, idx); err != nil {
@@ -186,11 +186,9 @@ func joinEtcdPeer(dcs []*DeployConfig) string {
 			continue
 		}
 
-		hostSequence := dc.GetHostSequence()
-		instanceSquence := dc.GetInstancesSequence()
 		peerHost := dc.GetListenIp()
 		peerPort := dc.GetListenPort()
-		peer := fmt.Sprintf("etcd%d%d=http://%s:%d", hostSequence, instanceSquence, peerHost, peerPort)
+		peer := fmt.Sprintf("%s=http://%s:%d", dc.GetEtcdName(), peerHost, peerPort)
 		peers = append(peers, peer)
 	}
 	return strings.Join(peers, ",")
@@ -38,6 +38,9 @@ import (
 
 const (
 	CODE_CANCEL_OPERATION = 900000
+
+	EXIT_CODE_FAILED    = 1
+	EXIT_CODE_UNHEALTHY = 2
 )
 
 type ErrorCode struct {
@@ -120,6 +123,18 @@ func (e *ErrorCode) FD(format string, s ...interface{}) *ErrorCode {
 	return newEC
 }
 
+// ExitCode returns the exit code of process for the error, unhealthy services
+// or clients exit with a distinct code to tell it from the command failure
+func ExitCode(err error) int {
+	e, ok := err.(*ErrorCode)
+	if ok && (e.code == ERR_UNHEALTHY_SERVICES_FOUND.code ||
+		e.code == ERR_UNHEALTHY_CLIENTS_FOUND.code ||
+		e.code == ERR_HEALTH_CHECK_FAILED.code) {
+		return EXIT_CODE_UNHEALTHY
+	}
+	return EXIT_CODE_FAILED
+}
+
 func (e *ErrorCode) Error() string {
 	if e.code == CODE_CANCEL_OPERATION {
 		return ""
@@ -140,11 +155,15 @@ func (e *ErrorCode) Error() string {
  *     * 114: plauground table
  *     * 115: audit table
  *     * 116: any table
+ *     * 117: monitor table
+ *     * 118: images table
  *
  * 2xx: command options
  *   20*: hosts
  *   21*: cluster
  *   22*: client
+ *   23*: playground
+ *   24*: common
  *
  * 3xx: configure (dingoadm.cfg, hosts.yaml, topology.yaml, format.yaml...)
  *   300: common
@@ -219,11 +238,13 @@ var (
 	ERR_UPDATE_CLUSTER_TOPOLOGY_FAILED = EC(111006, "execute SQL failed which update cluster topology")
 	ERR_UPDATE_CLUSTER_POOL_FAILED     = EC(111007, "execute SQL failed which update cluster pool")
 	ERR_RENAME_CLUSTER_FAILED          = EC(111008, "execute SQL failed which rename cluster")
+	ERR_UPDATE_CLUSTER_UUID_FAILED     = EC(111009, "execute SQL failed which update cluster uuid")
 	// 112: database/SQL (execute SQL statement: containers table)
 	ERR_INSERT_SERVICE_CONTAINER_ID_FAILED   = EC(112000, "execute SQL failed which insert service container id")
 	ERR_SET_SERVICE_CONTAINER_ID_FAILED      = EC(112001, "execute SQL failed which set service container id")
 	ERR_GET_SERVICE_CONTAINER_ID_FAILED      = EC(112002, "execute SQL failed which get service container id")
 	ERR_GET_ALL_SERVICES_CONTAINER_ID_FAILED = EC(112003, "execute SQL failed which get all services container id")
+	ERR_DELETE_SERVICE_FAILED                = EC(112004, "execute SQL failed which delete service")
 	// 113: database/SQL (execute SQL statement: clients table)
 	ERR_INSERT_CLIENT_FAILED           = EC(113000, "execute SQL failed which insert client")
 	ERR_GET_CLIENT_CONTAINER_ID_FAILED = EC(113001, "execute SQL failed which get client container id")
@@ -246,6 +267,11 @@ var (
 	ERR_GET_MONITOR_FAILED     = EC(117000, "execute SQL failed while get monitor")
 	ERR_REPLACE_MONITOR_FAILED = EC(117001, "execute SQL failed while replace monitor")
 	ERR_UPDATE_MONITOR_FAILED  = EC(117002, "execute SQL failed while update monitor")
+	// 118: database/SQL (execute SQL statement: images table)
+	ERR_REPLACE_SERVICE_IMAGE_FAILED = EC(118000, "execute SQL failed which replace service image")
+	ERR_GET_SERVICE_IMAGE_FAILED     = EC(118001, "execute SQL failed which get service image")
+
+	ERR_GET_STATUS_HISTORY_FAILED = EC(119000, "execute SQL failed which get status history")
 
 	// 200: command options (hosts)
 
@@ -259,6 +285,22 @@ var (
 	ERR_NO_SERVICES_MATCHED            = EC(210006, "no services matched")
 	ERR_UNSUPPORT_DINGODB_ROLE         = EC(210007, "unsupport dingodb role (coordinator/store/executor/document/index/diskann/proxy/web)")
 	ERR_UNSUPPORT_DINGOSTORE_ROLE      = EC(210008, "unsupport dingo-store role (coordinator/store/document/index/diskann)")
+	ERR_NO_LABELED_CONTAINERS_FOUND    = EC(210009, "no labeled containers found for adopting")
+	ERR_MULTIPLE_CLUSTERS_LABELED      = EC(210010, "labeled containers belong to multiple clusters")
+	ERR_INVALID_CANARY_NUMBER          = EC(210011, "canary number must be greater than 0")
+	ERR_NO_PROMETHEUS_FOR_CANARY_QUERY = EC(210012, "no prometheus found in monitor config for canary query")
+	ERR_INVALID_CONFIG_ITEM            = EC(210013, "invalid config item, it should be like KEY=VALUE")
+	ERR_UNSUPPORT_BALANCE_LEADER_ROLE  = EC(210014, "unsupport role for balance leader (store)")
+	ERR_INVALID_WATCH_INTERVAL         = EC(210015, "watch interval must be greater than 0")
+	ERR_INVALID_LOG_SINCE              = EC(210016, "invalid log since, e.g. 10m or '2025-01-01 12:00:00'")
+	ERR_UNSUPPORT_LOG_SOURCE           = EC(210017, "unsupport log source")
+	ERR_INVALID_LOG_GREP_PATTERN       = EC(210018, "invalid log grep pattern")
+	ERR_CORE_SAVE_DIR_NOT_EXIST        = EC(210019, "directory to save core not exist")
+	ERR_UNSUPPORT_PROFILE_TYPE         = EC(210020, "unsupport profile type, it must be one of cpu, heap and contention")
+	ERR_INVALID_PROFILE_DURATION       = EC(210021, "profile duration must be greater than or equal to 1s")
+	ERR_PROFILE_SAVE_DIR_NOT_EXIST     = EC(210022, "directory to save profile not exist")
+	ERR_INVALID_FLAP_THRESHOLD         = EC(210023, "flap threshold must be greater than 0")
+	ERR_UNSUPPORT_STATUS_HISTORY       = EC(210024, "--history can't be used with --watch, --with-cluster or --record")
 	// TODO: please check pool set disk type
 	ERR_INVALID_DISK_TYPE = EC(210007, "poolset disk type must be lowercase and can only be one of ssd, hdd and nvme")
 
@@ -286,6 +328,9 @@ var (
 	ERR_PLAYGROUND_MOUNTPOINT_REQUIRE_ABSOLUTE_PATH    = EC(230002, "mount point must be an absolute path")
 	ERR_PLAYGROUND_MOUNTPOINT_NOT_EXIST                = EC(230003, "mount point not exist")
 
+	// 240: command options (common)
+	ERR_UNSUPPORT_OUTPUT_FORMAT = EC(240000, "unsupport output format (table/json/yaml)")
+
 	// 301: configure (common: invalid configure value)
 	ERR_UNSUPPORT_CONFIGURE_VALUE_TYPE = EC(301000, "unsupport configure value type")
 	// lose 301001
@@ -344,6 +389,11 @@ var (
 	ERR_INSTANCES_REQUIRES_POSITIVE_INTEGER = EC(331002, "instances requires a positive integer")
 	ERR_INVALID_VARIABLE_SECTION            = EC(331003, "invalid variable section")
 	ERR_DUPLICATE_SERVICE_ID                = EC(331004, "service id is duplicate")
+	ERR_DEPLOY_HOST_AND_LABELS_CONFLICT     = EC(331005, "deploy host and labels can't be specified at the same time")
+	ERR_DEPLOY_COUNT_REQUIRES_POSITIVE      = EC(331006, "deploy count requires a positive integer")
+	ERR_NO_ENOUGH_HOSTS_MATCH_LABELS        = EC(331007, "no enough hosts match deploy labels")
+	ERR_NO_AVAILABLE_PORT_IN_RANGE          = EC(331008, "no available port in range")
+	ERR_LABEL_HOST_SEQUENCE_CONFLICT        = EC(331009, "hosts picked by labels have the same host sequence")
 	// 332: configure (topology.yaml: update topology)
 	ERR_DELETE_SERVICE_WHILE_COMMIT_TOPOLOGY_IS_DENIED   = EC(332000, "delete service while commit topology is denied")
 	ERR_ADD_SERVICE_WHILE_COMMIT_TOPOLOGY_IS_DENIED      = EC(332001, "add service while commit topology is denied")
@@ -357,6 +407,19 @@ var (
 	ERR_NO_SERVICES_FOR_MIGRATING                        = EC(332009, "no service for migrating")
 	ERR_REQUIRE_SAME_ROLE_SERVICES_FOR_MIGRATING         = EC(332010, "require same role services for migrating")
 	ERR_REQUIRE_WHOLE_HOST_SERVICES_FOR_MIGRATING        = EC(332011, "require whole host services for migrating")
+	ERR_INSTANCE_ID_CONFLICT_WHILE_SCALE_OUT             = EC(332012, "instance id of new service conflicts with existing service")
+	ERR_UNSUPPORT_SCALE_OUT_ROLE                         = EC(332013, "unsupport role for scale out cluster")
+	ERR_ADD_SERVICE_WHILE_SCALE_IN_CLUSTER_IS_DENIED     = EC(332014, "add service while scale in cluster is denied")
+	ERR_NO_SERVICES_FOR_SCALE_IN_CLUSTER                 = EC(332015, "no service for scale in cluster")
+	ERR_REQUIRE_SAME_ROLE_SERVICES_FOR_SCALE_IN_CLUSTER  = EC(332016, "require same role services for scale in cluster")
+	ERR_UNSUPPORT_SCALE_IN_ROLE                          = EC(332017, "unsupport role for scale in cluster")
+	ERR_SCALE_IN_ALL_SERVICES_OF_ROLE_IS_DENIED          = EC(332018, "scale in all services of role is denied")
+	ERR_REPLACE_HOST_WITH_SAME_HOST_IS_DENIED            = EC(332019, "replace host with same host is denied")
+	ERR_NO_SERVICES_ON_REPLACED_HOST                     = EC(332020, "no service on replaced host")
+	ERR_REPLACE_HOST_REQUIRES_EXPLICIT_DEPLOY_HOST       = EC(332021, "services placed by variable or labels can't be replaced, please update topology manually")
+	ERR_NO_ROLE_SERVICES_IN_TOPOLOGY                     = EC(332022, "no role services found in topology")
+	ERR_REPLACE_HOST_WITH_MULTIPLE_INSTANCES_IS_DENIED   = EC(332023, "replace service which has multiple instances is denied, please update topology manually")
+	ERR_NO_DEPLOY_ITEM_IN_TOPOLOGY                       = EC(332024, "no deploy item found in topology")
 
 	// 340: configure (format.yaml: parse failed)
 	ERR_FORMAT_CONFIGURE_FILE_NOT_EXIST = EC(340000, "format configure file not exits")
@@ -410,6 +473,17 @@ var (
 	ERR_ENCRYPT_FILE_FAILED                  = EC(410021, "encrypt file failed")
 	ERR_CLIENT_ID_NOT_FOUND                  = EC(410022, "client id not found")
 	ERR_ENABLE_ETCD_AUTH_FAILED              = EC(410023, "enable etcd auth failed")
+	ERR_NO_PREVIOUS_IMAGE_FOR_ROLLBACK       = EC(410024, "no previous image recorded for rollback")
+	ERR_ENCODE_OUTPUT_FAILED                 = EC(410025, "encode output failed")
+	ERR_UNHEALTHY_SERVICES_FOUND             = EC(410026, "some services are not running")
+	ERR_UNHEALTHY_CLIENTS_FOUND              = EC(410027, "some clients are not running")
+	ERR_HEALTH_CHECK_FAILED                  = EC(410028, "some health checks failed")
+	ERR_CORE_NOT_FOUND                       = EC(410029, "core not found")
+	ERR_UNKNOWN_CORE_BINARY                  = EC(410030, "unknown binary of core, file(1) maybe not installed on host")
+	ERR_UNSUPPORT_PROFILE_ROLE               = EC(410031, "service does not expose brpc builtin services for profiling")
+	ERR_PROFILE_REQUIRES_ONE_SERVICE         = EC(410032, "profile requires exactly one service, please specify --id")
+	ERR_SERVICES_DRIFT_FOUND                 = EC(410033, "some services drift from topology")
+	ERR_CLUSTER_HAS_OTHER_SERVICES           = EC(410034, "current cluster already has services which belong to another cluster uuid")
 
 	// 420: common (curvebs client)
 	ERR_VOLUME_ALREADY_MAPPED             = EC(420000, "volume already mapped")
@@ -446,6 +520,7 @@ var (
 	ERR_DATA_DIRECTORY_ALREADY_IN_USE   = EC(501001, "data directory already in use")
 	// 502: checker (topology/address)
 	ERR_DUPLICATE_LISTEN_ADDRESS = EC(502000, "listen address is duplicate")
+	ERR_DUPLICATE_HOST_PORT      = EC(502001, "port is already used by another service on the same host")
 	// 503: checker (topology/service)
 	ERR_ETCD_REQUIRES_3_SERVICES          = EC(503000, "etcd requires at least 3 services")
 	ERR_MDS_REQUIRES_3_SERVICES           = EC(503001, "mds requires at least 3 services")
@@ -459,6 +534,8 @@ var (
 	ERR_METASERVER_REQUIRES_3_HOSTS       = EC(503009, "metaserver requires at least 3 hosts to distrubute zones")
 	ERR_COORDINATOR_REQUIRES_3_SERVICES   = EC(503010, "coordinator requires at least 3 services")
 	ERR_STORE_REQUIRES_3_SERVICES         = EC(503011, "store requires at least 3 services")
+	ERR_STORE_ZONE_NOT_SPECIFIED          = EC(503012, "zone or rack of store is not specified")
+	ERR_REPLICA_NUM_EXCEEDS_ZONES         = EC(503013, "replica num exceeds the number of distinct zones")
 
 	// 510: checker (ssh)
 	ERR_SSH_CONNECT_FAILED = EC(510000, "SSH connect failed")
@@ -568,8 +645,21 @@ var (
 	// 650: mdsv2
 	ERR_CREATE_META_TABLE_FAILED = EC(650000, "create meta table failed")
 
+	// 660: dingo-store
+	ERR_NO_EXISTING_COORDINATOR_FOR_SCALE_OUT = EC(660000, "no existing coordinator found for scale out")
+	ERR_NO_MDSV2_CLIENT_FOR_SCALE_IN          = EC(660001, "no mds client container found for scale in")
+	ERR_NO_MIGRATE_TARGET_FOR_SERVICE         = EC(660002, "no migrate target found for service")
+	ERR_NO_ALIVE_MEMBER_FOR_REPLACE_HOST      = EC(660003, "no alive member found for replace host")
+	ERR_NO_MDSV2_CLIENT_FOR_TRANSFER_LEADER   = EC(660004, "no mds client container found for transfer leader")
+	ERR_NO_SERVICE_FOR_DINGODB_CLI            = EC(660005, "no coordinator or store found to run dingodb_cli")
+	ERR_NO_RUNNING_SERVICE_FOR_DINGODB_CLI    = EC(660006, "no running coordinator or store found to run dingodb_cli")
+
 	// 690: execuetr task (others)
 	ERR_START_CRONTAB_IN_CONTAINER_FAILED = EC(690000, "start crontab in container failed")
+	ERR_CANARY_SERVICE_RESTARTED          = EC(690001, "canary service restarted during observation")
+	ERR_CANARY_SERVICE_UNHEALTHY          = EC(690002, "canary service health check failed during observation")
+	ERR_CANARY_PROMETHEUS_QUERY_FIRED     = EC(690003, "prometheus query returned result during observation")
+	ERR_WAIT_SERVICE_HEALTHY_TIMEOUT      = EC(690004, "wait service healthy timeout")
 
 	// 900: others
 	ERR_CANCEL_OPERATION = EC(CODE_CANCEL_OPERATION, "cancel operation")
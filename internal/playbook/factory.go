@@ -162,6 +162,58 @@ const (
 	// dingo executor
 	SYNC_JAVA_OPTS
 
+	// adopt
+	SCAN_CONTAINERS
+
+	// dingo-store scale-out
+	WAIT_STORE_REGISTERED
+	ADD_COORDINATOR_PEER
+
+	// scale-in
+	DRAIN_SERVICE
+
+	// migrate
+	REMOVE_COORDINATOR_PEER
+
+	// replace host
+	REMOVE_DEAD_MEMBER
+
+	// upgrade
+	RECORD_IMAGE
+	OBSERVE_SERVICE
+
+	// config set
+	SET_RUNTIME_FLAG
+
+	// leader
+	TRANSFER_LEADER
+	WAIT_SERVICE_HEALTHY
+
+	// check health
+	CHECK_SERVICE_HEALTH
+	CHECK_MDSV2_HEALTH
+
+	// dingo-store inspection
+	RUN_DINGODB_CLI
+	GET_RAFT_STAT
+
+	// logs
+	STREAM_LOGS
+
+	// cores
+	LIST_CORES
+	FETCH_CORE
+	BACKTRACE_CORE
+
+	// profile
+	PROFILE_SERVICE
+
+	// flags
+	GET_FLAGS
+
+	// drift
+	CHECK_DRIFT
+
 	// unknown
 	UNKNOWN
 )
@@ -187,7 +239,8 @@ func (p *Playbook) createTasks(step *PlaybookStep) (*tasks.Tasks, error) {
 		// only need to execute task once per host
 		switch step.Type {
 		case CHECK_SSH_CONNECT,
-			GET_HOST_DATE:
+			GET_HOST_DATE,
+			SCAN_CONTAINERS:
 			host := config.GetDC(i).GetHost()
 			if once[host] {
 				continue
@@ -258,6 +311,48 @@ func (p *Playbook) createTasks(step *PlaybookStep) (*tasks.Tasks, error) {
 			t, err = checker.NewCheckMdsAddressTask(dingoadm, config.GetCC(i))
 		case CHECK_STORE_HEALTH:
 			t, err = comm.NewCheckStoreHealthTask(dingoadm, config.GetDC(i))
+		case WAIT_STORE_REGISTERED:
+			t, err = comm.NewWaitStoreRegisteredTask(dingoadm, config.GetDC(i))
+		case ADD_COORDINATOR_PEER:
+			t, err = comm.NewAddCoordinatorPeerTask(dingoadm, config.GetDC(i))
+		case DRAIN_SERVICE:
+			t, err = comm.NewDrainServiceTask(dingoadm, config.GetDC(i))
+		case REMOVE_COORDINATOR_PEER:
+			t, err = comm.NewRemoveCoordinatorPeerTask(dingoadm, config.GetDC(i))
+		case REMOVE_DEAD_MEMBER:
+			t, err = comm.NewRemoveDeadMemberTask(dingoadm, config.GetDC(i))
+		case RECORD_IMAGE:
+			t, err = comm.NewRecordImageTask(dingoadm, config.GetDC(i))
+		case OBSERVE_SERVICE:
+			t, err = comm.NewObserveServiceTask(dingoadm, config.GetDC(i))
+		case SET_RUNTIME_FLAG:
+			t, err = comm.NewSetRuntimeFlagTask(dingoadm, config.GetDC(i))
+		case TRANSFER_LEADER:
+			t, err = comm.NewTransferLeaderTask(dingoadm, config.GetDC(i))
+		case WAIT_SERVICE_HEALTHY:
+			t, err = comm.NewWaitServiceHealthyTask(dingoadm, config.GetDC(i))
+		case CHECK_SERVICE_HEALTH:
+			t, err = comm.NewCheckServiceHealthTask(dingoadm, config.GetDC(i))
+		case CHECK_MDSV2_HEALTH:
+			t, err = comm.NewCheckMdsv2HealthTask(dingoadm, config.GetDC(i))
+		case RUN_DINGODB_CLI:
+			t, err = comm.NewRunDingodbCliTask(dingoadm, config.GetDC(i))
+		case GET_RAFT_STAT:
+			t, err = comm.NewGetRaftStatTask(dingoadm, config.GetDC(i))
+		case STREAM_LOGS:
+			t, err = comm.NewStreamLogsTask(dingoadm, config.GetDC(i))
+		case LIST_CORES:
+			t, err = comm.NewListCoresTask(dingoadm, config.GetDC(i))
+		case FETCH_CORE:
+			t, err = comm.NewFetchCoreTask(dingoadm, config.GetDC(i))
+		case BACKTRACE_CORE:
+			t, err = comm.NewBacktraceCoreTask(dingoadm, config.GetDC(i))
+		case PROFILE_SERVICE:
+			t, err = comm.NewProfileServiceTask(dingoadm, config.GetDC(i))
+		case GET_FLAGS:
+			t, err = comm.NewGetFlagsTask(dingoadm, config.GetDC(i))
+		case CHECK_DRIFT:
+			t, err = comm.NewCheckDriftTask(dingoadm, config.GetDC(i))
 		case CLEAN_PRECHECK_ENVIRONMENT:
 			if config.GetDC(i).GetRole() == topology.ROLE_FS_MDS_CLI {
 				continue
@@ -411,6 +506,8 @@ func (p *Playbook) createTasks(step *PlaybookStep) (*tasks.Tasks, error) {
 		// dingo executor
 		case SYNC_JAVA_OPTS:
 			t, err = comm.NewSyncJavaOptsTask(dingoadm, config.GetDC(i))
+		case SCAN_CONTAINERS:
+			t, err = comm.NewScanContainersTask(dingoadm, config.GetDC(i))
 		case SYNC_GRAFANA_DASHBOARD:
 			t, err = monitor.NewSyncGrafanaDashboardTask(dingoadm, config.GetMC(i))
 
@@ -429,6 +526,7 @@ func (p *Playbook) createTasks(step *PlaybookStep) (*tasks.Tasks, error) {
 			t.SetTid(config.GetDC(i).GetId())
 			t.SetPtid(config.GetDC(i).GetParentId())
 		}
+		t.SetSSHClientPool(dingoadm.SSHClientPool())
 		ts.AddTask(t)
 	}
 
@@ -83,6 +83,10 @@ func (p *Playbook) AddPostStep(s *PlaybookStep) {
 	p.postSteps = append(p.postSteps, s)
 }
 
+func (p *Playbook) Steps() []*PlaybookStep {
+	return p.steps
+}
+
 func (p *Playbook) run(steps []*PlaybookStep) error {
 	for i, step := range steps {
 		tasks, err := p.createTasks(step)
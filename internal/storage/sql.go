@@ -99,14 +99,14 @@ var (
 
 // cluster
 type Cluster struct {
-	Id          int
-	UUId        string
-	Name        string
-	Description string
-	CreateTime  time.Time
-	Topology    string
-	Pool        string
-	Current     bool
+	Id          int       `json:"id" yaml:"id"`
+	UUId        string    `json:"uuid" yaml:"uuid"`
+	Name        string    `json:"name" yaml:"name"`
+	Description string    `json:"description" yaml:"description"`
+	CreateTime  time.Time `json:"create_time" yaml:"create_time"`
+	Topology    string    `json:"topology,omitempty" yaml:"topology,omitempty"`
+	Pool        string    `json:"pool,omitempty" yaml:"pool,omitempty"`
+	Current     bool      `json:"current" yaml:"current"`
 }
 
 var (
@@ -154,6 +154,9 @@ var (
 	// set cluster topology
 	SetClusterTopology = `UPDATE clusters SET topology = ? WHERE id = ?`
 
+	// set cluster uuid
+	SetClusterUUId = `UPDATE clusters SET uuid = ? WHERE id = ?`
+
 	// set cluster pool
 	SetClusterPool = `UPDATE clusters SET topology = ?, pool = ? WHERE id = ?`
 
@@ -190,6 +193,9 @@ var (
 
 	// set service container id
 	SetContainerId = `UPDATE containers SET container_id = ? WHERE id = ?`
+
+	// delete service
+	DeleteService = `DELETE FROM containers WHERE id = ?`
 )
 
 // client
@@ -376,3 +382,78 @@ var (
 
 	ReplaceMonitor = `REPLACE INTO monitors (cluster_id, monitor) VALUES(?, ?)`
 )
+
+// image: the image which service running before upgrade
+type ServiceImage struct {
+	Id         string
+	Image      string
+	Digest     string
+	RecordTime time.Time
+}
+
+var (
+	// table: images
+	// id: service id
+	CreateImagesTable = `
+		CREATE TABLE IF NOT EXISTS images (
+			id TEXT PRIMARY KEY,
+			image TEXT NOT NULL,
+			digest TEXT NOT NULL,
+			record_time DATE NOT NULL
+		)
+	`
+
+	// replace service image
+	ReplaceServiceImage = `
+		REPLACE INTO images(id, image, digest, record_time)
+		            VALUES(?, ?, ?, datetime('now','localtime'))
+	`
+
+	// select service image
+	SelectServiceImage = `SELECT * FROM images WHERE id = ?`
+)
+
+// status history: the state of service which recorded when it changed
+type StatusHistory struct {
+	Id           int
+	ClusterId    int
+	ServiceId    string
+	Role         string
+	Host         string
+	State        string
+	RestartCount int
+	Leader       string
+	RecordTime   time.Time
+}
+
+var (
+	// table: status_history
+	CreateStatusHistoryTable = `
+		CREATE TABLE IF NOT EXISTS status_history (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			cluster_id INTEGER NOT NULL,
+			service_id TEXT NOT NULL,
+			role TEXT NOT NULL,
+			host TEXT NOT NULL,
+			state TEXT NOT NULL,
+			restart_count INTEGER DEFAULT 0,
+			leader TEXT NOT NULL,
+			record_time DATE NOT NULL
+		)
+	`
+
+	// insert status history
+	InsertStatusHistory = `
+		INSERT INTO status_history(cluster_id, service_id, role, host, state, restart_count, leader, record_time)
+		                   VALUES(?, ?, ?, ?, ?, ?, ?, datetime('now','localtime'))
+	`
+
+	// select the last status history of service
+	SelectLastStatusHistory = `SELECT * FROM status_history WHERE service_id = ? ORDER BY id DESC LIMIT 1`
+
+	// select status history of cluster since specified time
+	SelectStatusHistory = `SELECT * FROM status_history WHERE cluster_id = ? AND record_time >= ? ORDER BY id`
+
+	// delete expired status history
+	DeleteStatusHistory = `DELETE FROM status_history WHERE record_time < datetime('now','localtime',?)`
+)
@@ -86,6 +86,8 @@ func (s *Storage) init() error {
 		CreateAuditTable,
 		CreateMonitorTable,
 		CreateAnyTable,
+		CreateImagesTable,
+		CreateStatusHistoryTable,
 	}
 
 	for _, sql := range sqls {
@@ -247,6 +249,10 @@ func (s *Storage) SetClusterTopology(id int, topology string) error {
 	return s.write(SetClusterTopology, topology, id)
 }
 
+func (s *Storage) SetClusterUUId(id int, uuid string) error {
+	return s.write(SetClusterUUId, uuid, id)
+}
+
 func (s *Storage) SetClusterPool(id int, topology, pool string) error {
 	return s.write(SetClusterPool, topology, pool, id)
 }
@@ -293,6 +299,10 @@ func (s *Storage) SetContainId(serviceId, containerId string) error {
 	return s.write(SetContainerId, containerId, serviceId)
 }
 
+func (s *Storage) DeleteService(serviceId string) error {
+	return s.write(DeleteService, serviceId)
+}
+
 // client
 func (s *Storage) InsertClient(id, kind, host, containerId, auxInfo string) error {
 	return s.write(InsertClient, id, kind, host, containerId, auxInfo)
@@ -511,3 +521,66 @@ func (s *Storage) DeleteMonitor(clusterId int) error {
 func (s *Storage) ReplaceMonitor(m Monitor) error {
 	return s.write(ReplaceMonitor, m.ClusterId, m.Monitor)
 }
+
+// image
+func (s *Storage) ReplaceServiceImage(serviceId, image, digest string) error {
+	return s.write(ReplaceServiceImage, serviceId, image, digest)
+}
+
+func (s *Storage) GetServiceImage(serviceId string) ([]ServiceImage, error) {
+	result, err := s.db.Query(SelectServiceImage, serviceId)
+	if err != nil {
+		return nil, err
+	}
+	defer result.Close()
+
+	images := []ServiceImage{}
+	var image ServiceImage
+	for result.Next() {
+		err = result.Scan(&image.Id, &image.Image, &image.Digest, &image.RecordTime)
+		if err != nil {
+			return nil, err
+		}
+		images = append(images, image)
+	}
+	return images, nil
+}
+
+// status history
+func (s *Storage) getStatusHistory(query string, args ...any) ([]StatusHistory, error) {
+	result, err := s.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer result.Close()
+
+	histories := []StatusHistory{}
+	var h StatusHistory
+	for result.Next() {
+		err = result.Scan(&h.Id, &h.ClusterId, &h.ServiceId, &h.Role, &h.Host,
+			&h.State, &h.RestartCount, &h.Leader, &h.RecordTime)
+		if err != nil {
+			return nil, err
+		}
+		histories = append(histories, h)
+	}
+	return histories, nil
+}
+
+func (s *Storage) InsertStatusHistory(h StatusHistory) error {
+	return s.write(InsertStatusHistory, h.ClusterId, h.ServiceId, h.Role, h.Host,
+		h.State, h.RestartCount, h.Leader)
+}
+
+func (s *Storage) GetLastStatusHistory(serviceId string) ([]StatusHistory, error) {
+	return s.getStatusHistory(SelectLastStatusHistory, serviceId)
+}
+
+func (s *Storage) GetStatusHistory(clusterId int, since time.Time) ([]StatusHistory, error) {
+	return s.getStatusHistory(SelectStatusHistory, clusterId, since.Format("2006-01-02 15:04:05"))
+}
+
+// delete status history which older than retention, e.g. "-30 days"
+func (s *Storage) DeleteStatusHistory(retention string) error {
+	return s.write(DeleteStatusHistory, retention)
+}
@@ -69,14 +69,38 @@ var (
 	//go:embed shell/start_gateway.sh
 	START_GATEWAY string
 
+	//go:embed shell/drain_metaserver.sh
+	DRAIN_METASERVER string
+
+	//go:embed shell/replace_etcd_member.sh
+	REPLACE_ETCD_MEMBER string
+
 	// DingoFS MdsV2
 	//go:embed shell/create_mdsv2_tables.sh
 	CREATE_MDSV2_TABLES string
 
+	//go:embed shell/drain_mdsv2.sh
+	DRAIN_MDSV2 string
+
 	// DingoStore
 	//go:embed shell/check_store_health.sh
 	CHECK_STORE_HEALTH string
 
+	//go:embed shell/wait_store_registered.sh
+	WAIT_STORE_REGISTERED string
+
+	//go:embed shell/add_coordinator_peer.sh
+	ADD_COORDINATOR_PEER string
+
+	//go:embed shell/remove_coordinator_peer.sh
+	REMOVE_COORDINATOR_PEER string
+
+	//go:embed shell/drain_store.sh
+	DRAIN_STORE string
+
+	//go:embed shell/transfer_leader.sh
+	TRANSFER_LEADER string
+
 	// DingoFS Executor
 	//go:embed shell/sync_java_opts.sh
 	SYNC_JAVA_OPTS string
@@ -0,0 +1,32 @@
+#!/usr/bin/env bash
+# Usage: add_coordinator_peer --peer=10.0.0.4:7500
+
+mydir="${BASH_SOURCE%/*}"
+if [[ ! -d "$mydir" ]]; then mydir="$PWD"; fi
+. $mydir/shflags
+
+DEFINE_string peer '' 'raft address of new coordinator'
+DEFINE_integer retry_times 30 'retry times'
+
+FLAGS "$@" || exit 1
+
+BASE_DIR=$(dirname $(cd $(dirname $0); pwd))
+DINGODB_BIN=$BASE_DIR/build/bin/
+
+cd ${DINGODB_BIN}
+
+times=0
+while [ ${times} -lt ${FLAGS_retry_times} ]; do
+    # add peer into coordinator raft group (index 0: coordinator region)
+    if ./dingodb_cli RaftAddPeer --peer=${FLAGS_peer} --index=0; then
+        echo "add coordinator peer ${FLAGS_peer} success"
+        exit 0
+    fi
+    times=`expr $times + 1`
+
+    echo "add coordinator peer ${FLAGS_peer} failed, times = ${times}, wait 2 second"
+    sleep 2
+done
+
+echo "add coordinator peer ${FLAGS_peer} timeout"
+exit 1
@@ -0,0 +1,23 @@
+#!/usr/bin/env bash
+
+# Usage: drain_mdsv2 MdsV2ClientPath MdsId
+
+g_mdsv2_client=$1
+g_mds_id=$2
+g_retry_times=30
+
+times=0
+while [ ${times} -lt ${g_retry_times} ]; do
+    # remove mds from mds group, its filesystems will be taken over by others
+    if $g_mdsv2_client --cmd=LeaveMds --mds_id=$g_mds_id --coor_addr=list://$COORDINATOR_ADDR; then
+        echo "mds $g_mds_id left mds group"
+        exit 0
+    fi
+    times=`expr $times + 1`
+
+    echo "remove mds $g_mds_id failed, times = ${times}, wait 2 second"
+    sleep 2
+done
+
+echo "remove mds $g_mds_id timeout"
+exit 1
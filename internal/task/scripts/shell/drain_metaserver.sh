@@ -0,0 +1,45 @@
+#!/usr/bin/env bash
+
+# Usage: drain_metaserver ToolsBinaryPath MetaserverAddr
+
+g_tools=$1
+g_addr=$2
+g_retry_times=1800
+
+# print copyset count of the metaserver, fail if copysets can't be listed
+function copyset_count() {
+    local out
+    out=$($g_tools list copyset 2>&1)
+    if [ $? -ne 0 ] || [ -z "${out}" ]; then
+        echo "${out}"
+        return 1
+    fi
+    echo "${out}" | grep -c -w "$g_addr"
+    return 0
+}
+
+# mark metaserver offline, mds will migrate its copysets to other metaservers
+if ! $g_tools update metaserver --metaserveraddr="$g_addr" --state=offline; then
+    echo "mark metaserver $g_addr offline failed"
+    exit 1
+fi
+
+times=0
+while [ ${times} -lt ${g_retry_times} ]; do
+    # wait all copysets migrated to other metaservers
+    if ! count=$(copyset_count); then
+        echo "list copyset failed: ${count}"
+        exit 1
+    fi
+    if [ "${count}" -eq 0 ]; then
+        echo "metaserver $g_addr is drained"
+        exit 0
+    fi
+    times=`expr $times + 1`
+
+    echo "metaserver $g_addr copyset count = ${count}, times = ${times}, wait 2 second"
+    sleep 2
+done
+
+echo "drain metaserver $g_addr timeout"
+exit 1
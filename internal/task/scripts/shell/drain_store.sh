@@ -0,0 +1,53 @@
+#!/usr/bin/env bash
+# Usage: drain_store --instance_id=1004
+
+mydir="${BASH_SOURCE%/*}"
+if [[ ! -d "$mydir" ]]; then mydir="$PWD"; fi
+. $mydir/shflags
+
+DEFINE_integer instance_id 0 'store instance id'
+DEFINE_integer retry_times 1800 'retry times'
+
+FLAGS "$@" || exit 1
+
+BASE_DIR=$(dirname $(cd $(dirname $0); pwd))
+DINGODB_BIN=$BASE_DIR/build/bin/
+
+cd ${DINGODB_BIN}
+
+# print region count of the store, fail if region map can't be fetched
+function region_count() {
+    local out
+    out=$(./dingodb_cli GetRegionMap 2>&1)
+    if [ $? -ne 0 ] || [ -z "${out}" ]; then
+        echo "${out}"
+        return 1
+    fi
+    echo "${out}" | grep -c -E "^\s*store_id: ${FLAGS_instance_id}\s*$"
+    return 0
+}
+
+# mark store out, coordinator will migrate its regions to other stores
+if ! ./dingodb_cli UpdateStoreInState --store_id=${FLAGS_instance_id} --store_in_state=STORE_OUT; then
+    echo "mark store ${FLAGS_instance_id} out failed"
+    exit 1
+fi
+
+times=0
+while [ ${times} -lt ${FLAGS_retry_times} ]; do
+    if ! count=$(region_count); then
+        echo "get region map failed: ${count}"
+        exit 1
+    fi
+    if [ "${count}" -eq 0 ]; then
+        echo "store ${FLAGS_instance_id} is drained"
+        exit 0
+    fi
+    times=`expr $times + 1`
+
+    echo "store ${FLAGS_instance_id} region count = ${count}, times = ${times}, wait 2 second"
+    sleep 2
+done
+
+echo "drain store ${FLAGS_instance_id} timeout"
+exit 1
@@ -0,0 +1,91 @@
+#!/usr/bin/env bash
+# Usage: remove_coordinator_peer --peer=10.0.0.1:7500 --new_peer=10.0.0.4:7500
+#        remove_coordinator_peer --peer=10.0.0.1:7500  (remove dead peer directly)
+
+mydir="${BASH_SOURCE%/*}"
+if [[ ! -d "$mydir" ]]; then mydir="$PWD"; fi
+. $mydir/shflags
+
+DEFINE_string peer '' 'raft address of coordinator to remove'
+DEFINE_string new_peer '' 'raft address of coordinator which replaces it'
+DEFINE_integer retry_times 64 'retry times'
+
+FLAGS "$@" || exit 1
+
+BASE_DIR=$(dirname $(cd $(dirname $0); pwd))
+DINGODB_BIN=$BASE_DIR/build/bin/
+
+cd ${DINGODB_BIN}
+
+# 1) wait the new coordinator catch up with the raft group:
+#    every raft node on it joined as leader/follower and applied all committed logs
+function is_caught_up() {
+    curl -s --connect-timeout 1 --max-time 3 http://${FLAGS_new_peer}/raft_stat | awk '
+        function check() {
+            if (group == "") return
+            nodes++
+            if ((state != "LEADER" && state != "FOLLOWER") || applied <= 0 || applied < committed) lag++
+        }
+        /^\[.*\]$/ { check(); group = $0; state = ""; applied = 0; committed = 0; next }
+        $1 == "state:" { state = $2 }
+        $1 == "known_applied_index:" { applied = $2 + 0 }
+        $1 == "last_committed_index:" { committed = $2 + 0 }
+        END { check(); exit !(nodes > 0 && lag == 0) }'
+}
+
+function wait_catch_up() {
+    local times=0
+    while [ ${times} -lt ${FLAGS_retry_times} ]; do
+        if is_caught_up; then
+            echo "coordinator ${FLAGS_new_peer} caught up"
+            return 0
+        fi
+        times=`expr $times + 1`
+
+        echo "coordinator ${FLAGS_new_peer} is catching up, times = ${times}, wait 2 second"
+        sleep 2
+    done
+
+    echo "wait coordinator ${FLAGS_new_peer} catch up timeout"
+    return 1
+}
+
+if [ -n "${FLAGS_new_peer}" ]; then
+    wait_catch_up || exit 1
+fi
+
+# 2) transfer leadership if the removed one is leader,
+#    ask the peer itself (ip:raft_port) because several coordinators may share one ip
+function is_leader() {
+    local out
+    out=$(curl -s --connect-timeout 1 --max-time 3 http://${FLAGS_peer}/raft_stat)
+    if [ $? -ne 0 ] || [ -z "${out}" ]; then
+        echo "get raft stat of coordinator ${FLAGS_peer} failed"
+        exit 1
+    fi
+    echo "${out}" | grep -q -E "^\s*state:\s*LEADER\s*$"
+}
+
+if [ -n "${FLAGS_new_peer}" ] && is_leader; then
+    if ! ./dingodb_cli RaftTransferLeader --peer=${FLAGS_new_peer} --index=0; then
+        echo "transfer coordinator leader to ${FLAGS_new_peer} failed"
+        exit 1
+    fi
+    sleep 2
+fi
+
+# 3) remove peer from coordinator raft group
+times=0
+while [ ${times} -lt ${FLAGS_retry_times} ]; do
+    if ./dingodb_cli RaftRemovePeer --peer=${FLAGS_peer} --index=0; then
+        echo "remove coordinator peer ${FLAGS_peer} success"
+        exit 0
+    fi
+    times=`expr $times + 1`
+
+    echo "remove coordinator peer ${FLAGS_peer} failed, times = ${times}, wait 2 second"
+    sleep 2
+done
+
+echo "remove coordinator peer ${FLAGS_peer} timeout"
+exit 1
@@ -0,0 +1,24 @@
+#!/usr/bin/env bash
+
+# Usage: replace_etcd_member Endpoints DeadPeerUrl NewName NewPeerUrl [User:Password]
+# Example: replace_etcd_member 10.0.0.2:2379 http://10.0.0.1:2380 etcd10 http://10.0.0.4:2380
+
+endpoints=$1
+dead_peer_url=$2
+new_name=$3
+new_peer_url=$4
+auth=""
+if [ -n "$5" ]; then
+    auth="--user=$5"
+fi
+
+# remove dead member, its host is unreachable
+member_id=$(etcdctl --endpoints=${endpoints} ${auth} member list | grep -w "${dead_peer_url}" | awk -F', ' '{print $1}')
+if [ -n "${member_id}" ]; then
+    etcdctl --endpoints=${endpoints} ${auth} member remove ${member_id} || exit 1
+    echo "etcd member ${dead_peer_url} removed"
+fi
+
+# add new member before it started (initial-cluster-state: existing)
+etcdctl --endpoints=${endpoints} ${auth} member add ${new_name} --peer-urls=${new_peer_url} || exit 1
+echo "etcd member ${new_peer_url} added"
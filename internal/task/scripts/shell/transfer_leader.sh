@@ -0,0 +1,122 @@
+#!/usr/bin/env bash
+# Usage: transfer_leader --role=store --instance_id=1001 --exclude=1002,1003
+#        transfer_leader --role=store --balance
+#        transfer_leader --role=coordinator --peer=10.0.0.1:7500 --new_peer=10.0.0.2:7500
+
+mydir="${BASH_SOURCE%/*}"
+if [[ ! -d "$mydir" ]]; then mydir="$PWD"; fi
+. $mydir/shflags
+
+DEFINE_string role 'store' 'service role, coordinator or store'
+DEFINE_integer instance_id 0 'store instance id which leaders transferred from'
+DEFINE_string exclude '' 'store instance ids which never take over leaders, separated by comma'
+DEFINE_boolean balance false 'balance region leaders among stores'
+DEFINE_string peer '' 'raft address of coordinator which leader transferred from'
+DEFINE_string new_peer '' 'raft address of coordinator which leader transferred to'
+DEFINE_integer retry_times 300 'retry times'
+
+FLAGS "$@" || exit 1
+
+BASE_DIR=$(dirname $(cd $(dirname $0); pwd))
+DINGODB_BIN=$BASE_DIR/build/bin/
+
+cd ${DINGODB_BIN}
+
+# print "region_id leader_store_id peer_store_id..." for each region
+function region_leaders() {
+    ./dingodb_cli GetRegionMap | awk '
+        function flush() {
+            if (id != "") { print id, leader, peers }
+            id = ""; leader = ""; peers = ""
+        }
+        /^[ \t]*regions[ \t]*\{/   { flush(); inregion = 1; next }
+        inregion && /^[ \t]*id:/ && id == "" { id = $2; next }
+        /^[ \t]*leader_store_id:/  { leader = $2; next }
+        /^[ \t]*store_id:/         { peers = peers " " $2; next }
+        END { flush() }'
+}
+
+# print "region_id target_store_id" for regions which leader should be transferred
+function transfer_plan() {
+    local balance=0
+    [ ${FLAGS_balance} -eq ${FLAGS_TRUE} ] && balance=1
+    region_leaders | awk -v from=${FLAGS_instance_id} -v balance=${balance} -v exclude=${FLAGS_exclude} '
+        BEGIN {
+            n = split(exclude, es, ",")
+            for (i = 1; i <= n; i++) { excluded[es[i]] = 1 }
+        }
+        {
+            region[NR] = $1; leader[NR] = $2; peers[NR] = ""
+            for (i = 3; i <= NF; i++) { peers[NR] = peers[NR] " " $i; count[$i] += 0 }
+            count[$2]++
+        }
+        END {
+            n = 0; total = 0
+            for (s in count) { n++; total += count[s] }
+            avg = (n > 0) ? int(total / n) : 0
+            for (r = 1; r <= NR; r++) {
+                src = leader[r]
+                if (balance == 0 && src != from) continue
+                if (balance != 0 && count[src] <= avg + 1) continue
+                split(peers[r], ps, " ")
+                target = ""
+                for (i in ps) {
+                    p = ps[i]
+                    if (p == src || p == from || (p in excluded)) continue
+                    if (target == "" || count[p] < count[target]) target = p
+                }
+                if (target == "" || (balance != 0 && count[target] >= avg)) continue
+                print region[r], target
+                count[src]--; count[target]++
+            }
+        }'
+}
+
+function leader_count() {
+    region_leaders | awk -v from=${FLAGS_instance_id} '$2 == from' | wc -l
+}
+
+function transfer_store_leaders() {
+    transfer_plan | while read region target; do
+        ./dingodb_cli TransferLeaderRegion --id=${region} --store_id=${target} > /dev/null 2>&1
+    done
+}
+
+function coordinator_is_leader() {
+    ./dingodb_cli GetCoordinatorMap | grep -i "leader" | grep -q -w "${FLAGS_peer%:*}"
+}
+
+times=0
+if [ "${FLAGS_role}" == "coordinator" ]; then
+    while [ ${times} -lt ${FLAGS_retry_times} ]; do
+        if ! coordinator_is_leader; then
+            echo "coordinator ${FLAGS_peer} holds no leader"
+            exit 0
+        fi
+        ./dingodb_cli RaftTransferLeader --peer=${FLAGS_new_peer} --index=0 > /dev/null 2>&1
+        times=`expr $times + 1`
+
+        echo "transfer coordinator leader to ${FLAGS_new_peer}, times = ${times}, wait 2 second"
+        sleep 2
+    done
+elif [ ${FLAGS_balance} -eq ${FLAGS_TRUE} ]; then
+    transfer_store_leaders
+    echo "store leaders balanced"
+    exit 0
+else
+    while [ ${times} -lt ${FLAGS_retry_times} ]; do
+        count=$(leader_count)
+        if [ "${count}" -eq 0 ]; then
+            echo "store ${FLAGS_instance_id} holds no leader"
+            exit 0
+        fi
+        transfer_store_leaders
+        times=`expr $times + 1`
+
+        echo "store ${FLAGS_instance_id} leader count = ${count}, times = ${times}, wait 2 second"
+        sleep 2
+    done
+fi
+
+echo "transfer leader timeout"
+exit 1
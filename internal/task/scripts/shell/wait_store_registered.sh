@@ -0,0 +1,33 @@
+#!/usr/bin/env bash
+# Usage: wait_store_registered --instance_id=1004
+
+mydir="${BASH_SOURCE%/*}"
+if [[ ! -d "$mydir" ]]; then mydir="$PWD"; fi
+. $mydir/shflags
+
+DEFINE_integer instance_id 0 'store instance id'
+DEFINE_integer retry_times 64 'retry times'
+
+FLAGS "$@" || exit 1
+
+BASE_DIR=$(dirname $(cd $(dirname $0); pwd))
+DINGODB_BIN=$BASE_DIR/build/bin/
+
+cd ${DINGODB_BIN}
+
+times=0
+while [ ${times} -lt ${FLAGS_retry_times} ]; do
+    # store registers itself into coordinator by heartbeat after started
+    if ./dingodb_cli GetStoreMap | grep -w "${FLAGS_instance_id}" | grep -q "NORMAL"; then
+        echo "store ${FLAGS_instance_id} is registered"
+        exit 0
+    fi
+    times=`expr $times + 1`
+
+    echo "store ${FLAGS_instance_id} is not registered, times = ${times}, wait 2 second"
+    sleep 2
+done
+
+./dingodb_cli GetStoreMap
+echo "store ${FLAGS_instance_id} register timeout"
+exit 1
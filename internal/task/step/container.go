@@ -61,6 +61,7 @@ type (
 		Envs              []string
 		Hostname          string
 		Init              bool
+		Labels            []string
 		LinuxCapabilities []string
 		Mount             string
 		Name              string
@@ -194,6 +195,9 @@ func (s *CreateContainer) Execute(ctx *context.Context) error {
 	if s.Init {
 		cli.AddOption("--init")
 	}
+	for _, label := range s.Labels {
+		cli.AddOption("--label %s", label)
+	}
 	for _, capability := range s.LinuxCapabilities {
 		cli.AddOption("--cap-add %s", capability)
 	}
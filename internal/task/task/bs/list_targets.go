@@ -52,11 +52,11 @@ type (
 	}
 
 	Target struct {
-		Host   string
-		Tid    string
-		Name   string
-		Store  string
-		Portal string
+		Host   string `json:"host" yaml:"host"`
+		Tid    string `json:"tid" yaml:"tid"`
+		Name   string `json:"name" yaml:"name"`
+		Store  string `json:"store" yaml:"store"`
+		Portal string `json:"portal" yaml:"portal"`
 	}
 )
 
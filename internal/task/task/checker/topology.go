@@ -74,6 +74,17 @@ type (
 		dcs []*topology.DeployConfig
 	}
 
+	// check whether the ports opened by services on the same host overlap
+	step2CheckHostPortOverlap struct {
+		dcs []*topology.DeployConfig
+	}
+
+	// check whether replicas of store can be placed across distinct zones
+	step2CheckReplicaZones struct {
+		dcs       []*topology.DeployConfig
+		skipRoles []string
+	}
+
 	// check list:
 	//   (1) each role requires at least 3 services
 	//   (2) each requires at least 3 hosts
@@ -211,6 +222,64 @@ func (s *step2CheckServices) skip(role string) bool {
 	return false
 }
 
+func (s *step2CheckHostPortOverlap) Execute(ctx *context.Context) error {
+	m := map[string]string{} // hostname:port => service
+	for _, dc := range s.dcs {
+		for _, port := range topology.GetServicePorts(dc) {
+			key := fmt.Sprintf("%s:%d", dc.GetHostname(), port.Port)
+			service := fmt.Sprintf("%s.host[%s].%s", dc.GetRole(), dc.GetHost(), port.Name)
+			if other, ok := m[key]; ok {
+				return errno.ERR_DUPLICATE_HOST_PORT.
+					F("port %d: %s and %s", port.Port, other, service)
+			}
+			m[key] = service
+		}
+	}
+	return nil
+}
+
+// the failure domain is zone, fallback to rack if no zone specified
+func (s *step2CheckReplicaZones) Execute(ctx *context.Context) error {
+	if utils.Slice2Map(s.skipRoles)[ROLE_STORE] {
+		return nil
+	}
+
+	stores := []*topology.DeployConfig{}
+	useZone, useRack := false, false
+	for _, dc := range s.dcs {
+		if dc.GetRole() != ROLE_STORE {
+			continue
+		}
+		stores = append(stores, dc)
+		useZone = useZone || len(dc.GetZone()) > 0
+		useRack = useRack || len(dc.GetRack()) > 0
+	}
+	if len(stores) == 0 || (!useZone && !useRack) {
+		return nil // no failure domain specified
+	}
+
+	zones := map[string]bool{}
+	replicaNum := 0
+	for _, dc := range stores {
+		zone := utils.Choose(useZone, dc.GetZone(), dc.GetRack())
+		if len(zone) == 0 {
+			return errno.ERR_STORE_ZONE_NOT_SPECIFIED.
+				F("%s.host[%s]", dc.GetRole(), dc.GetHost())
+		}
+		zones[zone] = true
+		if dc.GetDingoStoreReplicaNum() > replicaNum {
+			replicaNum = dc.GetDingoStoreReplicaNum()
+		}
+	}
+
+	if replicaNum > len(zones) {
+		return errno.ERR_REPLICA_NUM_EXCEEDS_ZONES.
+			F("default_replica_num: %d, distinct %s: %d",
+				replicaNum, utils.Choose(useZone, "zones", "racks"), len(zones))
+	}
+	return nil
+}
+
 func (s *step2CheckServices) Execute(ctx *context.Context) error {
 
 	// (1): each role requires at least 3 services
@@ -293,11 +362,16 @@ func NewCheckTopologyTask(dingoadm *cli.DingoAdm, null interface{}) (*task.Task,
 	}
 	t.AddStep(&step2CheckDataDirectoryDuplicate{dcs: dcs})
 	t.AddStep(&step2CheckAddressDuplicate{dcs: dcs})
+	t.AddStep(&step2CheckHostPortOverlap{dcs: dcs})
 	t.AddStep(&step2CheckServices{
 		dcs:       dcs,
 		dingoadm:  dingoadm,
 		skipRoles: dingoadm.MemStorage().Get(comm.KEY_SKIP_CHECKS_ROLES).([]string),
 	})
+	t.AddStep(&step2CheckReplicaZones{
+		dcs:       dcs,
+		skipRoles: dingoadm.MemStorage().Get(comm.KEY_SKIP_CHECKS_ROLES).([]string),
+	})
 	for _, dc := range dcs {
 		t.AddStep(&step2CheckS3Configure{
 			dc:       dc,
@@ -0,0 +1,74 @@
+package checker
+
+import (
+	"testing"
+
+	"github.com/dingodb/dingoadm/internal/configure/topology"
+	"github.com/dingodb/dingoadm/internal/errno"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	ZONE_TOPOLOGY = `
+kind: dingo-store
+coordinator_services:
+  deploy:
+    - host: host1
+store_services:
+  config:
+    default_replica_num: 3
+  deploy:
+    - host: host1
+    - host: host2
+    - host: host3
+      zone: zone-c
+`
+)
+
+func parseZoneTopology(t *testing.T, data string, zones map[string]string) []*topology.DeployConfig {
+	ctx := topology.NewContext()
+	for _, host := range []string{"host1", "host2", "host3"} {
+		ctx.Add(host, host)
+		ctx.AddLocation(host, zones[host], "")
+	}
+	dcs, err := topology.ParseTopology(data, ctx)
+	assert.Nil(t, err)
+	return dcs
+}
+
+func checkReplicaZones(dcs []*topology.DeployConfig, skipRoles []string) error {
+	step := &step2CheckReplicaZones{dcs: dcs, skipRoles: skipRoles}
+	return step.Execute(nil)
+}
+
+func TestCheckReplicaZones(t *testing.T) {
+	assert := assert.New(t)
+
+	// zone of deploy overrides zone of host
+	dcs := parseZoneTopology(t, ZONE_TOPOLOGY, map[string]string{
+		"host1": "zone-a", "host2": "zone-b", "host3": "zone-a",
+	})
+	assert.Nil(checkReplicaZones(dcs, []string{}))
+
+	// no failure domain specified
+	dcs = parseZoneTopology(t, "kind: dingo-store\nstore_services:\n  deploy:\n    - host: host1\n",
+		map[string]string{})
+	assert.Nil(checkReplicaZones(dcs, []string{}))
+}
+
+func TestCheckReplicaZonesFailed(t *testing.T) {
+	assert := assert.New(t)
+
+	// 3 replicas across 2 zones
+	dcs := parseZoneTopology(t, ZONE_TOPOLOGY, map[string]string{
+		"host1": "zone-a", "host2": "zone-a",
+	})
+	err := checkReplicaZones(dcs, []string{})
+	assert.Equal(errno.ERR_REPLICA_NUM_EXCEEDS_ZONES.GetCode(), err.(*errno.ErrorCode).GetCode())
+	assert.Nil(checkReplicaZones(dcs, []string{ROLE_STORE}))
+
+	// zone of host2 missing
+	dcs = parseZoneTopology(t, ZONE_TOPOLOGY, map[string]string{"host1": "zone-a"})
+	err = checkReplicaZones(dcs, []string{})
+	assert.Equal(errno.ERR_STORE_ZONE_NOT_SPECIFIED.GetCode(), err.(*errno.ErrorCode).GetCode())
+}
@@ -0,0 +1,582 @@
+/*
+ *  Copyright (c) 2025 dingodb.com Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: dingoadm
+ * Author: dongwei (jackblack369)
+ */
+
+package common
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/dingodb/dingoadm/cli/cli"
+	comm "github.com/dingodb/dingoadm/internal/common"
+	"github.com/dingodb/dingoadm/internal/configure/topology"
+	"github.com/dingodb/dingoadm/internal/storage"
+	"github.com/dingodb/dingoadm/internal/task/context"
+	"github.com/dingodb/dingoadm/internal/task/step"
+	"github.com/dingodb/dingoadm/internal/task/task"
+	tui "github.com/dingodb/dingoadm/internal/tui/common"
+	"github.com/dingodb/dingoadm/internal/utils"
+	"github.com/dingodb/dingoadm/pkg/module"
+)
+
+const (
+	HEALTH_PASS = "PASS"
+	HEALTH_WARN = "WARN"
+	HEALTH_FAIL = "FAIL"
+
+	HEALTH_ITEM_CONTAINER = "container"
+	HEALTH_ITEM_BRPC      = "brpc"
+	HEALTH_ITEM_RAFT      = "raft"
+	HEALTH_ITEM_LEADER    = "leader"
+	HEALTH_ITEM_STORE     = "store"
+	HEALTH_ITEM_REGIONS   = "regions"
+	HEALTH_ITEM_HEARTBEAT = "heartbeat"
+	HEALTH_ITEM_FS        = "fs"
+	HEALTH_ITEM_MYSQL     = "mysql"
+	HEALTH_ITEM_ENDPOINT  = "endpoint"
+	HEALTH_ITEM_DISK      = "disk"
+
+	HEALTH_DISK_WARN_PERCENT = 80
+	HEALTH_DISK_FAIL_PERCENT = 90
+
+	URL_BRPC_RAFT_STAT       = "--connect-timeout 1 --max-time 3 http://%s:%d/raft_stat"
+	COMMAND_DINGODB_CLI      = "bash -c 'cd %s && ./dingodb_cli %s'"
+	COMMAND_MDSV2_CLI        = "bash -c '%s --cmd=%s --coor_addr=list://$COORDINATOR_ADDR'"
+	COMMAND_ETCD_HEALTH      = "%s/etcdctl --endpoints %s:%d endpoint health"
+	COMMAND_TCP_PORT_CONNECT = "timeout 3 bash -c '</dev/tcp/%s/%d'"
+)
+
+var (
+	REGEX_IPV4 = regexp.MustCompile(`\b(\d{1,3}\.){3}\d{1,3}\b`)
+)
+
+type (
+	step2CheckServiceHealth struct {
+		dc          *topology.DeployConfig
+		serviceId   string
+		containerId string
+		memStorage  *utils.SafeMap
+		storage     *storage.Storage
+		clusterId   int
+		execOptions module.ExecOptions
+		checks      []HealthCheck
+	}
+
+	step2CheckMdsv2Health struct {
+		dc          *topology.DeployConfig
+		serviceId   string
+		containerId string // container id of mds client
+		binaryPath  string // binary path of mds client
+		memStorage  *utils.SafeMap
+		execOptions module.ExecOptions
+	}
+
+	HealthCheck struct {
+		Id      string `json:"id" yaml:"id"`
+		Role    string `json:"role" yaml:"role"`
+		Host    string `json:"host" yaml:"host"`
+		Item    string `json:"item" yaml:"item"`
+		Result  string `json:"result" yaml:"result"`
+		Message string `json:"message" yaml:"message"`
+		Hint    string `json:"hint,omitempty" yaml:"hint,omitempty"`
+	}
+
+	RegionHealth struct {
+		Regions    int // regions which has peer on the store
+		Leaders    int // regions which leader is the store
+		Leaderless int // regions which has peer on the store but no leader
+	}
+)
+
+func addHealthChecks(memStorage *utils.SafeMap, id string, checks []HealthCheck) {
+	memStorage.TX(func(kv *utils.SafeMap) error {
+		m := map[string][]HealthCheck{}
+		v := kv.Get(comm.KEY_ALL_HEALTH_CHECKS)
+		if v != nil {
+			m = v.(map[string][]HealthCheck)
+		}
+		m[id] = append(m[id], checks...)
+		kv.Set(comm.KEY_ALL_HEALTH_CHECKS, m)
+		return nil
+	})
+}
+
+func newHealthCheck(dc *topology.DeployConfig, id, item, result, message, hint string) HealthCheck {
+	return HealthCheck{
+		Id:      id,
+		Role:    dc.GetRole(),
+		Host:    dc.GetHost(),
+		Item:    item,
+		Result:  result,
+		Message: message,
+		Hint:    hint,
+	}
+}
+
+// ParseRaftStates returns the state of each raft node in braft /raft_stat, e.g. "state: LEADER"
+func ParseRaftStates(out string) []string {
+	states := []string{}
+	for _, line := range strings.Split(out, "\n") {
+		line = strings.TrimSpace(line)
+		if strings.HasPrefix(line, "state:") {
+			states = append(states, strings.TrimSpace(strings.TrimPrefix(line, "state:")))
+		}
+	}
+	return states
+}
+
+// RaftStatesResult returns the worst result of raft nodes
+func RaftStatesResult(states []string) string {
+	result := HEALTH_PASS
+	if len(states) == 0 {
+		return HEALTH_FAIL
+	}
+	for _, state := range states {
+		switch state {
+		case "LEADER", "FOLLOWER":
+		case "CANDIDATE", "TRANSFERRING":
+			result = HEALTH_WARN
+		default: // ERROR, SHUTDOWN, UNINITIALIZED...
+			return HEALTH_FAIL
+		}
+	}
+	return result
+}
+
+// ParseCoordinatorLeader returns the ip of leader in 'dingodb_cli GetCoordinatorMap'
+func ParseCoordinatorLeader(out string) string {
+	for _, line := range strings.Split(out, "\n") {
+		if !strings.Contains(strings.ToLower(line), "leader") {
+			continue
+		}
+		if ip := REGEX_IPV4.FindString(line); len(ip) > 0 {
+			return ip
+		}
+	}
+	return ""
+}
+
+// ParseStoreState returns the line of store in 'dingodb_cli GetStoreMap' and whether it is NORMAL
+func ParseStoreState(out string, storeId int) (string, bool) {
+	regex := regexp.MustCompile(fmt.Sprintf(`\b%d\b`, storeId))
+	for _, line := range strings.Split(out, "\n") {
+		if regex.MatchString(line) {
+			return strings.TrimSpace(line), strings.Contains(line, "NORMAL")
+		}
+	}
+	return "", false
+}
+
+// ParseRegionHealth counts regions of the store in 'dingodb_cli GetRegionMap'
+func ParseRegionHealth(out string, storeId int) RegionHealth {
+	health := RegionHealth{}
+	id := strconv.Itoa(storeId)
+	for _, region := range ParseRegionMap(out) {
+		if !utils.Contains(region.StoreIds, id) {
+			continue
+		}
+		health.Regions++
+		if region.LeaderStoreId == id {
+			health.Leaders++
+		} else if region.Unhealthy == REGION_UNHEALTHY_NO_LEADER {
+			health.Leaderless++
+		}
+	}
+	return health
+}
+
+// ParseDiskUsage returns the percentage in 'df --output=pcent', e.g. "Use%\n 45%"
+func ParseDiskUsage(out string) (int, error) {
+	lines := strings.Split(strings.TrimSpace(out), "\n")
+	value := strings.TrimSuffix(strings.TrimSpace(lines[len(lines)-1]), "%")
+	return strconv.Atoi(value)
+}
+
+func (s *step2CheckServiceHealth) add(item, result, message, hint string) {
+	s.checks = append(s.checks, newHealthCheck(s.dc, s.serviceId, item, result, message, hint))
+}
+
+func (s *step2CheckServiceHealth) containerExec(ctx *context.Context, command string) (bool, string) {
+	var success bool
+	var out string
+	(&step.ContainerExec{
+		ContainerId: &s.containerId,
+		Command:     command,
+		Success:     &success,
+		Out:         &out,
+		ExecOptions: s.execOptions,
+	}).Execute(ctx)
+	return success, out
+}
+
+func (s *step2CheckServiceHealth) dingodbCli(ctx *context.Context, command string) (bool, string) {
+	binDir := s.dc.GetProjectLayout().DingoStoreBinDir
+	return s.containerExec(ctx, fmt.Sprintf(COMMAND_DINGODB_CLI, binDir, command))
+}
+
+func (s *step2CheckServiceHealth) checkContainer(ctx *context.Context) bool {
+	count, out, err := inspectContainerState(ctx, s.containerId, s.execOptions)
+	if err != nil {
+		s.add(HEALTH_ITEM_CONTAINER, HEALTH_FAIL, "inspect container failed",
+			fmt.Sprintf("check docker daemon on host %s", s.dc.GetHost()))
+		return false
+	}
+
+	RecordStatusHistory(s.storage, storage.StatusHistory{
+		ClusterId:    s.clusterId,
+		ServiceId:    s.serviceId,
+		Role:         s.dc.GetRole(),
+		Host:         s.dc.GetHost(),
+		State:        out,
+		RestartCount: count,
+	})
+	if out != "running" {
+		s.add(HEALTH_ITEM_CONTAINER, HEALTH_FAIL, fmt.Sprintf("container is %s", out),
+			fmt.Sprintf("check logs under %s, then start it by 'dingoadm start --id %s'",
+				s.dc.GetLogDir(), s.serviceId))
+		return false
+	}
+	s.add(HEALTH_ITEM_CONTAINER, HEALTH_PASS, "running", "")
+	return true
+}
+
+func (s *step2CheckServiceHealth) checkBrpc(ctx *context.Context) {
+	dc := s.dc
+	var success bool
+	var out string
+	(&step.Curl{
+		Url:         fmt.Sprintf(URL_BRPC_HEALTH, dc.GetListenIp(), getBrpcPort(dc)),
+		Silent:      true,
+		Success:     &success,
+		Out:         &out,
+		ExecOptions: s.execOptions,
+	}).Execute(ctx)
+	if success && strings.Contains(out, "OK") {
+		s.add(HEALTH_ITEM_BRPC, HEALTH_PASS, fmt.Sprintf("port %d is healthy", getBrpcPort(dc)), "")
+	} else {
+		s.add(HEALTH_ITEM_BRPC, HEALTH_FAIL, fmt.Sprintf("port %d not responding", getBrpcPort(dc)),
+			fmt.Sprintf("check logs under %s", dc.GetLogDir()))
+	}
+}
+
+func (s *step2CheckServiceHealth) checkCoordinator(ctx *context.Context) {
+	// 1) raft state of local nodes
+	dc := s.dc
+	var success bool
+	var out string
+	(&step.Curl{
+		Url:         fmt.Sprintf(URL_BRPC_RAFT_STAT, dc.GetListenIp(), dc.GetDingoStoreRaftPort()),
+		Silent:      true,
+		Success:     &success,
+		Out:         &out,
+		ExecOptions: s.execOptions,
+	}).Execute(ctx)
+	states := ParseRaftStates(out)
+	switch RaftStatesResult(states) {
+	case HEALTH_PASS:
+		s.add(HEALTH_ITEM_RAFT, HEALTH_PASS, strings.Join(states, ","), "")
+	case HEALTH_WARN:
+		s.add(HEALTH_ITEM_RAFT, HEALTH_WARN, strings.Join(states, ","),
+			"election in progress, check network between coordinators if it lasts")
+	default:
+		message := utils.Choose(success, strings.Join(states, ","), "get raft_stat failed")
+		s.add(HEALTH_ITEM_RAFT, HEALTH_FAIL, utils.Choose(len(message) == 0, "no raft node", message),
+			fmt.Sprintf("check raft port %d and logs under %s", dc.GetDingoStoreRaftPort(), dc.GetLogDir()))
+	}
+
+	// 2) leader of coordinators
+	success, out = s.dingodbCli(ctx, "GetCoordinatorMap")
+	if leader := ParseCoordinatorLeader(out); success && len(leader) > 0 {
+		s.add(HEALTH_ITEM_LEADER, HEALTH_PASS, fmt.Sprintf("leader is %s", leader), "")
+	} else {
+		s.add(HEALTH_ITEM_LEADER, HEALTH_FAIL, "no leader found",
+			"ensure the majority of coordinators are running")
+	}
+}
+
+func (s *step2CheckServiceHealth) checkStore(ctx *context.Context) {
+	// 1) store state in coordinator
+	storeId := s.dc.GetDingoInstanceId()
+	success, out := s.dingodbCli(ctx, DINGODB_CLI_GET_STORE_MAP)
+	line, normal := ParseStoreState(out, storeId)
+	if !success {
+		s.add(HEALTH_ITEM_STORE, HEALTH_FAIL, "get store map failed",
+			"check coordinators are healthy by 'dingoadm check health --role coordinator'")
+		return
+	} else if len(line) == 0 {
+		s.add(HEALTH_ITEM_STORE, HEALTH_FAIL, fmt.Sprintf("store %d not registered", storeId),
+			fmt.Sprintf("check coordinator address in config and logs under %s", s.dc.GetLogDir()))
+		return
+	} else if !normal {
+		s.add(HEALTH_ITEM_STORE, HEALTH_FAIL, line,
+			fmt.Sprintf("check logs under %s", s.dc.GetLogDir()))
+	} else {
+		s.add(HEALTH_ITEM_STORE, HEALTH_PASS, fmt.Sprintf("store %d is NORMAL", storeId), "")
+	}
+
+	// 2) regions of store
+	success, out = s.dingodbCli(ctx, DINGODB_CLI_GET_REGION_MAP)
+	health := ParseRegionHealth(out, storeId)
+	message := fmt.Sprintf("%d regions, %d leaders, %d leaderless",
+		health.Regions, health.Leaders, health.Leaderless)
+	switch {
+	case !success:
+		s.add(HEALTH_ITEM_REGIONS, HEALTH_FAIL, "get region map failed",
+			"check coordinators are healthy by 'dingoadm check health --role coordinator'")
+	case health.Leaderless > 0:
+		s.add(HEALTH_ITEM_REGIONS, HEALTH_FAIL, message,
+			"ensure the majority of stores are running")
+	case health.Regions == 0:
+		s.add(HEALTH_ITEM_REGIONS, HEALTH_WARN, message,
+			"no region on store, ignore it if store is just added or drained")
+	default:
+		s.add(HEALTH_ITEM_REGIONS, HEALTH_PASS, message, "")
+	}
+}
+
+func (s *step2CheckServiceHealth) checkExecutor(ctx *context.Context) {
+	dc := s.dc
+	var success bool
+	var out string
+	(&step.Command{
+		Command:     fmt.Sprintf(COMMAND_TCP_PORT_CONNECT, dc.GetListenIp(), dc.GetDingoDBMySQLPort()),
+		Success:     &success,
+		Out:         &out,
+		ExecOptions: s.execOptions,
+	}).Execute(ctx)
+	if success {
+		s.add(HEALTH_ITEM_MYSQL, HEALTH_PASS,
+			fmt.Sprintf("port %d accepting connections", dc.GetDingoDBMySQLPort()), "")
+	} else {
+		s.add(HEALTH_ITEM_MYSQL, HEALTH_FAIL,
+			fmt.Sprintf("port %d refused connection", dc.GetDingoDBMySQLPort()),
+			fmt.Sprintf("check logs under %s", dc.GetLogDir()))
+	}
+}
+
+func (s *step2CheckServiceHealth) checkEtcd(ctx *context.Context) {
+	dc := s.dc
+	success, out := s.containerExec(ctx, fmt.Sprintf(COMMAND_ETCD_HEALTH,
+		dc.GetProjectLayout().ServiceBinDir, dc.GetListenIp(), dc.GetListenClientPort()))
+	if success && strings.Contains(out, "is healthy") {
+		s.add(HEALTH_ITEM_ENDPOINT, HEALTH_PASS, fmt.Sprintf("%s:%d is healthy",
+			dc.GetListenIp(), dc.GetListenClientPort()), "")
+	} else {
+		s.add(HEALTH_ITEM_ENDPOINT, HEALTH_FAIL, fmt.Sprintf("%s:%d is unhealthy",
+			dc.GetListenIp(), dc.GetListenClientPort()),
+			fmt.Sprintf("check member list and logs under %s", dc.GetLogDir()))
+	}
+}
+
+func (s *step2CheckServiceHealth) checkDisk(ctx *context.Context) {
+	dirs := []string{}
+	for _, dir := range []string{s.dc.GetDataDir(), s.dc.GetDingoRaftDir()} {
+		if len(dir) > 0 && dir != comm.SERVICE_DIR_ABSENT && !utils.Contains(dirs, dir) {
+			dirs = append(dirs, dir)
+		}
+	}
+
+	for _, dir := range dirs {
+		var out string
+		err := (&step.ShowDiskFree{
+			Files:       []string{dir},
+			Format:      "pcent",
+			Out:         &out,
+			ExecOptions: s.execOptions,
+		}).Execute(ctx)
+		usage, perr := ParseDiskUsage(out)
+		item := fmt.Sprintf("%s(%s)", HEALTH_ITEM_DISK, dir)
+		hint := fmt.Sprintf("free space under %s or expand the disk", dir)
+		switch {
+		case err != nil || perr != nil:
+			s.add(item, HEALTH_WARN, "get disk usage failed", fmt.Sprintf("check %s exists", dir))
+		case usage >= HEALTH_DISK_FAIL_PERCENT:
+			s.add(item, HEALTH_FAIL, fmt.Sprintf("%d%% used", usage), hint)
+		case usage >= HEALTH_DISK_WARN_PERCENT:
+			s.add(item, HEALTH_WARN, fmt.Sprintf("%d%% used", usage), hint)
+		default:
+			s.add(item, HEALTH_PASS, fmt.Sprintf("%d%% used", usage), "")
+		}
+	}
+}
+
+/*
+ * the failed probe is recorded rather than returned,
+ * so that all services are checked and reported together
+ */
+func (s *step2CheckServiceHealth) Execute(ctx *context.Context) error {
+	s.checks = []HealthCheck{}
+	defer func() { addHealthChecks(s.memStorage, s.serviceId, s.checks) }()
+
+	// 1) container status, skip probes if it isn't running
+	if !s.checkContainer(ctx) {
+		s.checkDisk(ctx)
+		return nil
+	}
+
+	// 2) brpc health
+	if hasBrpcHealth(s.dc) {
+		s.checkBrpc(ctx)
+	}
+
+	// 3) role-specific probes
+	switch s.dc.GetRole() {
+	case topology.ROLE_COORDINATOR:
+		s.checkCoordinator(ctx)
+	case topology.ROLE_STORE:
+		s.checkStore(ctx)
+	case topology.ROLE_DINGODB_EXECUTOR:
+		s.checkExecutor(ctx)
+	case topology.ROLE_ETCD:
+		s.checkEtcd(ctx)
+	}
+
+	// 4) disk usage of data/raft dirs
+	s.checkDisk(ctx)
+	return nil
+}
+
+func (s *step2CheckMdsv2Health) mdsCli(ctx *context.Context, command string) (bool, string) {
+	var success bool
+	var out string
+	(&step.ContainerExec{
+		ContainerId: &s.containerId,
+		Command:     fmt.Sprintf(COMMAND_MDSV2_CLI, s.binaryPath, command),
+		Success:     &success,
+		Out:         &out,
+		ExecOptions: s.execOptions,
+	}).Execute(ctx)
+	return success, out
+}
+
+/*
+ * mds v2 is probed through mds client:
+ *   heartbeat: mds is listed in 'GetMdsList' once its heartbeat reached coordinator
+ *   fs: filesystems can be listed from meta tables
+ */
+func (s *step2CheckMdsv2Health) Execute(ctx *context.Context) error {
+	dc := s.dc
+	checks := []HealthCheck{}
+	mdsId := dc.GetDingoInstanceId()
+	success, out := s.mdsCli(ctx, "GetMdsList")
+	regex := regexp.MustCompile(fmt.Sprintf(`\b%d\b`, mdsId))
+	if success && regex.MatchString(out) {
+		checks = append(checks, newHealthCheck(dc, s.serviceId, HEALTH_ITEM_HEARTBEAT,
+			HEALTH_PASS, fmt.Sprintf("mds %d in mds list", mdsId), ""))
+	} else {
+		checks = append(checks, newHealthCheck(dc, s.serviceId, HEALTH_ITEM_HEARTBEAT,
+			HEALTH_FAIL, fmt.Sprintf("mds %d not in mds list", mdsId),
+			fmt.Sprintf("check logs under %s, then restart it by 'dingoadm restart --id %s'",
+				dc.GetLogDir(), s.serviceId)))
+	}
+
+	success, _ = s.mdsCli(ctx, "ListFs")
+	if success {
+		checks = append(checks, newHealthCheck(dc, s.serviceId, HEALTH_ITEM_FS,
+			HEALTH_PASS, "list filesystems success", ""))
+	} else {
+		checks = append(checks, newHealthCheck(dc, s.serviceId, HEALTH_ITEM_FS,
+			HEALTH_FAIL, "list filesystems failed",
+			"check coordinators are reachable from mds client"))
+	}
+
+	addHealthChecks(s.memStorage, s.serviceId, checks)
+	return nil
+}
+
+func NewCheckServiceHealthTask(dingoadm *cli.DingoAdm, dc *topology.DeployConfig) (*task.Task, error) {
+	if dc.GetRole() == topology.ROLE_FS_MDS_CLI {
+		return nil, nil
+	}
+
+	serviceId := dingoadm.GetServiceId(dc.GetId())
+	containerId, err := dingoadm.GetContainerId(serviceId)
+	if dingoadm.IsSkip(dc) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	hc, err := dingoadm.GetHost(dc.GetHost())
+	if err != nil {
+		return nil, err
+	}
+
+	// new task
+	subname := fmt.Sprintf("host=%s role=%s containerId=%s",
+		dc.GetHost(), dc.GetRole(), tui.TrimContainerId(containerId))
+	t := task.NewTask("Check Service Health", subname, hc.GetSSHConfig())
+
+	// add step to task
+	t.AddStep(&step2CheckServiceHealth{
+		dc:          dc,
+		serviceId:   serviceId,
+		containerId: containerId,
+		memStorage:  dingoadm.MemStorage(),
+		storage:     dingoadm.Storage(),
+		clusterId:   dingoadm.ClusterId(),
+		execOptions: dingoadm.ExecOptions(),
+	})
+
+	return t, nil
+}
+
+func NewCheckMdsv2HealthTask(dingoadm *cli.DingoAdm, dc *topology.DeployConfig) (*task.Task, error) {
+	if dc.GetRole() != topology.ROLE_FS_MDS ||
+		dc.GetCtx().Lookup(topology.CTX_KEY_MDS_VERSION) != topology.CTX_VAL_MDS_V2 {
+		return nil, nil
+	} else if dingoadm.IsSkip(dc) {
+		return nil, nil
+	}
+
+	serviceId := dingoadm.GetServiceId(dc.GetId())
+	mdsCli, containerId := getMdsv2ClientContainer(dingoadm)
+	if mdsCli == nil {
+		addHealthChecks(dingoadm.MemStorage(), serviceId, []HealthCheck{
+			newHealthCheck(dc, serviceId, HEALTH_ITEM_HEARTBEAT, HEALTH_WARN,
+				"no mds client container found",
+				"mds client container is created by 'dingoadm deploy'"),
+		})
+		return nil, nil
+	}
+	hc, err := dingoadm.GetHost(mdsCli.GetHost())
+	if err != nil {
+		return nil, err
+	}
+
+	// new task
+	subname := fmt.Sprintf("host=%s role=%s containerId=%s",
+		dc.GetHost(), dc.GetRole(), tui.TrimContainerId(containerId))
+	t := task.NewTask("Check MDS Health", subname, hc.GetSSHConfig())
+
+	// add step to task
+	t.AddStep(&step2CheckMdsv2Health{
+		dc:          dc,
+		serviceId:   serviceId,
+		containerId: containerId,
+		binaryPath:  mdsCli.GetProjectLayout().FSMdsCliBinaryPath,
+		memStorage:  dingoadm.MemStorage(),
+		execOptions: dingoadm.ExecOptions(),
+	})
+
+	return t, nil
+}
@@ -0,0 +1,76 @@
+package common
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRaftStates(t *testing.T) {
+	assert := assert.New(t)
+	out := "[meta]\npeer_id: 10.0.0.1:22101:0\nstate: LEADER\n\n[kv]\nstate: FOLLOWER\n"
+	states := ParseRaftStates(out)
+	assert.Equal([]string{"LEADER", "FOLLOWER"}, states)
+	assert.Equal(HEALTH_PASS, RaftStatesResult(states))
+	assert.Equal(HEALTH_WARN, RaftStatesResult([]string{"LEADER", "CANDIDATE"}))
+	assert.Equal(HEALTH_FAIL, RaftStatesResult([]string{"CANDIDATE", "ERROR"}))
+	assert.Equal(HEALTH_FAIL, RaftStatesResult([]string{}))
+}
+
+func TestParseCoordinatorLeader(t *testing.T) {
+	assert := assert.New(t)
+	out := "coordinator 10.0.0.1:22001 FOLLOWER\nleader_location: 10.0.0.2:22001\n"
+	assert.Equal("10.0.0.2", ParseCoordinatorLeader(out))
+	assert.Equal("", ParseCoordinatorLeader("no coordinator"))
+}
+
+func TestParseStoreState(t *testing.T) {
+	assert := assert.New(t)
+	out := "store_id=1001 state=STORE_NORMAL\nstore_id=1002 state=STORE_OFFLINE\n"
+	line, normal := ParseStoreState(out, 1001)
+	assert.Equal("store_id=1001 state=STORE_NORMAL", line)
+	assert.True(normal)
+	_, normal = ParseStoreState(out, 1002)
+	assert.False(normal)
+	line, _ = ParseStoreState(out, 100)
+	assert.Equal("", line)
+}
+
+func TestParseRegionHealth(t *testing.T) {
+	assert := assert.New(t)
+	out := `
+regions {
+  id: 80001
+  leader_store_id: 1001
+  store_id: 1001
+  store_id: 1002
+}
+regions {
+  id: 80002
+  leader_store_id: 1002
+  store_id: 1001
+  store_id: 1002
+}
+regions {
+  id: 80003
+  leader_store_id: 0
+  store_id: 1001
+}
+regions {
+  id: 80004
+  leader_store_id: 1003
+  store_id: 1003
+}
+`
+	assert.Equal(RegionHealth{Regions: 3, Leaders: 1, Leaderless: 1}, ParseRegionHealth(out, 1001))
+	assert.Equal(RegionHealth{}, ParseRegionHealth(out, 1004))
+}
+
+func TestParseDiskUsage(t *testing.T) {
+	assert := assert.New(t)
+	usage, err := ParseDiskUsage("Use%\n 45%\n")
+	assert.Nil(err)
+	assert.Equal(45, usage)
+	_, err = ParseDiskUsage("")
+	assert.NotNil(err)
+}
@@ -58,13 +58,13 @@ type (
 	}
 
 	ClientStatus struct {
-		Id          string
-		Host        string
-		Kind        string
-		ContainerId string
-		Status      string
-		AuxInfo     string
-		CfgPath     string
+		Id          string `json:"id" yaml:"id"`
+		Host        string `json:"host" yaml:"host"`
+		Kind        string `json:"kind" yaml:"kind"`
+		ContainerId string `json:"container_id" yaml:"container_id"`
+		Status      string `json:"status" yaml:"status"`
+		AuxInfo     string `json:"aux_info" yaml:"aux_info"`
+		CfgPath     string `json:"cfg_path" yaml:"cfg_path"`
 	}
 )
 
@@ -0,0 +1,304 @@
+/*
+ *  Copyright (c) 2025 dingodb.com Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: dingoadm
+ * Author: dongwei (jackblack369)
+ */
+
+package common
+
+import (
+	"fmt"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/dingodb/dingoadm/cli/cli"
+	comm "github.com/dingodb/dingoadm/internal/common"
+	"github.com/dingodb/dingoadm/internal/configure/topology"
+	"github.com/dingodb/dingoadm/internal/errno"
+	"github.com/dingodb/dingoadm/internal/task/context"
+	"github.com/dingodb/dingoadm/internal/task/step"
+	"github.com/dingodb/dingoadm/internal/task/task"
+	tui "github.com/dingodb/dingoadm/internal/tui/common"
+	"github.com/dingodb/dingoadm/internal/utils"
+	"github.com/dingodb/dingoadm/pkg/module"
+)
+
+const (
+	// output line: <size> <mtime> <path>, e.g. "1048576 1735689600.1234567890 /data/cores/core.dingodb_server.1234"
+	COMMAND_LIST_CORES = "find %s -maxdepth 1 -type f -printf '%%s %%T@ %%p\\n'"
+	// output line: <path>: ELF 64-bit LSB core file, ..., from '...', ..., execfn: '/path/to/binary', ...
+	COMMAND_FILE_CORES  = "file %s"
+	COMMAND_GDB_BT      = "bash -c 'command -v gdb >/dev/null || { echo \"gdb not found in container\" >&2; exit 127; }; gdb -batch -q -ex \"thread apply all bt\" %s %s 2>&1'"
+	CORE_ID_LENGTH      = 12
+	CORE_BINARY_UNKNOWN = ""
+)
+
+var (
+	REGEX_CORE_EXECFN = regexp.MustCompile(`execfn: '([^']+)'`)
+	REGEX_CORE_FROM   = regexp.MustCompile(`from '([^' ]+)`)
+)
+
+type (
+	Core struct {
+		Id            string    `json:"id" yaml:"id"`
+		ServiceId     string    `json:"service_id" yaml:"service_id"`
+		Role          string    `json:"role" yaml:"role"`
+		Host          string    `json:"host" yaml:"host"`
+		Path          string    `json:"path" yaml:"path"`
+		ContainerPath string    `json:"container_path" yaml:"container_path"`
+		Size          int64     `json:"size" yaml:"size"`
+		Time          time.Time `json:"time" yaml:"time"`
+		Binary        string    `json:"binary" yaml:"binary"`
+	}
+
+	step2ListCores struct {
+		dc          *topology.DeployConfig
+		serviceId   string
+		memStorage  *utils.SafeMap
+		execOptions module.ExecOptions
+	}
+)
+
+// GetCoreId returns the identifier of core which is stable across listings
+func GetCoreId(host, path string) string {
+	return utils.MD5Sum(fmt.Sprintf("%s:%s", host, path))[:CORE_ID_LENGTH]
+}
+
+// ParseCoreList parses output of COMMAND_LIST_CORES, the newest core comes first
+func ParseCoreList(out string) []Core {
+	cores := []Core{}
+	for _, line := range strings.Split(out, "\n") {
+		fields := strings.SplitN(strings.TrimSpace(line), " ", 3)
+		if len(fields) != 3 {
+			continue
+		}
+		size, err := strconv.ParseInt(fields[0], 10, 64)
+		if err != nil {
+			continue
+		}
+		mtime, err := strconv.ParseFloat(fields[1], 64)
+		if err != nil {
+			continue
+		}
+		sec := int64(mtime)
+		cores = append(cores, Core{
+			Path: fields[2],
+			Size: size,
+			Time: time.Unix(sec, int64((mtime-float64(sec))*1e9)),
+		})
+	}
+
+	sort.SliceStable(cores, func(i, j int) bool {
+		return cores[i].Time.After(cores[j].Time)
+	})
+	return cores
+}
+
+// ParseCoreBinaries parses output of COMMAND_FILE_CORES, returns binary keyed by core path
+func ParseCoreBinaries(out string) map[string]string {
+	binaries := map[string]string{}
+	for _, line := range strings.Split(out, "\n") {
+		i := strings.Index(line, ": ")
+		if i <= 0 {
+			continue
+		}
+		corePath, desc := line[:i], line[i+2:]
+		if mu := REGEX_CORE_EXECFN.FindStringSubmatch(desc); len(mu) > 0 {
+			binaries[corePath] = mu[1]
+		} else if mu := REGEX_CORE_FROM.FindStringSubmatch(desc); len(mu) > 0 {
+			binaries[corePath] = mu[1]
+		}
+	}
+	return binaries
+}
+
+func addCores(memStorage *utils.SafeMap, id string, cores []Core) {
+	memStorage.TX(func(kv *utils.SafeMap) error {
+		m := map[string][]Core{}
+		v := kv.Get(comm.KEY_ALL_CORES)
+		if v != nil {
+			m = v.(map[string][]Core)
+		}
+		m[id] = cores
+		kv.Set(comm.KEY_ALL_CORES, m)
+		return nil
+	})
+}
+
+func (s *step2ListCores) Execute(ctx *context.Context) error {
+	dc := s.dc
+	targetCoreDir := dc.GetTargetCoreDir()
+	out, err := ctx.Module().Shell().Command(fmt.Sprintf(COMMAND_LIST_CORES, targetCoreDir)).Execute(s.execOptions)
+	if err != nil {
+		return errno.ERR_RUN_A_BASH_COMMAND_FAILED.E(err)
+	}
+
+	cores := ParseCoreList(out)
+	if len(cores) > 0 {
+		paths := []string{}
+		for _, core := range cores {
+			paths = append(paths, fmt.Sprintf("'%s'", core.Path))
+		}
+		// binary is best effort, file(1) maybe not installed on host
+		out, _ = ctx.Module().Shell().Command(fmt.Sprintf(COMMAND_FILE_CORES, strings.Join(paths, " "))).Execute(s.execOptions)
+		binaries := ParseCoreBinaries(out)
+		for i := range cores {
+			core := &cores[i]
+			core.Id = GetCoreId(dc.GetHost(), core.Path)
+			core.ServiceId = s.serviceId
+			core.Role = dc.GetRole()
+			core.Host = dc.GetHost()
+			core.ContainerPath = path.Join(dc.GetSourceCoreDir(), path.Base(core.Path))
+			core.Binary = binaries[core.Path]
+		}
+	}
+	addCores(s.memStorage, s.serviceId, cores)
+	return nil
+}
+
+func checkCoreContainer(core Core, containerId string) step.LambdaType {
+	return func(ctx *context.Context) error {
+		if len(containerId) == 0 || containerId == comm.CLEANED_CONTAINER_ID {
+			return errno.ERR_CONTAINER_NOT_EXISTED.
+				F("host=%s role=%s core=%s", core.Host, core.Role, core.Id)
+		} else if core.Binary == CORE_BINARY_UNKNOWN {
+			return errno.ERR_UNKNOWN_CORE_BINARY.F("core=%s", core.Path)
+		}
+		return nil
+	}
+}
+
+// list core files under target core dir which mounted into container
+func NewListCoresTask(dingoadm *cli.DingoAdm, dc *topology.DeployConfig) (*task.Task, error) {
+	serviceId := dingoadm.GetServiceId(dc.GetId())
+	if dingoadm.IsSkip(dc) {
+		return nil, nil
+	} else if len(dc.GetSourceCoreDir()) == 0 || len(dc.GetTargetCoreDir()) == 0 {
+		return nil, nil
+	}
+	hc, err := dingoadm.GetHost(dc.GetHost())
+	if err != nil {
+		return nil, err
+	}
+
+	// new task
+	subname := fmt.Sprintf("host=%s role=%s coreDir=%s",
+		dc.GetHost(), dc.GetRole(), dc.GetTargetCoreDir())
+	t := task.NewTask("List Cores", subname, hc.GetSSHConfig())
+
+	// add step to task
+	t.AddStep(&step2ListCores{
+		dc:          dc,
+		serviceId:   serviceId,
+		memStorage:  dingoadm.MemStorage(),
+		execOptions: dingoadm.ExecOptions(),
+	})
+
+	return t, nil
+}
+
+// download core file and the binary which copied from service container
+func NewFetchCoreTask(dingoadm *cli.DingoAdm, dc *topology.DeployConfig) (*task.Task, error) {
+	serviceId := dingoadm.GetServiceId(dc.GetId())
+	containerId, err := dingoadm.GetContainerId(serviceId)
+	if err != nil {
+		return nil, err
+	}
+	hc, err := dingoadm.GetHost(dc.GetHost())
+	if err != nil {
+		return nil, err
+	}
+
+	// new task
+	core := dingoadm.MemStorage().Get(comm.KEY_CORE).(Core)
+	saveDir := dingoadm.MemStorage().Get(comm.KEY_CORE_SAVE_DIR).(string)
+	subname := fmt.Sprintf("host=%s role=%s containerId=%s core=%s",
+		dc.GetHost(), dc.GetRole(), tui.TrimContainerId(containerId), core.Id)
+	t := task.NewTask("Fetch Core", subname, hc.GetSSHConfig())
+
+	// add step to task
+	remoteBinaryPath := utils.RandFilename(TEMP_DIR)
+	t.AddStep(&step.Lambda{
+		Lambda: checkCoreContainer(core, containerId),
+	})
+	t.AddStep(&step.CopyFromContainer{ // copy binary to host
+		ContainerId:      containerId,
+		ContainerSrcPath: core.Binary,
+		HostDestPath:     remoteBinaryPath,
+		ExecOptions:      dingoadm.ExecOptions(),
+	})
+	t.AddStep(&step.DownloadFile{
+		RemotePath:  core.Path,
+		LocalPath:   path.Join(saveDir, path.Base(core.Path)),
+		ExecOptions: dingoadm.ExecOptions(),
+	})
+	t.AddStep(&step.DownloadFile{
+		RemotePath:  remoteBinaryPath,
+		LocalPath:   path.Join(saveDir, path.Base(core.Binary)),
+		ExecOptions: dingoadm.ExecOptions(),
+	})
+	t.AddPostStep(&step.RemoveFile{
+		Files:       []string{remoteBinaryPath},
+		ExecOptions: dingoadm.ExecOptions(),
+	})
+
+	return t, nil
+}
+
+// run gdb in service container to print backtrace of all threads
+func NewBacktraceCoreTask(dingoadm *cli.DingoAdm, dc *topology.DeployConfig) (*task.Task, error) {
+	serviceId := dingoadm.GetServiceId(dc.GetId())
+	containerId, err := dingoadm.GetContainerId(serviceId)
+	if err != nil {
+		return nil, err
+	}
+	hc, err := dingoadm.GetHost(dc.GetHost())
+	if err != nil {
+		return nil, err
+	}
+
+	// new task
+	core := dingoadm.MemStorage().Get(comm.KEY_CORE).(Core)
+	subname := fmt.Sprintf("host=%s role=%s containerId=%s core=%s",
+		dc.GetHost(), dc.GetRole(), tui.TrimContainerId(containerId), core.Id)
+	t := task.NewTask("Backtrace Core", subname, hc.GetSSHConfig())
+
+	// add step to task
+	var out string
+	t.AddStep(&step.Lambda{
+		Lambda: checkCoreContainer(core, containerId),
+	})
+	t.AddStep(&step.ContainerExec{
+		ContainerId: &containerId,
+		Command:     fmt.Sprintf(COMMAND_GDB_BT, core.Binary, core.ContainerPath),
+		Out:         &out,
+		ExecOptions: dingoadm.ExecOptions(),
+	})
+	t.AddStep(&step.Lambda{
+		Lambda: func(ctx *context.Context) error {
+			dingoadm.MemStorage().Set(comm.KEY_CORE_BACKTRACE, out)
+			return nil
+		},
+	})
+
+	return t, nil
+}
@@ -0,0 +1,43 @@
+package common
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseCoreList(t *testing.T) {
+	assert := assert.New(t)
+	out := `
+1048576 1735689600.5000000000 /data/cores/core.dingodb_server.1234
+2048 1735776000.0000000000 /data/cores/core dingo-mdsv2.99
+invalid line
+`
+	cores := ParseCoreList(out)
+	assert.Len(cores, 2)
+	assert.Equal("/data/cores/core dingo-mdsv2.99", cores[0].Path)
+	assert.Equal(int64(2048), cores[0].Size)
+	assert.Equal("/data/cores/core.dingodb_server.1234", cores[1].Path)
+	assert.Equal(int64(1048576), cores[1].Size)
+	assert.Equal(time.Unix(1735689600, 500000000), cores[1].Time)
+}
+
+func TestParseCoreBinaries(t *testing.T) {
+	assert := assert.New(t)
+	out := `/data/cores/core.1: ELF 64-bit LSB core file, x86-64, version 1 (SYSV), SVR4-style, from '/opt/dingo/dist/bin/dingodb_server --role=store', real uid: 0, effective uid: 0, execfn: '/opt/dingo/dist/bin/dingodb_server', platform: 'x86_64'
+/data/cores/core.2: ELF 64-bit LSB core file, x86-64, version 1 (SYSV), SVR4-style, from '/opt/dingo/bin/dingo-mdsv2 --conf_path=/x'
+/data/cores/core.3: data`
+	binaries := ParseCoreBinaries(out)
+	assert.Equal("/opt/dingo/dist/bin/dingodb_server", binaries["/data/cores/core.1"])
+	assert.Equal("/opt/dingo/bin/dingo-mdsv2", binaries["/data/cores/core.2"])
+	_, ok := binaries["/data/cores/core.3"]
+	assert.False(ok)
+}
+
+func TestGetCoreId(t *testing.T) {
+	id := GetCoreId("server-1", "/data/cores/core.1")
+	assert.Len(t, id, CORE_ID_LENGTH)
+	assert.Equal(t, id, GetCoreId("server-1", "/data/cores/core.1"))
+	assert.NotEqual(t, id, GetCoreId("server-2", "/data/cores/core.1"))
+}
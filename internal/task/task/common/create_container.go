@@ -41,6 +41,7 @@ import (
 	"github.com/dingodb/dingoadm/internal/task/context"
 	"github.com/dingodb/dingoadm/internal/task/step"
 	"github.com/dingodb/dingoadm/internal/task/task"
+	"github.com/dingodb/dingoadm/internal/utils"
 	log "github.com/dingodb/dingoadm/pkg/log/glg"
 )
 
@@ -63,6 +64,8 @@ const (
 	ENV_DINGOSTORE_RAFT_START_PORT               = "RAFT_START_PORT"
 	ENV_DINGOSTORE_INSTANCE_START_ID             = "INSTANCE_START_ID"
 	ENV_DINGOSTORE_ENABLE_LITE                   = "ENABLE_LITE"
+	ENV_DINGOSTORE_ZONE                          = "ZONE"
+	ENV_DINGOSTORE_RACK                          = "RACK"
 
 	// dingodb
 	ENV_DINGOSTORE_DISKANN_SERVER_HOST       = "DISKANN_SERVER_HOST"
@@ -315,6 +318,17 @@ func configMdsv2ENV(envs []string, dc *topology.DeployConfig) []string {
 	return envs
 }
 
+// GetScaleOutEnvironments returns extra envs for the service which join an existing cluster
+func GetScaleOutEnvironments(dc *topology.DeployConfig) []string {
+	envs := []string{}
+	if dc.GetKind() == topology.KIND_DINGOFS && dc.GetRole() == topology.ROLE_FS_MDS &&
+		dc.GetCtx().Lookup(topology.CTX_KEY_MDS_VERSION) == topology.CTX_VAL_MDS_V2 {
+		// the new container only starts the mds server of its own instance id
+		envs = append(envs, fmt.Sprintf("%s=%d", ENV_DINGOFS_V2_FLAGS_SERVER_NUM, 1))
+	}
+	return envs
+}
+
 func configDingoStoreENV(envs []string, dc *topology.DeployConfig) []string {
 	envs = append(envs, fmt.Sprintf("%s=%s", ENV_DINGOSTROE_FLAGS_ROLE, dc.GetRole()))
 	envs = append(envs, fmt.Sprintf("%s=%s", ENV_DINGOSTROE_FLAGS_CLEAN_LOG, "1")) // not clean log
@@ -323,6 +337,12 @@ func configDingoStoreENV(envs []string, dc *topology.DeployConfig) []string {
 	envs = append(envs, fmt.Sprintf("%s=%s", ENV_DINGO_SERVER_HOST, dc.GetHostname()))
 	envs = append(envs, fmt.Sprintf("%s=%s", ENV_DINGOSTORE_RAFT_HOST, dc.GetHostname()))
 	envs = append(envs, fmt.Sprintf("%s=%d", ENV_DINGOSTORE_DEFAULT_REPLICA_NUM, dc.GetDingoStoreReplicaNum()))
+	if len(dc.GetZone()) > 0 {
+		envs = append(envs, fmt.Sprintf("%s=%s", ENV_DINGOSTORE_ZONE, dc.GetZone()))
+	}
+	if len(dc.GetRack()) > 0 {
+		envs = append(envs, fmt.Sprintf("%s=%s", ENV_DINGOSTORE_RACK, dc.GetRack()))
+	}
 	envs = append(envs, fmt.Sprintf("%s=%d", ENV_DINGOSTORE_COORDINATOR_SERVER_START_PORT,
 		dc.GetDingoServerPort()))
 	envs = append(envs, fmt.Sprintf("%s=%d", ENV_DINGOSTORE_COORDINATOR_RAFT_START_PORT,
@@ -444,6 +464,19 @@ func getRestartPolicy(dc *topology.DeployConfig) string {
 	return POLICY_NEVER_RESTART
 }
 
+// labels let us find out which cluster/service a container belongs to
+// even if the database of dingoadm is lost, see `dingoadm cluster adopt`
+func getLabels(dingoadm *cli.DingoAdm, dc *topology.DeployConfig, serviceId string) []string {
+	return []string{
+		fmt.Sprintf("%s=%s", comm.LABEL_CLUSTER_UUID, dingoadm.ClusterUUId()),
+		fmt.Sprintf("%s=%s", comm.LABEL_SERVICE_ID, serviceId),
+		fmt.Sprintf("%s=%s", comm.LABEL_ROLE, dc.GetRole()),
+		fmt.Sprintf("%s=%s", comm.LABEL_KIND, dc.GetKind()),
+		fmt.Sprintf("%s=%s", comm.LABEL_DINGOADM_VERSION, cli.Version),
+		fmt.Sprintf("%s=%s", comm.LABEL_TOPOLOGY_HASH, utils.MD5Sum(dingoadm.ClusterTopologyData())),
+	}
+}
+
 func TrimContainerId(containerId *string) step.LambdaType {
 	return func(ctx *context.Context) error {
 		items := strings.Split(*containerId, "\n")
@@ -497,6 +530,11 @@ func NewCreateContainerTask(dingoadm *cli.DingoAdm, dc *topology.DeployConfig) (
 	}
 	// TODO createDir for dingodb executor /opt/dingo/localStore
 
+	envs := GetEnvironments(dc)
+	if dingoadm.MemStorage().Get(comm.KEY_SCALE_OUT_CLUSTER) != nil { // scale out cluster
+		envs = append(envs, GetScaleOutEnvironments(dc)...)
+	}
+
 	t.AddStep(&step.CreateDirectory{
 		Paths:       createDir,
 		ExecOptions: options,
@@ -505,9 +543,10 @@ func NewCreateContainerTask(dingoadm *cli.DingoAdm, dc *topology.DeployConfig) (
 		Image:      dc.GetContainerImage(),
 		Command:    getContainerCMD(dc),
 		AddHost:    []string{fmt.Sprintf("%s:127.0.0.1", hostname)},
-		Envs:       GetEnvironments(dc),
+		Envs:       envs,
 		Hostname:   hostname,
 		Init:       true,
+		Labels:     getLabels(dingoadm, dc, serviceId),
 		Name:       hostname,
 		Privileged: true,
 		Restart:    getRestartPolicy(dc), // POLICY_ALWAYS_RESTART
@@ -567,6 +606,7 @@ func NewCreateMdsv2CliContainerTask(dingoadm *cli.DingoAdm, dc *topology.DeployC
 		Envs:       GetEnvironments(dc),
 		Hostname:   hostname,
 		Init:       true,
+		Labels:     getLabels(dingoadm, dc, serviceId),
 		Name:       hostname,
 		Privileged: true,
 		Restart:    POLICY_NEVER_RESTART,
@@ -0,0 +1,74 @@
+package common
+
+import (
+	"testing"
+
+	"github.com/dingodb/dingoadm/internal/configure/topology"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConfigDingoStoreENVLocation(t *testing.T) {
+	assert := assert.New(t)
+
+	data := `
+kind: dingo-store
+coordinator_services:
+  deploy:
+    - host: host1
+store_services:
+  deploy:
+    - host: host1
+    - host: host2
+      zone: zone-b
+      rack: rack-b
+`
+	ctx := topology.NewContext()
+	ctx.Add("host1", "host1")
+	ctx.Add("host2", "host2")
+	ctx.AddLocation("host1", "zone-a", "")
+	dcs, err := topology.ParseTopology(data, ctx)
+	assert.Nil(err)
+
+	envs := configDingoStoreENV([]string{}, dcs[1])
+	assert.Contains(envs, "ZONE=zone-a")
+	assert.NotContains(envs, "RACK=")
+	assert.Contains(envs, "DEFAULT_REPLICA_NUM=3")
+
+	envs = configDingoStoreENV([]string{}, dcs[2])
+	assert.Contains(envs, "ZONE=zone-b")
+	assert.Contains(envs, "RACK=rack-b")
+}
+
+func TestGetScaleOutEnvironments(t *testing.T) {
+	assert := assert.New(t)
+
+	data := `
+kind: dingofs
+coordinator_services:
+  deploy:
+    - host: host1
+store_services:
+  deploy:
+    - host: host1
+mds_services:
+  deploy:
+    - host: host1
+executor_services:
+  deploy:
+    - host: host1
+`
+	ctx := topology.NewContext()
+	ctx.Add("host1", "host1")
+	dcs, err := topology.ParseTopology(data, ctx)
+	assert.Nil(err)
+
+	for _, dc := range dcs {
+		envs := GetScaleOutEnvironments(dc)
+		if dc.GetRole() == topology.ROLE_FS_MDS {
+			assert.Equal([]string{"FLAGS_server_num=1"}, envs)
+		} else {
+			assert.Empty(envs)
+		}
+		assert.NotContains(GetEnvironments(dc), "FLAGS_server_num=1")
+	}
+}
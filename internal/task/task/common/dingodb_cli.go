@@ -0,0 +1,434 @@
+/*
+ *  Copyright (c) 2025 dingodb.com Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: dingoadm
+ * Author: dongwei (jackblack369)
+ */
+
+package common
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/dingodb/dingoadm/cli/cli"
+	comm "github.com/dingodb/dingoadm/internal/common"
+	"github.com/dingodb/dingoadm/internal/configure/topology"
+	"github.com/dingodb/dingoadm/internal/task/context"
+	"github.com/dingodb/dingoadm/internal/task/step"
+	"github.com/dingodb/dingoadm/internal/task/task"
+	tui "github.com/dingodb/dingoadm/internal/tui/common"
+	"github.com/dingodb/dingoadm/internal/utils"
+	"github.com/dingodb/dingoadm/pkg/module"
+)
+
+const (
+	DINGODB_CLI_GET_STORE_MAP  = "GetStoreMap"
+	DINGODB_CLI_GET_REGION_MAP = "GetRegionMap"
+
+	REGION_UNHEALTHY_NO_LEADER       = "no leader"
+	REGION_UNHEALTHY_LEADER_NOT_PEER = "leader not in peers"
+	REGION_UNHEALTHY_NO_PEER         = "no peer"
+)
+
+var (
+	// e.g. addr={host: "10.0.0.1" port: 20001}
+	REGEX_STORE_ADDR = regexp.MustCompile(`host:\s*"?([^"\s]+)"?\s+port:\s*(\d+)`)
+	// e.g. store_id=1001 or state=STORE_NORMAL
+	REGEX_KEY_VALUE = regexp.MustCompile(`(\w+)=([^\s{}]+)`)
+)
+
+type (
+	step2RunDingodbCli struct {
+		dc          *topology.DeployConfig
+		containerId string
+		commands    []string
+		memStorage  *utils.SafeMap
+		execOptions module.ExecOptions
+	}
+
+	step2GetRaftStat struct {
+		dc          *topology.DeployConfig
+		serviceId   string
+		memStorage  *utils.SafeMap
+		execOptions module.ExecOptions
+	}
+
+	Region struct {
+		Id            string   `json:"id" yaml:"id"`
+		Name          string   `json:"name" yaml:"name"`
+		State         string   `json:"state" yaml:"state"`
+		LeaderStoreId string   `json:"leader_store_id" yaml:"leader_store_id"`
+		StoreIds      []string `json:"store_ids" yaml:"store_ids"`
+		Unhealthy     string   `json:"unhealthy,omitempty" yaml:"unhealthy,omitempty"`
+	}
+
+	Store struct {
+		Id      string `json:"id" yaml:"id"`
+		Type    string `json:"type" yaml:"type"`
+		State   string `json:"state" yaml:"state"`
+		InState string `json:"in_state" yaml:"in_state"`
+		Address string `json:"address" yaml:"address"`
+		Regions int    `json:"regions" yaml:"regions"`
+		Leaders int    `json:"leaders" yaml:"leaders"`
+	}
+
+	RaftNode struct {
+		StoreId string   `json:"store_id" yaml:"store_id"`
+		Host    string   `json:"host" yaml:"host"`
+		Group   string   `json:"group" yaml:"group"`
+		PeerId  string   `json:"peer_id" yaml:"peer_id"`
+		State   string   `json:"state" yaml:"state"`
+		Term    string   `json:"term" yaml:"term"`
+		Peers   []string `json:"peers" yaml:"peers"`
+	}
+)
+
+/*
+ * walkTextFormat walks the protobuf text format which printed by dingodb_cli,
+ * fn is called with key "{" when a block begins, "}" when it ends,
+ * otherwise with the key and unquoted value of field:
+ *
+ * regions {             -> fn([regions], "{", "")
+ *   id: 80001           -> fn([regions], "id", "80001")
+ *   definition {        -> fn([regions definition], "{", "")
+ *     name: "T_1"       -> fn([regions definition], "name", "T_1")
+ *   }                   -> fn([regions definition], "}", "")
+ * }                     -> fn([regions], "}", "")
+ */
+func walkTextFormat(out string, fn func(path []string, key, value string)) {
+	out = strings.NewReplacer("{", " { ", "}", " } ").Replace(out)
+	tokens := strings.Fields(out)
+	path := []string{}
+	for i := 0; i < len(tokens); i++ {
+		token := tokens[i]
+		switch {
+		case token == "}":
+			if len(path) > 0 {
+				fn(path, "}", "")
+				path = path[:len(path)-1]
+			}
+		case i+1 < len(tokens) && tokens[i+1] == "{": // e.g. "definition {" or "definition: {"
+			path = append(path, strings.TrimSuffix(token, ":"))
+			fn(path, "{", "")
+			i++
+		case strings.HasSuffix(token, ":") && i+1 < len(tokens):
+			fn(path, strings.TrimSuffix(token, ":"), strings.Trim(tokens[i+1], `"`))
+			i++
+		}
+	}
+}
+
+func lastOf(path []string) string {
+	if len(path) == 0 {
+		return ""
+	}
+	return path[len(path)-1]
+}
+
+func regionUnhealthy(region Region) string {
+	if len(region.StoreIds) == 0 {
+		return REGION_UNHEALTHY_NO_PEER
+	} else if len(region.LeaderStoreId) == 0 || region.LeaderStoreId == "0" {
+		return REGION_UNHEALTHY_NO_LEADER
+	} else if !utils.Contains(region.StoreIds, region.LeaderStoreId) {
+		return REGION_UNHEALTHY_LEADER_NOT_PEER
+	}
+	return ""
+}
+
+// ParseRegionMap parses the regions in 'dingodb_cli GetRegionMap'
+func ParseRegionMap(out string) []Region {
+	regions := []Region{}
+	var region *Region
+	depth := 0 // depth of block 'regions'
+	walkTextFormat(out, func(path []string, key, value string) {
+		switch {
+		case region == nil && key == "{" && lastOf(path) == "regions":
+			region, depth = &Region{StoreIds: []string{}}, len(path)
+		case region == nil:
+		case key == "}" && len(path) == depth:
+			region.Unhealthy = regionUnhealthy(*region)
+			regions = append(regions, *region)
+			region = nil
+		case key == "id" && len(region.Id) == 0:
+			region.Id = value
+		case key == "name" && len(region.Name) == 0:
+			region.Name = value
+		case key == "state" && len(path) == depth:
+			region.State = value
+		case key == "leader_store_id":
+			region.LeaderStoreId = value
+		case key == "store_id" && !utils.Contains(region.StoreIds, value):
+			region.StoreIds = append(region.StoreIds, value)
+		}
+	})
+	return regions
+}
+
+/*
+ * ParseStoreMap parses the stores in 'dingodb_cli GetStoreMap', which prints
+ * one line for each store:
+ *
+ *   store_id=1001 type=NODE_TYPE_STORE addr={host: "10.0.0.1" port: 20001} state=STORE_NORMAL in_state=STORE_IN
+ *
+ * the stores block in protobuf text format is also supported.
+ */
+func ParseStoreMap(out string) []Store {
+	stores := []Store{}
+	for _, line := range strings.Split(out, "\n") {
+		if !strings.Contains(line, "store_id=") {
+			continue
+		}
+		store := Store{}
+		for _, mu := range REGEX_KEY_VALUE.FindAllStringSubmatch(line, -1) {
+			switch mu[1] {
+			case "store_id":
+				store.Id = mu[2]
+			case "type":
+				store.Type = mu[2]
+			case "state":
+				store.State = mu[2]
+			case "in_state":
+				store.InState = mu[2]
+			}
+		}
+		if mu := REGEX_STORE_ADDR.FindStringSubmatch(line); len(mu) > 0 {
+			store.Address = fmt.Sprintf("%s:%s", mu[1], mu[2])
+		}
+		stores = append(stores, store)
+	}
+	if len(stores) > 0 {
+		return stores
+	}
+
+	var store *Store
+	depth := 0 // depth of block 'stores'
+	host := ""
+	walkTextFormat(out, func(path []string, key, value string) {
+		switch {
+		case store == nil && key == "{" && lastOf(path) == "stores":
+			store, depth = &Store{}, len(path)
+		case store == nil:
+		case key == "}" && len(path) == depth:
+			stores = append(stores, *store)
+			store = nil
+		case len(path) == depth && key == "id":
+			store.Id = value
+		case len(path) == depth && (key == "store_type" || key == "type"):
+			store.Type = value
+		case len(path) == depth && key == "state":
+			store.State = value
+		case len(path) == depth && key == "in_state":
+			store.InState = value
+		case lastOf(path) == "server_location" && key == "host":
+			host = value
+		case lastOf(path) == "server_location" && key == "port":
+			store.Address = fmt.Sprintf("%s:%s", host, value)
+		}
+	})
+	return stores
+}
+
+// CountStoreRegions fills the region and leader count of each store
+func CountStoreRegions(stores []Store, regions []Region) {
+	for i := range stores {
+		for _, region := range regions {
+			if utils.Contains(region.StoreIds, stores[i].Id) {
+				stores[i].Regions++
+			}
+			if region.LeaderStoreId == stores[i].Id {
+				stores[i].Leaders++
+			}
+		}
+	}
+}
+
+/*
+ * ParseRaftNodes parses the raft nodes in braft /raft_stat:
+ *
+ * [80001]
+ * peer_id: 10.0.0.1:20101:0
+ * state: LEADER
+ * term: 2
+ * peers: 10.0.0.1:20101:0 10.0.0.2:20101:0 10.0.0.3:20101:0
+ */
+func ParseRaftNodes(out string) []RaftNode {
+	nodes := []RaftNode{}
+	var node *RaftNode
+	for _, line := range strings.Split(out, "\n") {
+		line = strings.TrimSpace(line)
+		if strings.HasPrefix(line, "[") && strings.HasSuffix(line, "]") {
+			if node != nil {
+				nodes = append(nodes, *node)
+			}
+			node = &RaftNode{Group: strings.Trim(line, "[]"), Peers: []string{}}
+			continue
+		} else if node == nil {
+			continue
+		}
+
+		items := strings.SplitN(line, ":", 2)
+		if len(items) != 2 {
+			continue
+		}
+		value := strings.TrimSpace(items[1])
+		switch strings.TrimSpace(items[0]) {
+		case "peer_id":
+			node.PeerId = value
+		case "state":
+			node.State = value
+		case "term":
+			node.Term = value
+		case "peers":
+			node.Peers = strings.Fields(value)
+		}
+	}
+	if node != nil {
+		nodes = append(nodes, *node)
+	}
+	return nodes
+}
+
+func setDingodbCliOutput(memStorage *utils.SafeMap, command, out string) {
+	memStorage.TX(func(kv *utils.SafeMap) error {
+		m := map[string]string{}
+		v := kv.Get(comm.KEY_DINGODB_CLI_OUTPUTS)
+		if v != nil {
+			m = v.(map[string]string)
+		}
+		m[command] = out
+		kv.Set(comm.KEY_DINGODB_CLI_OUTPUTS, m)
+		return nil
+	})
+}
+
+func setRaftStat(memStorage *utils.SafeMap, id, out string) {
+	memStorage.TX(func(kv *utils.SafeMap) error {
+		m := map[string]string{}
+		v := kv.Get(comm.KEY_ALL_RAFT_STATS)
+		if v != nil {
+			m = v.(map[string]string)
+		}
+		m[id] = out
+		kv.Set(comm.KEY_ALL_RAFT_STATS, m)
+		return nil
+	})
+}
+
+func (s *step2RunDingodbCli) Execute(ctx *context.Context) error {
+	binDir := s.dc.GetProjectLayout().DingoStoreBinDir
+	for _, command := range s.commands {
+		var out string
+		err := (&step.ContainerExec{
+			ContainerId: &s.containerId,
+			Command:     fmt.Sprintf(COMMAND_DINGODB_CLI, binDir, command),
+			Out:         &out,
+			ExecOptions: s.execOptions,
+		}).Execute(ctx)
+		if err != nil {
+			return err
+		}
+		setDingodbCliOutput(s.memStorage, command, out)
+	}
+	return nil
+}
+
+func (s *step2GetRaftStat) Execute(ctx *context.Context) error {
+	var out string
+	err := (&step.Curl{
+		Url:         fmt.Sprintf(URL_BRPC_RAFT_STAT, s.dc.GetListenIp(), s.dc.GetDingoStoreRaftPort()),
+		Silent:      true,
+		Out:         &out,
+		ExecOptions: s.execOptions,
+	}).Execute(ctx)
+	if err != nil {
+		return err
+	}
+	setRaftStat(s.memStorage, s.serviceId, out)
+	return nil
+}
+
+// execute dingodb_cli commands in coordinator or store container, the outputs are keyed by command
+func NewRunDingodbCliTask(dingoadm *cli.DingoAdm, dc *topology.DeployConfig) (*task.Task, error) {
+	serviceId := dingoadm.GetServiceId(dc.GetId())
+	containerId, err := dingoadm.GetContainerId(serviceId)
+	if dingoadm.IsSkip(dc) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	hc, err := dingoadm.GetHost(dc.GetHost())
+	if err != nil {
+		return nil, err
+	}
+
+	// new task
+	commands := dingoadm.MemStorage().Get(comm.KEY_DINGODB_CLI_COMMANDS).([]string)
+	subname := fmt.Sprintf("host=%s role=%s containerId=%s commands=%s",
+		dc.GetHost(), dc.GetRole(), tui.TrimContainerId(containerId), strings.Join(commands, ","))
+	t := task.NewTask("Run dingodb_cli", subname, hc.GetSSHConfig())
+
+	// add step to task
+	var out string
+	t.AddStep(&step.ListContainers{
+		ShowAll:     true,
+		Format:      `"{{.ID}}"`,
+		Filter:      fmt.Sprintf("id=%s", containerId),
+		Out:         &out,
+		ExecOptions: dingoadm.ExecOptions(),
+	})
+	t.AddStep(&step.Lambda{
+		Lambda: CheckContainerExist(dc.GetHost(), dc.GetRole(), containerId, &out),
+	})
+	t.AddStep(&step2RunDingodbCli{
+		dc:          dc,
+		containerId: containerId,
+		commands:    commands,
+		memStorage:  dingoadm.MemStorage(),
+		execOptions: dingoadm.ExecOptions(),
+	})
+
+	return t, nil
+}
+
+func NewGetRaftStatTask(dingoadm *cli.DingoAdm, dc *topology.DeployConfig) (*task.Task, error) {
+	serviceId := dingoadm.GetServiceId(dc.GetId())
+	if dingoadm.IsSkip(dc) {
+		return nil, nil
+	}
+	hc, err := dingoadm.GetHost(dc.GetHost())
+	if err != nil {
+		return nil, err
+	}
+
+	// new task
+	subname := fmt.Sprintf("host=%s role=%s raftPort=%d",
+		dc.GetHost(), dc.GetRole(), dc.GetDingoStoreRaftPort())
+	t := task.NewTask("Get Raft Stat", subname, hc.GetSSHConfig())
+
+	// add step to task
+	t.AddStep(&step2GetRaftStat{
+		dc:          dc,
+		serviceId:   serviceId,
+		memStorage:  dingoadm.MemStorage(),
+		execOptions: dingoadm.ExecOptions(),
+	})
+
+	return t, nil
+}
@@ -0,0 +1,111 @@
+package common
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseRegionMap(t *testing.T) {
+	assert := assert.New(t)
+	out := `
+region_map {
+  epoch: 3
+  regions {
+    id: 80001
+    state: REGION_NORMAL
+    leader_store_id: 1001
+    definition {
+      id: 80001
+      name: "T_1_t_user_part_0"
+      peers {
+        store_id: 1001
+        raft_location { host: "10.0.0.1" port: 20101 }
+      }
+      peers {
+        store_id: 1002
+      }
+    }
+  }
+  regions {
+    id: 80002
+    state: REGION_NEW
+    leader_store_id: 0
+    definition {
+      name: "T_1_t_order_part_0"
+      peers {
+        store_id: 1002
+      }
+    }
+  }
+}
+`
+	regions := ParseRegionMap(out)
+	assert.Equal([]Region{
+		{Id: "80001", Name: "T_1_t_user_part_0", State: "REGION_NORMAL",
+			LeaderStoreId: "1001", StoreIds: []string{"1001", "1002"}},
+		{Id: "80002", Name: "T_1_t_order_part_0", State: "REGION_NEW",
+			LeaderStoreId: "0", StoreIds: []string{"1002"}, Unhealthy: REGION_UNHEALTHY_NO_LEADER},
+	}, regions)
+}
+
+func TestParseStoreMap(t *testing.T) {
+	assert := assert.New(t)
+
+	// one line for each store
+	out := `store_id=1001 type=NODE_TYPE_STORE addr={host: "10.0.0.1" port: 20001} state=STORE_NORMAL in_state=STORE_IN
+store_id=1002 type=NODE_TYPE_STORE addr={host: "10.0.0.2" port: 20001} state=STORE_OFFLINE in_state=STORE_IN
+DINGODB_HAVE_STORE_AVAILABLE, store_count=1`
+	stores := ParseStoreMap(out)
+	assert.Equal([]Store{
+		{Id: "1001", Type: "NODE_TYPE_STORE", State: "STORE_NORMAL", InState: "STORE_IN", Address: "10.0.0.1:20001"},
+		{Id: "1002", Type: "NODE_TYPE_STORE", State: "STORE_OFFLINE", InState: "STORE_IN", Address: "10.0.0.2:20001"},
+	}, stores)
+
+	// protobuf text format
+	out = `
+storemap {
+  stores {
+    id: 1001
+    state: STORE_NORMAL
+    server_location {
+      host: "10.0.0.1"
+      port: 20001
+    }
+    store_type: NODE_TYPE_STORE
+    in_state: STORE_IN
+  }
+}`
+	stores = ParseStoreMap(out)
+	assert.Equal([]Store{
+		{Id: "1001", Type: "NODE_TYPE_STORE", State: "STORE_NORMAL", InState: "STORE_IN", Address: "10.0.0.1:20001"},
+	}, stores)
+
+	CountStoreRegions(stores, []Region{
+		{LeaderStoreId: "1001", StoreIds: []string{"1001", "1002"}},
+		{LeaderStoreId: "1002", StoreIds: []string{"1001", "1002"}},
+	})
+	assert.Equal(2, stores[0].Regions)
+	assert.Equal(1, stores[0].Leaders)
+}
+
+func TestParseRaftNodes(t *testing.T) {
+	assert := assert.New(t)
+	out := `[80001]
+peer_id: 10.0.0.1:20101:0
+state: LEADER
+term: 2
+peers: 10.0.0.1:20101:0 10.0.0.2:20101:0 10.0.0.3:20101:0
+
+[80002]
+peer_id: 10.0.0.1:20101:0
+state: CANDIDATE
+term: 5
+peers: 10.0.0.1:20101:0`
+	assert.Equal([]RaftNode{
+		{Group: "80001", PeerId: "10.0.0.1:20101:0", State: "LEADER", Term: "2",
+			Peers: []string{"10.0.0.1:20101:0", "10.0.0.2:20101:0", "10.0.0.3:20101:0"}},
+		{Group: "80002", PeerId: "10.0.0.1:20101:0", State: "CANDIDATE", Term: "5",
+			Peers: []string{"10.0.0.1:20101:0"}},
+	}, ParseRaftNodes(out))
+}
@@ -0,0 +1,140 @@
+/*
+ *  Copyright (c) 2025 dingodb.com Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: dingoadm
+ * Author: dongwei (jackblack369)
+ */
+
+package common
+
+import (
+	"fmt"
+
+	"github.com/dingodb/dingoadm/cli/cli"
+	comm "github.com/dingodb/dingoadm/internal/common"
+	"github.com/dingodb/dingoadm/internal/configure/topology"
+	"github.com/dingodb/dingoadm/internal/errno"
+	"github.com/dingodb/dingoadm/internal/task/scripts"
+	"github.com/dingodb/dingoadm/internal/task/step"
+	"github.com/dingodb/dingoadm/internal/task/task"
+	tui "github.com/dingodb/dingoadm/internal/tui/common"
+)
+
+// find the mds client container which created by deploy
+func getMdsv2ClientContainer(dingoadm *cli.DingoAdm) (*topology.DeployConfig, string) {
+	v := dingoadm.MemStorage().Get(comm.KEY_ALL_DEPLOY_CONFIGS)
+	if v == nil {
+		return nil, ""
+	}
+	for _, dc := range dingoadm.FilterDeployConfigByRole(v.([]*topology.DeployConfig), topology.ROLE_FS_MDS_CLI) {
+		containerId, err := dingoadm.GetContainerId(dingoadm.GetServiceId(dc.GetId()))
+		if err == nil && len(containerId) > 0 && containerId != comm.CLEANED_CONTAINER_ID {
+			return dc, containerId
+		}
+	}
+	return nil, ""
+}
+
+/*
+ * drain service before it removed from cluster:
+ *   store:      mark store out and wait until its region count is zero,
+ *               document and index are drained in the same way
+ *   mds v2:     remove mds from mds group
+ *   metaserver: mark metaserver offline and wait until all copysets migrated
+ *               to other metaservers
+ *
+ * other roles are stateless (or hold no data), nothing to drain.
+ */
+func NewDrainServiceTask(dingoadm *cli.DingoAdm, dc *topology.DeployConfig) (*task.Task, error) {
+	serviceId := dingoadm.GetServiceId(dc.GetId())
+	containerId, err := dingoadm.GetContainerId(serviceId)
+	if dingoadm.IsSkip(dc) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	role := dc.GetRole()
+	host := dc.GetHost()
+	layout := dc.GetProjectLayout()
+	var script, scriptPath, command string
+	switch {
+	case role == topology.ROLE_STORE ||
+		role == topology.ROLE_DINGODB_DOCUMENT ||
+		role == topology.ROLE_DINGODB_INDEX:
+		script = scripts.DRAIN_STORE
+		scriptPath = fmt.Sprintf("%s/%s", layout.DingoStoreScriptDir, topology.SCRIPT_DRAIN_STORE)
+		command = fmt.Sprintf("bash %s --instance_id=%d", scriptPath, dc.GetDingoInstanceId())
+	case role == topology.ROLE_FS_MDS &&
+		dc.GetCtx().Lookup(topology.CTX_KEY_MDS_VERSION) == topology.CTX_VAL_MDS_V2:
+		// execute through mds client, the mds itself will be stopped
+		mdsCli, id := getMdsv2ClientContainer(dingoadm)
+		if mdsCli == nil {
+			return nil, errno.ERR_NO_MDSV2_CLIENT_FOR_SCALE_IN
+		}
+		containerId = id
+		host = mdsCli.GetHost()
+		layout = mdsCli.GetProjectLayout()
+		script = scripts.DRAIN_MDSV2
+		scriptPath = fmt.Sprintf("%s/%s", layout.FSMdsCliBinDir, topology.SCRIPT_DRAIN_MDSV2)
+		command = fmt.Sprintf("bash %s %s %d", scriptPath, layout.FSMdsCliBinaryPath, dc.GetDingoInstanceId())
+	case role == topology.ROLE_METASERVER:
+		script = scripts.DRAIN_METASERVER
+		scriptPath = fmt.Sprintf("%s/%s", layout.ServiceBinDir, topology.SCRIPT_DRAIN_METASERVER)
+		command = fmt.Sprintf("bash %s %s %s:%d", scriptPath, layout.FSToolsBinaryPath,
+			dc.GetListenIp(), dc.GetListenPort())
+	default:
+		return nil, nil
+	}
+
+	hc, err := dingoadm.GetHost(host)
+	if err != nil {
+		return nil, err
+	}
+
+	// new task
+	subname := fmt.Sprintf("host=%s role=%s containerId=%s",
+		dc.GetHost(), dc.GetRole(), tui.TrimContainerId(containerId))
+	t := task.NewTask("Drain Service", subname, hc.GetSSHConfig())
+
+	// add step to task
+	var out string
+	t.AddStep(&step.ListContainers{
+		ShowAll:     true,
+		Format:      `"{{.ID}}"`,
+		Filter:      fmt.Sprintf("id=%s", containerId),
+		Out:         &out,
+		ExecOptions: dingoadm.ExecOptions(),
+	})
+	t.AddStep(&step.Lambda{
+		Lambda: CheckContainerExist(host, dc.GetRole(), containerId, &out),
+	})
+	t.AddStep(&step.InstallFile{
+		ContainerId:       &containerId,
+		ContainerDestPath: scriptPath,
+		Content:           &script,
+		ExecOptions:       dingoadm.ExecOptions(),
+	})
+	t.AddStep(&step.ContainerExec{
+		ContainerId: &containerId,
+		Command:     command,
+		Out:         &out,
+		ExecOptions: dingoadm.ExecOptions(),
+	})
+
+	return t, nil
+}
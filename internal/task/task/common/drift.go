@@ -0,0 +1,320 @@
+/*
+ *  Copyright (c) 2025 dingodb.com Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: dingoadm
+ * Author: dongwei (jackblack369)
+ */
+
+package common
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/dingodb/dingoadm/cli/cli"
+	comm "github.com/dingodb/dingoadm/internal/common"
+	"github.com/dingodb/dingoadm/internal/configure/topology"
+	"github.com/dingodb/dingoadm/internal/errno"
+	"github.com/dingodb/dingoadm/internal/task/context"
+	"github.com/dingodb/dingoadm/internal/task/step"
+	"github.com/dingodb/dingoadm/internal/task/task"
+	tui "github.com/dingodb/dingoadm/internal/tui/common"
+	"github.com/dingodb/dingoadm/internal/utils"
+	"github.com/dingodb/dingoadm/pkg/module"
+)
+
+const (
+	DRIFT_ITEM_IMAGE  = "image"
+	DRIFT_ITEM_ENV    = "env"
+	DRIFT_ITEM_MOUNT  = "mount"
+	DRIFT_ITEM_CONFIG = "config"
+
+	DRIFT_VALUE_ABSENT = "-"
+)
+
+type (
+	Drift struct {
+		Id       string `json:"id" yaml:"id"`
+		Role     string `json:"role" yaml:"role"`
+		Host     string `json:"host" yaml:"host"`
+		Item     string `json:"item" yaml:"item"`
+		Key      string `json:"key" yaml:"key"`
+		Expected string `json:"expected" yaml:"expected"`
+		Actual   string `json:"actual" yaml:"actual"`
+	}
+
+	// part of `docker inspect` output
+	ContainerInspect struct {
+		Config struct {
+			Image string   `json:"Image"`
+			Env   []string `json:"Env"`
+		} `json:"Config"`
+		Mounts []struct {
+			Source      string `json:"Source"`
+			Destination string `json:"Destination"`
+		} `json:"Mounts"`
+	}
+
+	step2CheckDrift struct {
+		dc          *topology.DeployConfig
+		serviceId   string
+		containerId string
+		memStorage  *utils.SafeMap
+		execOptions module.ExecOptions
+	}
+)
+
+// compare values of expected keys, the order of expected keys is kept
+func diffKV(keys []string, expected, actual map[string]string) []Drift {
+	drifts := []Drift{}
+	for _, key := range keys {
+		value, ok := actual[key]
+		if !ok {
+			value = DRIFT_VALUE_ABSENT
+		}
+		if value != expected[key] {
+			drifts = append(drifts, Drift{Key: key, Expected: expected[key], Actual: value})
+		}
+	}
+	return drifts
+}
+
+func splitConfig(content, delimiter string) ([]string, map[string]string) {
+	keys := []string{}
+	kv := map[string]string{}
+	for _, line := range strings.Split(content, "\n") {
+		line = strings.TrimSpace(line)
+		if len(line) == 0 || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		key, value := line, ""
+		if i := strings.Index(line, strings.TrimSpace(delimiter)); i > 0 {
+			key, value = strings.TrimSpace(line[:i]), strings.TrimSpace(line[i+len(strings.TrimSpace(delimiter)):])
+		}
+		if _, ok := kv[key]; !ok {
+			keys = append(keys, key)
+		}
+		kv[key] = value
+	}
+	return keys, kv
+}
+
+// DiffConfig compares rendered config with the actual one, comment and blank lines are ignored
+func DiffConfig(expected, actual, delimiter string) []Drift {
+	expectedKeys, expectedKV := splitConfig(expected, delimiter)
+	actualKeys, actualKV := splitConfig(actual, delimiter)
+	drifts := diffKV(expectedKeys, expectedKV, actualKV)
+	for _, key := range actualKeys { // the key which added by hand
+		if _, ok := expectedKV[key]; !ok {
+			drifts = append(drifts, Drift{Key: key, Expected: DRIFT_VALUE_ABSENT, Actual: actualKV[key]})
+		}
+	}
+	return drifts
+}
+
+// DiffEnvs compares expected envs with container envs, the extra envs (e.g. PATH from image) are ignored
+func DiffEnvs(expected, actual []string) []Drift {
+	split := func(envs []string) ([]string, map[string]string) {
+		keys := []string{}
+		kv := map[string]string{}
+		for _, env := range envs {
+			items := strings.SplitN(env, "=", 2)
+			if len(items) != 2 {
+				continue
+			}
+			if _, ok := kv[items[0]]; !ok {
+				keys = append(keys, items[0])
+			}
+			kv[items[0]] = items[1]
+		}
+		return keys, kv
+	}
+	keys, expectedKV := split(expected)
+	_, actualKV := split(actual)
+	return diffKV(keys, expectedKV, actualKV)
+}
+
+// DiffMounts compares expected bind mounts with container mounts, keyed by container path
+func DiffMounts(expected []step.Volume, inspect ContainerInspect) []Drift {
+	keys := []string{}
+	expectedKV := map[string]string{}
+	for _, volume := range expected {
+		keys = append(keys, volume.ContainerPath)
+		expectedKV[volume.ContainerPath] = volume.HostPath
+	}
+	actualKV := map[string]string{}
+	for _, mount := range inspect.Mounts {
+		actualKV[mount.Destination] = mount.Source
+	}
+	return diffKV(keys, expectedKV, actualKV)
+}
+
+func addDrifts(memStorage *utils.SafeMap, id string, drifts []Drift) {
+	memStorage.TX(func(kv *utils.SafeMap) error {
+		m := map[string][]Drift{}
+		v := kv.Get(comm.KEY_ALL_DRIFTS)
+		if v != nil {
+			m = v.(map[string][]Drift)
+		}
+		m[id] = drifts
+		kv.Set(comm.KEY_ALL_DRIFTS, m)
+		return nil
+	})
+}
+
+func (s *step2CheckDrift) inspect(ctx *context.Context) (ContainerInspect, error) {
+	var out string
+	var inspect ContainerInspect
+	err := (&step.InspectContainer{
+		ContainerId: s.containerId,
+		Format:      "'{{json .}}'",
+		Out:         &out,
+		ExecOptions: s.execOptions,
+	}).Execute(ctx)
+	if err != nil {
+		return inspect, err
+	}
+
+	err = json.Unmarshal([]byte(out), &inspect)
+	if err != nil {
+		return inspect, errno.ERR_INSPECT_CONTAINER_FAILED.E(err)
+	}
+	return inspect, nil
+}
+
+// render config by the same way as SYNC_CONFIG, then read the actual one
+func (s *step2CheckDrift) diffConfig(ctx *context.Context, conf topology.ConfFile) ([]Drift, error) {
+	var input, expected, actual string
+	delimiter := GetConfigDelimiter(s.dc)
+	steps := []task.Step{
+		&step.ReadFile{
+			ContainerId:      s.containerId,
+			ContainerSrcPath: conf.SourcePath,
+			Content:          &input,
+			ExecOptions:      s.execOptions,
+		},
+		&step.Filter{
+			KVFieldSplit:  delimiter,
+			Mutate:        NewMutate(s.dc, delimiter, conf.Name == "nginx.conf"),
+			SerivceConfig: s.dc.GetServiceConfig(),
+			Input:         &input,
+			Output:        &expected,
+		},
+		&step.ReadFile{
+			ContainerId:      s.containerId,
+			ContainerSrcPath: conf.TargetPath,
+			Content:          &actual,
+			ExecOptions:      s.execOptions,
+		},
+	}
+	for _, step := range steps {
+		if err := step.Execute(ctx); err != nil {
+			return nil, err
+		}
+	}
+
+	drifts := DiffConfig(expected, actual, delimiter)
+	for i := range drifts {
+		drifts[i].Key = fmt.Sprintf("%s:%s", conf.Name, drifts[i].Key)
+	}
+	return drifts, nil
+}
+
+func (s *step2CheckDrift) Execute(ctx *context.Context) error {
+	dc := s.dc
+	inspect, err := s.inspect(ctx)
+	if err != nil {
+		return err
+	}
+
+	// 1) container create options
+	drifts := []Drift{}
+	if inspect.Config.Image != dc.GetContainerImage() {
+		drifts = append(drifts, Drift{
+			Item:     DRIFT_ITEM_IMAGE,
+			Key:      DRIFT_ITEM_IMAGE,
+			Expected: dc.GetContainerImage(),
+			Actual:   inspect.Config.Image,
+		})
+	}
+	for _, drift := range DiffEnvs(GetEnvironments(dc), inspect.Config.Env) {
+		drift.Item = DRIFT_ITEM_ENV
+		drifts = append(drifts, drift)
+	}
+	for _, drift := range DiffMounts(getMountVolumes(dc), inspect) {
+		drift.Item = DRIFT_ITEM_MOUNT
+		drifts = append(drifts, drift)
+	}
+
+	// 2) config files
+	kind := dc.GetKind()
+	if kind == topology.KIND_DINGOFS || kind == topology.KIND_DINGODB || kind == topology.KIND_DINGOSTORE {
+		for _, conf := range dc.GetProjectLayout().ServiceConfFiles {
+			items, err := s.diffConfig(ctx, conf)
+			if err != nil {
+				return err
+			}
+			for _, drift := range items {
+				drift.Item = DRIFT_ITEM_CONFIG
+				drifts = append(drifts, drift)
+			}
+		}
+	}
+
+	for i := range drifts {
+		drifts[i].Id = s.serviceId
+		drifts[i].Role = dc.GetRole()
+		drifts[i].Host = dc.GetHost()
+	}
+	addDrifts(s.memStorage, s.serviceId, drifts)
+	return nil
+}
+
+// compare config files, image, envs and mounts of container with what topology would render
+func NewCheckDriftTask(dingoadm *cli.DingoAdm, dc *topology.DeployConfig) (*task.Task, error) {
+	serviceId := dingoadm.GetServiceId(dc.GetId())
+	containerId, err := dingoadm.GetContainerId(serviceId)
+	if dingoadm.IsSkip(dc) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	} else if containerId == comm.CLEANED_CONTAINER_ID {
+		return nil, nil
+	}
+	hc, err := dingoadm.GetHost(dc.GetHost())
+	if err != nil {
+		return nil, err
+	}
+
+	// new task
+	subname := fmt.Sprintf("host=%s role=%s containerId=%s",
+		dc.GetHost(), dc.GetRole(), tui.TrimContainerId(containerId))
+	t := task.NewTask("Check Drift", subname, hc.GetSSHConfig())
+
+	// add step to task
+	t.AddStep(&step2CheckDrift{
+		dc:          dc,
+		serviceId:   serviceId,
+		containerId: containerId,
+		memStorage:  dingoadm.MemStorage(),
+		execOptions: dingoadm.ExecOptions(),
+	})
+
+	return t, nil
+}
@@ -0,0 +1,58 @@
+package common
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/dingodb/dingoadm/internal/task/step"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiffConfig(t *testing.T) {
+	assert := assert.New(t)
+	expected := `
+# server
+-server_listen_host=10.0.0.1
+-server_port=20001
+-raft_sync=true
+`
+	actual := `
+# server
+-server_listen_host=10.0.0.1
+-server_port = 20002
+-log_level=DEBUG
+`
+	drifts := DiffConfig(expected, actual, CONFIG_DELIMITER_ASSIGN)
+	assert.Equal([]Drift{
+		{Key: "-server_port", Expected: "20001", Actual: "20002"},
+		{Key: "-raft_sync", Expected: "true", Actual: DRIFT_VALUE_ABSENT},
+		{Key: "-log_level", Expected: DRIFT_VALUE_ABSENT, Actual: "DEBUG"},
+	}, drifts)
+
+	drifts = DiffConfig("name: etcd\ndata-dir: /data", "name: etcd\ndata-dir: /data\n", CONFIG_DELIMITER_COLON)
+	assert.Len(drifts, 0)
+}
+
+func TestDiffEnvs(t *testing.T) {
+	assert := assert.New(t)
+	expected := []string{"FLAGS_role=store", "COORDINATOR_ADDR=10.0.0.1:22001", "FLAGS_role=store"}
+	actual := []string{"PATH=/usr/bin", "FLAGS_role=store", "COORDINATOR_ADDR=10.0.0.2:22001"}
+	assert.Equal([]Drift{
+		{Key: "COORDINATOR_ADDR", Expected: "10.0.0.1:22001", Actual: "10.0.0.2:22001"},
+	}, DiffEnvs(expected, actual))
+}
+
+func TestDiffMounts(t *testing.T) {
+	assert := assert.New(t)
+	inspect := ContainerInspect{}
+	err := json.Unmarshal([]byte(`{"Mounts":[{"Source":"/data/logs","Destination":"/opt/dingo/log"}]}`), &inspect)
+	assert.Nil(err)
+
+	drifts := DiffMounts([]step.Volume{
+		{HostPath: "/data/logs", ContainerPath: "/opt/dingo/log"},
+		{HostPath: "/data/raft", ContainerPath: "/opt/dingo/raft"},
+	}, inspect)
+	assert.Equal([]Drift{
+		{Key: "/opt/dingo/raft", Expected: "/data/raft", Actual: DRIFT_VALUE_ABSENT},
+	}, drifts)
+}
@@ -0,0 +1,230 @@
+/*
+ *  Copyright (c) 2025 dingodb.com Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: dingoadm
+ * Author: dongwei (jackblack369)
+ */
+
+package common
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/dingodb/dingoadm/cli/cli"
+	comm "github.com/dingodb/dingoadm/internal/common"
+	"github.com/dingodb/dingoadm/internal/configure/topology"
+	"github.com/dingodb/dingoadm/internal/task/context"
+	"github.com/dingodb/dingoadm/internal/task/step"
+	"github.com/dingodb/dingoadm/internal/task/task"
+	"github.com/dingodb/dingoadm/internal/utils"
+	"github.com/dingodb/dingoadm/pkg/module"
+)
+
+const (
+	URL_BRPC_FLAGS      = "--connect-timeout 1 --max-time 3 'http://%s:%d/flags/%s?console=1'"
+	FLAG_VALUE_MISSING  = "-"
+	FLAG_DEFAULT_PREFIX = "(default:"
+	FLAG_RELOADABLE     = "(R)"
+)
+
+type (
+	// runtime flag from brpc /flags
+	Flag struct {
+		Name    string
+		Value   string
+		Default string
+	}
+
+	FlagValue struct {
+		Id         string `json:"id" yaml:"id"`
+		Role       string `json:"role" yaml:"role"`
+		Host       string `json:"host" yaml:"host"`
+		Name       string `json:"name" yaml:"name"`
+		Value      string `json:"value" yaml:"value"`
+		Configured string `json:"configured" yaml:"configured"`
+		Differ     bool   `json:"differ" yaml:"differ"`
+	}
+
+	step2GetFlags struct {
+		dc          *topology.DeployConfig
+		serviceId   string
+		names       []string
+		memStorage  *utils.SafeMap
+		execOptions module.ExecOptions
+	}
+)
+
+// GetConfiguredFlags returns flags configured in service config, keyed by
+// gflag name, see GetRuntimeFlagName for the config items which are gflags.
+// The value is rendered by variables as it synced into config file.
+func GetConfiguredFlags(dc *topology.DeployConfig) (map[string]string, error) {
+	flags := map[string]string{}
+	for key, value := range dc.GetServiceConfig() {
+		name := GetRuntimeFlagName(dc, key)
+		if len(name) == 0 {
+			continue
+		}
+		value, err := dc.GetVariables().Rendering(value)
+		if err != nil {
+			return nil, err
+		}
+		flags[name] = value
+	}
+	return flags, nil
+}
+
+/*
+ * ParseFlags parses output of brpc /flags in console mode:
+ *
+ * Name | Value | Description | Defined At
+ * ---------------------------------------
+ * raft_sync | false (default:true) | call fsync when need | braft/src/braft/raft.cpp
+ * max_body_size (R) | 67108864 | Maximum size of a single message body | brpc/src/brpc/protocol.cpp
+ */
+func ParseFlags(out string) map[string]Flag {
+	flags := map[string]Flag{}
+	for _, line := range strings.Split(out, "\n") {
+		fields := strings.Split(line, " | ")
+		if len(fields) < 2 || fields[0] == "Name" {
+			continue
+		}
+
+		name := strings.TrimSpace(strings.TrimSuffix(strings.TrimSpace(fields[0]), FLAG_RELOADABLE))
+		value := strings.TrimSpace(fields[1])
+		flag := Flag{Name: name, Value: value, Default: value}
+		if i := strings.LastIndex(value, FLAG_DEFAULT_PREFIX); i >= 0 && strings.HasSuffix(value, ")") {
+			flag.Value = strings.TrimSpace(value[:i])
+			flag.Default = value[i+len(FLAG_DEFAULT_PREFIX) : len(value)-1]
+		}
+		flags[name] = flag
+	}
+	return flags
+}
+
+// FlagDiffers returns true if runtime value differs from configured, e.g. "1" equals "1.0", "True" equals "true"
+func FlagDiffers(runtime, configured string) bool {
+	if len(configured) == 0 {
+		return false
+	} else if runtime == FLAG_VALUE_MISSING {
+		return true
+	}
+
+	runtime, configured = strings.TrimSpace(runtime), strings.TrimSpace(configured)
+	if strings.EqualFold(runtime, configured) {
+		return false
+	}
+	v1, err1 := strconv.ParseFloat(runtime, 64)
+	v2, err2 := strconv.ParseFloat(configured, 64)
+	return err1 != nil || err2 != nil || v1 != v2
+}
+
+func addFlagValues(memStorage *utils.SafeMap, id string, values []FlagValue) {
+	memStorage.TX(func(kv *utils.SafeMap) error {
+		m := map[string][]FlagValue{}
+		v := kv.Get(comm.KEY_ALL_FLAGS)
+		if v != nil {
+			m = v.(map[string][]FlagValue)
+		}
+		m[id] = values
+		kv.Set(comm.KEY_ALL_FLAGS, m)
+		return nil
+	})
+}
+
+func (s *step2GetFlags) Execute(ctx *context.Context) error {
+	dc := s.dc
+	configured, err := GetConfiguredFlags(dc)
+	if err != nil {
+		return err
+	}
+	names := s.names
+	if len(names) == 0 { // all configured flags
+		for name := range configured {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+	}
+	if len(names) == 0 {
+		addFlagValues(s.memStorage, s.serviceId, []FlagValue{})
+		return nil
+	}
+
+	var out string
+	err = (&step.Curl{
+		Url:         fmt.Sprintf(URL_BRPC_FLAGS, dc.GetListenIp(), getBrpcPort(dc), strings.Join(names, ",")),
+		Silent:      true,
+		Out:         &out,
+		ExecOptions: s.execOptions,
+	}).Execute(ctx)
+	if err != nil {
+		return err
+	}
+
+	flags := ParseFlags(out)
+	values := []FlagValue{}
+	for _, name := range names {
+		value := FLAG_VALUE_MISSING
+		if flag, ok := flags[name]; ok {
+			value = flag.Value
+		}
+		values = append(values, FlagValue{
+			Id:         s.serviceId,
+			Role:       dc.GetRole(),
+			Host:       dc.GetHost(),
+			Name:       name,
+			Value:      value,
+			Configured: configured[name],
+			Differ:     FlagDiffers(value, configured[name]),
+		})
+	}
+	addFlagValues(s.memStorage, s.serviceId, values)
+	return nil
+}
+
+// query runtime flags from brpc builtin service, all configured flags are queried if no name specified
+func NewGetFlagsTask(dingoadm *cli.DingoAdm, dc *topology.DeployConfig) (*task.Task, error) {
+	serviceId := dingoadm.GetServiceId(dc.GetId())
+	if dingoadm.IsSkip(dc) {
+		return nil, nil
+	} else if !hasBrpcHealth(dc) {
+		return nil, nil
+	}
+	hc, err := dingoadm.GetHost(dc.GetHost())
+	if err != nil {
+		return nil, err
+	}
+
+	// new task
+	names := dingoadm.MemStorage().Get(comm.KEY_FLAG_NAMES).([]string)
+	subname := fmt.Sprintf("host=%s role=%s flags=%s",
+		dc.GetHost(), dc.GetRole(), utils.Choose(len(names) == 0, "<configured>", strings.Join(names, ",")))
+	t := task.NewTask("Get Flags", subname, hc.GetSSHConfig())
+
+	// add step to task
+	t.AddStep(&step2GetFlags{
+		dc:          dc,
+		serviceId:   serviceId,
+		names:       names,
+		memStorage:  dingoadm.MemStorage(),
+		execOptions: dingoadm.ExecOptions(),
+	})
+
+	return t, nil
+}
@@ -0,0 +1,102 @@
+package common
+
+import (
+	"testing"
+
+	"github.com/dingodb/dingoadm/internal/configure/topology"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseFlags(t *testing.T) {
+	assert := assert.New(t)
+	out := `Name | Value | Description | Defined At
+---------------------------------------
+raft_sync | false (default:true) | call fsync when need | braft/src/braft/raft.cpp
+max_body_size (R) | 67108864 | Maximum size of a single message body | brpc/src/brpc/protocol.cpp
+log_dir |  (default:/tmp) | log directory | glog/src/logging.cc
+`
+	flags := ParseFlags(out)
+	assert.Len(flags, 3)
+	assert.Equal(Flag{Name: "raft_sync", Value: "false", Default: "true"}, flags["raft_sync"])
+	assert.Equal(Flag{Name: "max_body_size", Value: "67108864", Default: "67108864"}, flags["max_body_size"])
+	assert.Equal(Flag{Name: "log_dir", Value: "", Default: "/tmp"}, flags["log_dir"])
+}
+
+func TestFlagDiffers(t *testing.T) {
+	assert := assert.New(t)
+	assert.False(FlagDiffers("true", "True"))
+	assert.False(FlagDiffers("1", "1.0"))
+	assert.False(FlagDiffers("100", ""))
+	assert.True(FlagDiffers("100", "200"))
+	assert.True(FlagDiffers(FLAG_VALUE_MISSING, "200"))
+	assert.True(FlagDiffers("false", "true"))
+}
+
+func TestGetConfiguredFlags(t *testing.T) {
+	assert := assert.New(t)
+	ctx := topology.NewContext()
+	ctx.Add("host1", "host1")
+
+	// mds v2
+	dcs, err := topology.ParseTopology(`
+kind: dingofs
+coordinator_services:
+  deploy:
+    - host: host1
+store_services:
+  config:
+    gflags.raft_sync: false
+    gflags.server_host: ${service_host}
+  deploy:
+    - host: host1
+mds_services:
+  config:
+    mds_heartbeat_interval_s: 10
+  deploy:
+    - host: host1
+executor_services:
+  deploy:
+    - host: host1
+`, ctx)
+	assert.Nil(err)
+	for _, dc := range dcs {
+		flags, err := GetConfiguredFlags(dc)
+		assert.Nil(err)
+		switch dc.GetRole() {
+		case topology.ROLE_STORE:
+			assert.Equal("false", flags["raft_sync"])
+			assert.Equal("host1", flags["server_host"]) // rendered by variable
+			assert.NotContains(flags, "gflags.raft_sync")
+		case topology.ROLE_FS_MDS:
+			assert.Equal("10", flags["mds_heartbeat_interval_s"])
+			for name := range flags {
+				assert.NotContains(name, ".")
+			}
+		case topology.ROLE_DINGODB_EXECUTOR:
+			assert.Empty(flags)
+		}
+	}
+
+	// mds v1 isn't configured by gflags
+	dcs, err = topology.ParseTopology(`
+kind: dingofs
+etcd_services:
+  deploy:
+    - host: host1
+mds_services:
+  config:
+    mds_heartbeat_interval_s: 10
+    gflags.raft_sync: false
+  deploy:
+    - host: host1
+metaserver_services:
+  deploy:
+    - host: host1
+`, ctx)
+	assert.Nil(err)
+	for _, dc := range dcs {
+		flags, err := GetConfiguredFlags(dc)
+		assert.Nil(err)
+		assert.Empty(flags)
+	}
+}
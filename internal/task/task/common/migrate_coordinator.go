@@ -0,0 +1,98 @@
+/*
+ *  Copyright (c) 2025 dingodb.com Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: dingoadm
+ * Author: dongwei (jackblack369)
+ */
+
+package common
+
+import (
+	"fmt"
+
+	"github.com/dingodb/dingoadm/cli/cli"
+	comm "github.com/dingodb/dingoadm/internal/common"
+	"github.com/dingodb/dingoadm/internal/configure"
+	"github.com/dingodb/dingoadm/internal/configure/topology"
+	"github.com/dingodb/dingoadm/internal/errno"
+	"github.com/dingodb/dingoadm/internal/task/scripts"
+	"github.com/dingodb/dingoadm/internal/task/step"
+	"github.com/dingodb/dingoadm/internal/task/task"
+	tui "github.com/dingodb/dingoadm/internal/tui/common"
+)
+
+func getMigrateTarget(dingoadm *cli.DingoAdm, dc *topology.DeployConfig) *topology.DeployConfig {
+	v := dingoadm.MemStorage().Get(comm.KEY_MIGRATE_SERVERS)
+	if v == nil {
+		return nil
+	}
+	for _, migrate := range v.([]*configure.MigrateServer) {
+		if migrate.From.GetId() == dc.GetId() {
+			return migrate.To
+		}
+	}
+	return nil
+}
+
+// remove the migrated coordinator from raft group through its target,
+// the target MUST be added into raft group before (ADD_COORDINATOR_PEER)
+func NewRemoveCoordinatorPeerTask(dingoadm *cli.DingoAdm, dc *topology.DeployConfig) (*task.Task, error) {
+	if dingoadm.IsSkip(dc) {
+		return nil, nil
+	}
+
+	to := getMigrateTarget(dingoadm, dc)
+	if to == nil {
+		return nil, errno.ERR_NO_MIGRATE_TARGET_FOR_SERVICE.
+			F("%s.host[%s]", dc.GetRole(), dc.GetHost())
+	}
+	containerId, err := dingoadm.GetContainerId(dingoadm.GetServiceId(to.GetId()))
+	if err != nil {
+		return nil, err
+	}
+	hc, err := dingoadm.GetHost(to.GetHost())
+	if err != nil {
+		return nil, err
+	}
+
+	// new task
+	peer := fmt.Sprintf("%s:%d", dc.GetListenIp(), dc.GetDingoStoreRaftPort())
+	newPeer := fmt.Sprintf("%s:%d", to.GetListenIp(), to.GetDingoStoreRaftPort())
+	subname := fmt.Sprintf("host=%s peer=%s containerId=%s",
+		dc.GetHost(), peer, tui.TrimContainerId(containerId))
+	t := task.NewTask("Remove Coordinator Peer", subname, hc.GetSSHConfig())
+
+	// add step to task
+	var out string
+	script := scripts.REMOVE_COORDINATOR_PEER
+	scriptPath := fmt.Sprintf("%s/%s", to.GetProjectLayout().DingoStoreScriptDir, topology.SCRIPT_REMOVE_COORDINATOR_PEER)
+	t.AddStep(&step.InstallFile{
+		ContainerId:       &containerId,
+		ContainerDestPath: scriptPath,
+		Content:           &script,
+		ExecOptions:       dingoadm.ExecOptions(),
+	})
+	t.AddStep(&step.ContainerExec{
+		ContainerId: &containerId,
+		Command: fmt.Sprintf("bash %s --peer=%s --new_peer=%s",
+			scriptPath, peer, newPeer),
+		Out:         &out,
+		ExecOptions: dingoadm.ExecOptions(),
+	})
+
+	return t, nil
+}
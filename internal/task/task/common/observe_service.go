@@ -0,0 +1,257 @@
+/*
+ *  Copyright (c) 2025 dingodb.com Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: dingoadm
+ * Author: dongwei (jackblack369)
+ */
+
+package common
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/dingodb/dingoadm/cli/cli"
+	comm "github.com/dingodb/dingoadm/internal/common"
+	"github.com/dingodb/dingoadm/internal/configure/topology"
+	"github.com/dingodb/dingoadm/internal/errno"
+	"github.com/dingodb/dingoadm/internal/task/context"
+	"github.com/dingodb/dingoadm/internal/task/step"
+	"github.com/dingodb/dingoadm/internal/task/task"
+	tui "github.com/dingodb/dingoadm/internal/tui/common"
+	"github.com/dingodb/dingoadm/pkg/module"
+)
+
+const (
+	OBSERVE_INTERVAL           = 10 * time.Second
+	OBSERVE_MAX_HEALTH_FAILURE = 3
+	URL_BRPC_HEALTH            = "--connect-timeout 1 --max-time 3 http://%s:%d/health"
+	COMMAND_PROMETHEUS_QUERY   = "curl -sG --connect-timeout 3 --max-time 10 http://%s/api/v1/query --data-urlencode 'query=%s'"
+)
+
+type (
+	step2ObserveService struct {
+		dc          *topology.DeployConfig
+		containerId string
+		duration    time.Duration
+		prometheus  string
+		queries     []string
+		execOptions module.ExecOptions
+	}
+
+	prometheusResult struct {
+		Status string `json:"status"`
+		Data   struct {
+			Result []interface{} `json:"result"`
+		} `json:"data"`
+	}
+)
+
+// return the port which brpc builtin services (e.g. /health, /pprof) listen on,
+// 0 means the role doesn't expose them
+func getBrpcPort(dc *topology.DeployConfig) int {
+	switch dc.GetRole() {
+	case topology.ROLE_COORDINATOR,
+		topology.ROLE_STORE,
+		topology.ROLE_DINGODB_DOCUMENT,
+		topology.ROLE_DINGODB_INDEX,
+		topology.ROLE_DINGODB_DISKANN:
+		return dc.GetDingoServerPort()
+	case topology.ROLE_FS_MDS:
+		if dc.GetCtx().Lookup(topology.CTX_KEY_MDS_VERSION) == topology.CTX_VAL_MDS_V2 {
+			return dc.GetDingoServerPort()
+		}
+		return dc.GetListenDummyPort() // mds v1 exposes them on dummy server
+	case topology.ROLE_METASERVER:
+		return dc.GetListenPort()
+	}
+	return 0
+}
+
+func hasBrpcHealth(dc *topology.DeployConfig) bool {
+	return getBrpcPort(dc) > 0
+}
+
+// return restart count and status of container, e.g. "0 running"
+func inspectContainerState(ctx *context.Context, containerId string, execOptions module.ExecOptions) (int, string, error) {
+	var out string
+	err := (&step.InspectContainer{
+		ContainerId: containerId,
+		Format:      "'{{.RestartCount}} {{.State.Status}}'",
+		Out:         &out,
+		ExecOptions: execOptions,
+	}).Execute(ctx)
+	if err != nil {
+		return 0, "", err
+	}
+
+	items := strings.Fields(out)
+	if len(items) != 2 {
+		return 0, "", errno.ERR_INSPECT_CONTAINER_FAILED.F("unexpected output: %s", out)
+	}
+	count, err := strconv.Atoi(items[0])
+	if err != nil {
+		return 0, "", errno.ERR_INSPECT_CONTAINER_FAILED.E(err)
+	}
+	return count, items[1], nil
+}
+
+func (s *step2ObserveService) inspect(ctx *context.Context) (int, string, error) {
+	return inspectContainerState(ctx, s.containerId, s.execOptions)
+}
+
+// probe brpc health endpoint of service once
+func checkBrpcHealth(ctx *context.Context, dc *topology.DeployConfig, execOptions module.ExecOptions) bool {
+	var success bool
+	var out string
+	(&step.Curl{
+		Url:         fmt.Sprintf(URL_BRPC_HEALTH, dc.GetListenIp(), getBrpcPort(dc)),
+		Silent:      true,
+		Success:     &success,
+		Out:         &out,
+		ExecOptions: execOptions,
+	}).Execute(ctx)
+	return success && strings.Contains(out, "OK")
+}
+
+func (s *step2ObserveService) checkHealth(ctx *context.Context) bool {
+	return checkBrpcHealth(ctx, s.dc, s.execOptions)
+}
+
+// return the first query which has result, the query which failed to
+// execute is ignored, because prometheus is not the service we upgrade
+func (s *step2ObserveService) checkQueries(ctx *context.Context) string {
+	for _, query := range s.queries {
+		var success bool
+		var out string
+		(&step.Command{
+			Command: fmt.Sprintf(COMMAND_PROMETHEUS_QUERY, s.prometheus,
+				strings.ReplaceAll(query, "'", `'\''`)),
+			Success:     &success,
+			Out:         &out,
+			ExecOptions: s.execOptions,
+		}).Execute(ctx)
+
+		if success && HasPrometheusResult(out) {
+			return query
+		}
+	}
+	return ""
+}
+
+// the query on regression returns result, e.g. 'increase(restarts[5m]) > 0'
+func HasPrometheusResult(out string) bool {
+	result := prometheusResult{}
+	if json.Unmarshal([]byte(out), &result) != nil {
+		return false
+	}
+	return result.Status == "success" && len(result.Data.Result) > 0
+}
+
+func (s *step2ObserveService) Execute(ctx *context.Context) error {
+	dc := s.dc
+	baseline, _, err := s.inspect(ctx)
+	if err != nil {
+		return err
+	}
+
+	failures := 0
+	deadline := time.Now().Add(s.duration)
+	for time.Now().Before(deadline) {
+		time.Sleep(OBSERVE_INTERVAL)
+
+		// 1) container restarted or exited
+		count, status, err := s.inspect(ctx)
+		if err != nil {
+			return err
+		} else if count > baseline || status != "running" {
+			return errno.ERR_CANARY_SERVICE_RESTARTED.
+				F("%s.host[%s]: restart count %d, status %s", dc.GetRole(), dc.GetHost(), count, status)
+		}
+
+		// 2) brpc health
+		if hasBrpcHealth(dc) {
+			if s.checkHealth(ctx) {
+				failures = 0
+			} else if failures++; failures >= OBSERVE_MAX_HEALTH_FAILURE {
+				return errno.ERR_CANARY_SERVICE_UNHEALTHY.
+					F("%s.host[%s]: %d consecutive failures", dc.GetRole(), dc.GetHost(), failures)
+			}
+		}
+
+		// 3) prometheus queries
+		if len(s.prometheus) > 0 {
+			if query := s.checkQueries(ctx); len(query) > 0 {
+				return errno.ERR_CANARY_PROMETHEUS_QUERY_FIRED.F("query: %s", query)
+			}
+		}
+	}
+	return nil
+}
+
+/*
+ * observe the upgraded service for a while, the observation fails if:
+ *   1) the container restarted or exited
+ *   2) brpc health endpoint failed continuously
+ *   3) any prometheus query returns result, e.g. 'up{job="store"} == 0'
+ */
+func NewObserveServiceTask(dingoadm *cli.DingoAdm, dc *topology.DeployConfig) (*task.Task, error) {
+	if dc.GetRole() == topology.ROLE_FS_MDS_CLI {
+		return nil, nil
+	}
+
+	serviceId := dingoadm.GetServiceId(dc.GetId())
+	containerId, err := dingoadm.GetContainerId(serviceId)
+	if dingoadm.IsSkip(dc) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	hc, err := dingoadm.GetHost(dc.GetHost())
+	if err != nil {
+		return nil, err
+	}
+
+	// options
+	duration := dingoadm.MemStorage().Get(comm.KEY_OBSERVE_DURATION).(time.Duration)
+	prometheus := ""
+	queries := []string{}
+	if v := dingoadm.MemStorage().Get(comm.KEY_PROMETHEUS_ADDRESS); v != nil {
+		prometheus = v.(string)
+		queries = dingoadm.MemStorage().Get(comm.KEY_PROMETHEUS_QUERIES).([]string)
+	}
+
+	// new task
+	subname := fmt.Sprintf("host=%s role=%s containerId=%s duration=%s",
+		dc.GetHost(), dc.GetRole(), tui.TrimContainerId(containerId), duration)
+	t := task.NewTask("Observe Service", subname, hc.GetSSHConfig())
+
+	// add step to task
+	t.AddStep(&step2ObserveService{
+		dc:          dc,
+		containerId: containerId,
+		duration:    duration,
+		prometheus:  prometheus,
+		queries:     queries,
+		execOptions: dingoadm.ExecOptions(),
+	})
+
+	return t, nil
+}
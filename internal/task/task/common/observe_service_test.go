@@ -0,0 +1,51 @@
+package common
+
+import (
+	"testing"
+
+	"github.com/dingodb/dingoadm/internal/configure/topology"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHasPrometheusResult(t *testing.T) {
+	assert := assert.New(t)
+	assert.True(HasPrometheusResult(`{"status":"success","data":{"resultType":"vector","result":[{"metric":{},"value":[1,"1"]}]}}`))
+	assert.False(HasPrometheusResult(`{"status":"success","data":{"resultType":"vector","result":[]}}`))
+	assert.False(HasPrometheusResult(`{"status":"error","errorType":"bad_data","error":"parse error"}`))
+	assert.False(HasPrometheusResult("curl: (7) Failed to connect"))
+}
+
+func TestGetBrpcPort(t *testing.T) {
+	assert := assert.New(t)
+
+	data := `
+kind: dingofs
+etcd_services:
+  deploy:
+    - host: host1
+mds_services:
+  config:
+    listen.port: 6700
+    listen.dummy_port: 7700
+  deploy:
+    - host: host1
+metaserver_services:
+  config:
+    listen.port: 6800
+  deploy:
+    - host: host1
+`
+	ctx := topology.NewContext()
+	ctx.Add("host1", "host1")
+	dcs, err := topology.ParseTopology(data, ctx)
+	assert.Nil(err)
+
+	// mds v1 exposes brpc builtin services on dummy port
+	ports := map[string]int{}
+	for _, dc := range dcs {
+		ports[dc.GetRole()] = getBrpcPort(dc)
+	}
+	assert.Equal(7700, ports[topology.ROLE_FS_MDS])
+	assert.Equal(6800, ports[topology.ROLE_METASERVER])
+}
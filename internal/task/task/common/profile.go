@@ -0,0 +1,171 @@
+/*
+ *  Copyright (c) 2025 dingodb.com Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: dingoadm
+ * Author: dongwei (jackblack369)
+ */
+
+package common
+
+import (
+	"fmt"
+	"path"
+	"time"
+
+	"github.com/dingodb/dingoadm/cli/cli"
+	comm "github.com/dingodb/dingoadm/internal/common"
+	"github.com/dingodb/dingoadm/internal/configure/topology"
+	"github.com/dingodb/dingoadm/internal/errno"
+	"github.com/dingodb/dingoadm/internal/task/context"
+	"github.com/dingodb/dingoadm/internal/task/step"
+	"github.com/dingodb/dingoadm/internal/task/task"
+	"github.com/dingodb/dingoadm/internal/utils"
+)
+
+const (
+	PROFILE_TYPE_CPU        = "cpu"
+	PROFILE_TYPE_HEAP       = "heap"
+	PROFILE_TYPE_CONTENTION = "contention"
+
+	// the profile is sampled for duration, allow some time for symbolizing and transferring
+	PROFILE_EXTRA_TIMEOUT = 60 * time.Second
+	URL_BRPC_PROFILE      = "--fail --connect-timeout 3 --max-time %d 'http://%s:%d%s'"
+)
+
+var (
+	// brpc builtin services, raw profile which can be analyzed by pprof
+	BRPC_PPROF_PATHS = map[string]string{
+		PROFILE_TYPE_CPU:        "/pprof/profile",
+		PROFILE_TYPE_HEAP:       "/pprof/heap",
+		PROFILE_TYPE_CONTENTION: "/pprof/contention",
+	}
+)
+
+type (
+	ProfileOptions struct {
+		Type       string
+		Duration   time.Duration
+		SaveDir    string
+		Flamegraph bool
+		Timestamp  time.Time
+	}
+)
+
+func IsValidProfileType(typ string) bool {
+	_, ok := BRPC_PPROF_PATHS[typ]
+	return ok
+}
+
+/*
+ * GetProfilePath returns the path of brpc builtin service:
+ *   raw profile:  /pprof/profile?seconds=30
+ *   flamegraph:   /hotspots/cpu?seconds=30&display_type=flame
+ * heap profile is a snapshot, so seconds is not required
+ */
+func GetProfilePath(typ string, duration time.Duration, flamegraph bool) string {
+	p := BRPC_PPROF_PATHS[typ]
+	if flamegraph {
+		p = fmt.Sprintf("/hotspots/%s", typ)
+	}
+
+	query := []string{}
+	if typ != PROFILE_TYPE_HEAP {
+		query = append(query, fmt.Sprintf("seconds=%d", int(duration.Seconds())))
+	}
+	if flamegraph {
+		query = append(query, "display_type=flame")
+	}
+	for i, q := range query {
+		p += utils.Choose(i == 0, "?", "&") + q
+	}
+	return p
+}
+
+// e.g. store_server-1_0_cpu_20250101120000.prof
+func GetProfileFilename(dc *topology.DeployConfig, options ProfileOptions, flamegraph bool) string {
+	return fmt.Sprintf("%s_%s_%d_%s_%s.%s", dc.GetRole(), dc.GetHost(), dc.GetInstancesSequence(),
+		options.Type, options.Timestamp.Format("20060102150405"), utils.Choose(flamegraph, "svg", "prof"))
+}
+
+func checkProfileRole(dc *topology.DeployConfig) step.LambdaType {
+	return func(ctx *context.Context) error {
+		if !hasBrpcHealth(dc) {
+			return errno.ERR_UNSUPPORT_PROFILE_ROLE.F("role: %s", dc.GetRole())
+		}
+		return nil
+	}
+}
+
+/*
+ * fetch profile by curl on service host, because brpc only listens on listen ip,
+ * then download it to control machine. the flamegraph is rendered by another
+ * sampling after the raw profile, so it takes twice the duration
+ */
+func NewProfileServiceTask(dingoadm *cli.DingoAdm, dc *topology.DeployConfig) (*task.Task, error) {
+	hc, err := dingoadm.GetHost(dc.GetHost())
+	if err != nil {
+		return nil, err
+	}
+
+	// new task
+	options := dingoadm.MemStorage().Get(comm.KEY_PROFILE_OPTIONS).(ProfileOptions)
+	subname := fmt.Sprintf("host=%s role=%s type=%s duration=%s",
+		dc.GetHost(), dc.GetRole(), options.Type, options.Duration)
+	t := task.NewTask("Profile Service", subname, hc.GetSSHConfig())
+
+	// add step to task
+	t.AddStep(&step.Lambda{
+		Lambda: checkProfileRole(dc),
+	})
+	timeout := int((options.Duration + PROFILE_EXTRA_TIMEOUT).Seconds())
+	curlOptions := dingoadm.ExecOptions()
+	curlOptions.ExecTimeoutSec = timeout + int(PROFILE_EXTRA_TIMEOUT.Seconds()) // outlive the --max-time of curl
+	flamegraphs := []bool{false}
+	if options.Flamegraph {
+		flamegraphs = append(flamegraphs, true)
+	}
+	files := []string{}
+	for _, flamegraph := range flamegraphs {
+		remotePath := utils.RandFilename(TEMP_DIR)
+		localPath := path.Join(options.SaveDir, GetProfileFilename(dc, options, flamegraph))
+		t.AddStep(&step.Curl{
+			Url: fmt.Sprintf(URL_BRPC_PROFILE, timeout, dc.GetListenIp(), getBrpcPort(dc),
+				GetProfilePath(options.Type, options.Duration, flamegraph)),
+			Output:      remotePath,
+			Silent:      true,
+			ExecOptions: curlOptions,
+		})
+		t.AddStep(&step.DownloadFile{
+			RemotePath:  remotePath,
+			LocalPath:   localPath,
+			ExecOptions: dingoadm.ExecOptions(),
+		})
+		t.AddPostStep(&step.RemoveFile{
+			Files:       []string{remotePath},
+			ExecOptions: dingoadm.ExecOptions(),
+		})
+		files = append(files, localPath)
+	}
+	t.AddStep(&step.Lambda{
+		Lambda: func(ctx *context.Context) error {
+			dingoadm.MemStorage().Set(comm.KEY_PROFILE_FILES, files)
+			return nil
+		},
+	})
+
+	return t, nil
+}
@@ -0,0 +1,25 @@
+package common
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetProfilePath(t *testing.T) {
+	assert := assert.New(t)
+	duration := 30 * time.Second
+
+	assert.Equal("/pprof/profile?seconds=30", GetProfilePath(PROFILE_TYPE_CPU, duration, false))
+	assert.Equal("/pprof/heap", GetProfilePath(PROFILE_TYPE_HEAP, duration, false))
+	assert.Equal("/pprof/contention?seconds=30", GetProfilePath(PROFILE_TYPE_CONTENTION, duration, false))
+	assert.Equal("/hotspots/cpu?seconds=30&display_type=flame", GetProfilePath(PROFILE_TYPE_CPU, duration, true))
+	assert.Equal("/hotspots/heap?display_type=flame", GetProfilePath(PROFILE_TYPE_HEAP, duration, true))
+}
+
+func TestIsValidProfileType(t *testing.T) {
+	assert.True(t, IsValidProfileType(PROFILE_TYPE_CPU))
+	assert.True(t, IsValidProfileType(PROFILE_TYPE_CONTENTION))
+	assert.False(t, IsValidProfileType("growth"))
+}
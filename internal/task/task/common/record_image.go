@@ -0,0 +1,160 @@
+/*
+ *  Copyright (c) 2025 dingodb.com Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: dingoadm
+ * Author: dongwei (jackblack369)
+ */
+
+package common
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/dingodb/dingoadm/cli/cli"
+	comm "github.com/dingodb/dingoadm/internal/common"
+	"github.com/dingodb/dingoadm/internal/configure/topology"
+	"github.com/dingodb/dingoadm/internal/errno"
+	"github.com/dingodb/dingoadm/internal/storage"
+	"github.com/dingodb/dingoadm/internal/task/context"
+	"github.com/dingodb/dingoadm/internal/task/step"
+	"github.com/dingodb/dingoadm/internal/task/task"
+	tui "github.com/dingodb/dingoadm/internal/tui/common"
+	"github.com/dingodb/dingoadm/pkg/log"
+	"github.com/dingodb/dingoadm/pkg/module"
+)
+
+type step2RecordImage struct {
+	serviceId   string
+	image       string // the image which will be upgraded to
+	success     *bool
+	out         *string
+	storage     *storage.Storage
+	execOptions module.ExecOptions
+}
+
+var (
+	IMAGE_ID_PATTERN = regexp.MustCompile(`^(sha256:)?[0-9a-f]{12,64}$`)
+)
+
+// the image which created by 'docker run IMAGE_ID' has no tag
+func IsImageId(image string) bool {
+	return IMAGE_ID_PATTERN.MatchString(image)
+}
+
+// returns the repo digest of tag, e.g. dingodatabase/dingofs@sha256:..., the image id is
+// returned if the image has no repo digest (e.g. built locally)
+func GetImageDigest(tag, digests, imageId string) string {
+	repo := tag
+	if i := strings.LastIndex(tag, ":"); i > strings.LastIndex(tag, "/") {
+		repo = tag[:i]
+	}
+	items := strings.Fields(digests)
+	for _, digest := range items {
+		if strings.HasPrefix(digest, repo+"@") {
+			return digest
+		}
+	}
+	if len(items) > 0 {
+		return items[0]
+	}
+	return imageId
+}
+
+// record the tag of image which service running before it upgraded, the record is
+// kept if the image is not changed, so upgrade again never lose the record
+func (s *step2RecordImage) Execute(ctx *context.Context) error {
+	if !*s.success { // container not exist, nothing to record
+		return nil
+	}
+
+	items := strings.Fields(*s.out)
+	if len(items) != 2 || items[0] == s.image {
+		return nil
+	} else if IsImageId(items[0]) { // rollback requires a tag which can be pulled
+		log.Warn("Skip record image without tag",
+			log.Field("ServiceId", s.serviceId),
+			log.Field("Image", items[0]))
+		return nil
+	}
+
+	tag, imageId := items[0], items[1]
+	var success bool
+	var digests string
+	(&step.InspectContainer{
+		ContainerId: imageId, // inspect image
+		Format:      "'{{join .RepoDigests \" \"}}'",
+		Success:     &success,
+		Out:         &digests,
+		ExecOptions: s.execOptions,
+	}).Execute(ctx)
+	err := s.storage.ReplaceServiceImage(s.serviceId, tag, GetImageDigest(tag, digests, imageId))
+	if err != nil {
+		return errno.ERR_REPLACE_SERVICE_IMAGE_FAILED.E(err)
+	}
+	return nil
+}
+
+func NewRecordImageTask(dingoadm *cli.DingoAdm, dc *topology.DeployConfig) (*task.Task, error) {
+	if dc.GetRole() == topology.ROLE_FS_MDS_CLI {
+		skipTmp := dingoadm.MemStorage().Get(comm.KEY_SKIP_MDSV2_CLI)
+		if skipTmp != nil && skipTmp.(bool) {
+			return nil, nil
+		}
+	}
+
+	serviceId := dingoadm.GetServiceId(dc.GetId())
+	containerId, err := dingoadm.GetContainerId(serviceId)
+	if dingoadm.IsSkip(dc) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	} else if len(containerId) == 0 || containerId == comm.CLEANED_CONTAINER_ID {
+		return nil, nil
+	}
+	hc, err := dingoadm.GetHost(dc.GetHost())
+	if err != nil {
+		return nil, err
+	}
+
+	// new task
+	subname := fmt.Sprintf("host=%s role=%s containerId=%s",
+		dc.GetHost(), dc.GetRole(), tui.TrimContainerId(containerId))
+	t := task.NewTask("Record Service Image", subname, hc.GetSSHConfig())
+
+	// add step to task
+	var success bool
+	var out string
+	t.AddStep(&step.InspectContainer{
+		ContainerId: containerId,
+		Format:      "'{{.Config.Image}} {{.Image}}'",
+		Success:     &success,
+		Out:         &out,
+		ExecOptions: dingoadm.ExecOptions(),
+	})
+	t.AddStep(&step2RecordImage{
+		serviceId:   serviceId,
+		image:       dc.GetContainerImage(),
+		success:     &success,
+		out:         &out,
+		storage:     dingoadm.Storage(),
+		execOptions: dingoadm.ExecOptions(),
+	})
+
+	return t, nil
+}
@@ -0,0 +1,25 @@
+package common
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsImageId(t *testing.T) {
+	assert := assert.New(t)
+	assert.True(IsImageId("sha256:4f53cda18c2baa0c0354bb5f9a3ecbe5ed12ab4d8e11ba873c2f11161202b945"))
+	assert.True(IsImageId("4f53cda18c2b"))
+	assert.False(IsImageId("dingodatabase/dingofs:latest"))
+	assert.False(IsImageId("registry:5000/dingofs:v1"))
+}
+
+func TestGetImageDigest(t *testing.T) {
+	assert := assert.New(t)
+
+	digests := "harbor.local/dingofs@sha256:aaa dingodatabase/dingofs@sha256:bbb"
+	assert.Equal("dingodatabase/dingofs@sha256:bbb", GetImageDigest("dingodatabase/dingofs:v1", digests, "sha256:ccc"))
+	assert.Equal("harbor.local/dingofs@sha256:aaa", GetImageDigest("registry:5000/dingofs:v1", digests, "sha256:ccc"))
+	assert.Equal("dingodatabase/dingofs@sha256:bbb", GetImageDigest("dingodatabase/dingofs", digests, "sha256:ccc"))
+	assert.Equal("sha256:ccc", GetImageDigest("dingofs:local", "", "sha256:ccc")) // built locally
+}
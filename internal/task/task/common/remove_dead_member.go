@@ -0,0 +1,121 @@
+/*
+ *  Copyright (c) 2025 dingodb.com Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: dingoadm
+ * Author: dongwei (jackblack369)
+ */
+
+package common
+
+import (
+	"fmt"
+
+	"github.com/dingodb/dingoadm/cli/cli"
+	comm "github.com/dingodb/dingoadm/internal/common"
+	"github.com/dingodb/dingoadm/internal/configure/topology"
+	"github.com/dingodb/dingoadm/internal/errno"
+	"github.com/dingodb/dingoadm/internal/task/scripts"
+	"github.com/dingodb/dingoadm/internal/task/step"
+	"github.com/dingodb/dingoadm/internal/task/task"
+	tui "github.com/dingodb/dingoadm/internal/tui/common"
+)
+
+// find an alive member which has same role with dead one but on other host
+func getAliveMember(dingoadm *cli.DingoAdm, dead *topology.DeployConfig) (*topology.DeployConfig, string) {
+	v := dingoadm.MemStorage().Get(comm.KEY_ALL_DEPLOY_CONFIGS)
+	if v == nil {
+		return nil, ""
+	}
+	for _, dc := range dingoadm.FilterDeployConfigByRole(v.([]*topology.DeployConfig), dead.GetRole()) {
+		if dc.GetHost() == dead.GetHost() {
+			continue
+		}
+		containerId, err := dingoadm.GetContainerId(dingoadm.GetServiceId(dc.GetId()))
+		if err == nil && len(containerId) > 0 && containerId != comm.CLEANED_CONTAINER_ID {
+			return dc, containerId
+		}
+	}
+	return nil, ""
+}
+
+/*
+ * remove member whose host is dead from raft group through an alive member,
+ * the dead host is never accessed:
+ *   etcd:        member remove, and add the replacement as new member
+ *   coordinator: RaftRemovePeer, the replacement is added after it started
+ */
+func NewRemoveDeadMemberTask(dingoadm *cli.DingoAdm, dc *topology.DeployConfig) (*task.Task, error) {
+	alive, containerId := getAliveMember(dingoadm, dc)
+	if alive == nil {
+		return nil, errno.ERR_NO_ALIVE_MEMBER_FOR_REPLACE_HOST.
+			F("%s.host[%s]", dc.GetRole(), dc.GetHost())
+	}
+	hc, err := dingoadm.GetHost(alive.GetHost())
+	if err != nil {
+		return nil, err
+	}
+
+	layout := alive.GetProjectLayout()
+	var script, scriptPath, command string
+	switch dc.GetRole() {
+	case topology.ROLE_ETCD:
+		to := getMigrateTarget(dingoadm, dc)
+		if to == nil {
+			return nil, errno.ERR_NO_MIGRATE_TARGET_FOR_SERVICE.
+				F("%s.host[%s]", dc.GetRole(), dc.GetHost())
+		}
+		auth := ""
+		if alive.GetEtcdAuthEnable() {
+			auth = fmt.Sprintf("%s:%s", alive.GetEtcdAuthUsername(), alive.GetEtcdAuthPassword())
+		}
+		script = scripts.REPLACE_ETCD_MEMBER
+		scriptPath = fmt.Sprintf("%s/%s", layout.ServiceBinDir, topology.SCRIPT_REPLACE_ETCD_MEMBER)
+		command = fmt.Sprintf("bash %s %s:%d http://%s:%d %s http://%s:%d %s", scriptPath,
+			alive.GetListenIp(), alive.GetListenClientPort(),
+			dc.GetListenIp(), dc.GetListenPort(),
+			to.GetEtcdName(), // same as the name in ${cluster_etcd_http_addr} of new topology
+			to.GetListenIp(), to.GetListenPort(), auth)
+	case topology.ROLE_COORDINATOR:
+		script = scripts.REMOVE_COORDINATOR_PEER
+		scriptPath = fmt.Sprintf("%s/%s", layout.DingoStoreScriptDir, topology.SCRIPT_REMOVE_COORDINATOR_PEER)
+		command = fmt.Sprintf("bash %s --peer=%s:%d", scriptPath, dc.GetListenIp(), dc.GetDingoStoreRaftPort())
+	default:
+		return nil, nil
+	}
+
+	// new task
+	subname := fmt.Sprintf("host=%s role=%s containerId=%s",
+		dc.GetHost(), dc.GetRole(), tui.TrimContainerId(containerId))
+	t := task.NewTask("Remove Dead Member", subname, hc.GetSSHConfig())
+
+	// add step to task
+	var out string
+	t.AddStep(&step.InstallFile{
+		ContainerId:       &containerId,
+		ContainerDestPath: scriptPath,
+		Content:           &script,
+		ExecOptions:       dingoadm.ExecOptions(),
+	})
+	t.AddStep(&step.ContainerExec{
+		ContainerId: &containerId,
+		Command:     command,
+		Out:         &out,
+		ExecOptions: dingoadm.ExecOptions(),
+	})
+
+	return t, nil
+}
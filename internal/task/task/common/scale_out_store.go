@@ -0,0 +1,126 @@
+/*
+ *  Copyright (c) 2025 dingodb.com Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: dingoadm
+ * Author: dongwei (jackblack369)
+ */
+
+package common
+
+import (
+	"fmt"
+
+	"github.com/dingodb/dingoadm/cli/cli"
+	comm "github.com/dingodb/dingoadm/internal/common"
+	"github.com/dingodb/dingoadm/internal/configure/topology"
+	"github.com/dingodb/dingoadm/internal/errno"
+	"github.com/dingodb/dingoadm/internal/task/scripts"
+	"github.com/dingodb/dingoadm/internal/task/step"
+	"github.com/dingodb/dingoadm/internal/task/task"
+	tui "github.com/dingodb/dingoadm/internal/tui/common"
+)
+
+// wait the new store registered into coordinator (by heartbeat)
+func NewWaitStoreRegisteredTask(dingoadm *cli.DingoAdm, dc *topology.DeployConfig) (*task.Task, error) {
+	serviceId := dingoadm.GetServiceId(dc.GetId())
+	containerId, err := dingoadm.GetContainerId(serviceId)
+	if dingoadm.IsSkip(dc) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	hc, err := dingoadm.GetHost(dc.GetHost())
+	if err != nil {
+		return nil, err
+	}
+
+	// new task
+	subname := fmt.Sprintf("host=%s role=%s instanceId=%d containerId=%s",
+		dc.GetHost(), dc.GetRole(), dc.GetDingoInstanceId(), tui.TrimContainerId(containerId))
+	t := task.NewTask("Wait Store Registered", subname, hc.GetSSHConfig())
+
+	// add step to task
+	var out string
+	script := scripts.WAIT_STORE_REGISTERED
+	scriptPath := fmt.Sprintf("%s/%s", dc.GetProjectLayout().DingoStoreScriptDir, topology.SCRIPT_WAIT_STORE_REGISTERED)
+	t.AddStep(&step.InstallFile{
+		ContainerId:       &containerId,
+		ContainerDestPath: scriptPath,
+		Content:           &script,
+		ExecOptions:       dingoadm.ExecOptions(),
+	})
+	t.AddStep(&step.ContainerExec{
+		ContainerId: &containerId,
+		Command:     fmt.Sprintf("bash %s --instance_id=%d", scriptPath, dc.GetDingoInstanceId()),
+		Out:         &out,
+		ExecOptions: dingoadm.ExecOptions(),
+	})
+
+	return t, nil
+}
+
+// add the new coordinator into raft group through an existing coordinator
+func NewAddCoordinatorPeerTask(dingoadm *cli.DingoAdm, dc *topology.DeployConfig) (*task.Task, error) {
+	if dingoadm.IsSkip(dc) {
+		return nil, nil
+	}
+
+	// find an existing coordinator to execute command
+	var target *topology.DeployConfig
+	var containerId string
+	v := dingoadm.MemStorage().Get(comm.KEY_EXISTING_COORDINATORS)
+	if v != nil {
+		for _, coordinator := range v.([]*topology.DeployConfig) {
+			id, err := dingoadm.GetContainerId(dingoadm.GetServiceId(coordinator.GetId()))
+			if err == nil && len(id) > 0 {
+				target, containerId = coordinator, id
+				break
+			}
+		}
+	}
+	if target == nil {
+		return nil, errno.ERR_NO_EXISTING_COORDINATOR_FOR_SCALE_OUT
+	}
+	hc, err := dingoadm.GetHost(target.GetHost())
+	if err != nil {
+		return nil, err
+	}
+
+	// new task
+	peer := fmt.Sprintf("%s:%d", dc.GetListenIp(), dc.GetDingoStoreRaftPort())
+	subname := fmt.Sprintf("host=%s peer=%s", dc.GetHost(), peer)
+	t := task.NewTask("Add Coordinator Peer", subname, hc.GetSSHConfig())
+
+	// add step to task
+	var out string
+	script := scripts.ADD_COORDINATOR_PEER
+	scriptPath := fmt.Sprintf("%s/%s", target.GetProjectLayout().DingoStoreScriptDir, topology.SCRIPT_ADD_COORDINATOR_PEER)
+	t.AddStep(&step.InstallFile{
+		ContainerId:       &containerId,
+		ContainerDestPath: scriptPath,
+		Content:           &script,
+		ExecOptions:       dingoadm.ExecOptions(),
+	})
+	t.AddStep(&step.ContainerExec{
+		ContainerId: &containerId,
+		Command:     fmt.Sprintf("bash %s --peer=%s", scriptPath, peer),
+		Out:         &out,
+		ExecOptions: dingoadm.ExecOptions(),
+	})
+
+	return t, nil
+}
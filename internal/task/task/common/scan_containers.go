@@ -0,0 +1,111 @@
+/*
+ *  Copyright (c) 2025 dingodb.com Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: dingoadm
+ * Author: dongwei (jackblack369)
+ */
+
+package common
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/dingodb/dingoadm/cli/cli"
+	comm "github.com/dingodb/dingoadm/internal/common"
+	"github.com/dingodb/dingoadm/internal/configure/topology"
+	"github.com/dingodb/dingoadm/internal/task/context"
+	"github.com/dingodb/dingoadm/internal/task/step"
+	"github.com/dingodb/dingoadm/internal/task/task"
+	"github.com/dingodb/dingoadm/internal/utils"
+)
+
+type (
+	LabeledContainer struct {
+		ContainerId string
+		ClusterUUId string
+		ServiceId   string
+		Role        string
+		Host        string
+	}
+
+	step2ParseLabeledContainers struct {
+		host       string
+		out        *string
+		memStorage *utils.SafeMap
+	}
+)
+
+func (s *step2ParseLabeledContainers) Execute(ctx *context.Context) error {
+	containers := []LabeledContainer{}
+	for _, line := range strings.Split(strings.TrimSpace(*s.out), "\n") {
+		// containerId clusterUUId serviceId role
+		fields := strings.Fields(line)
+		if len(fields) != 4 {
+			continue
+		}
+		containers = append(containers, LabeledContainer{
+			ContainerId: fields[0],
+			ClusterUUId: fields[1],
+			ServiceId:   fields[2],
+			Role:        fields[3],
+			Host:        s.host,
+		})
+	}
+
+	s.memStorage.TX(func(kv *utils.SafeMap) error {
+		m := []LabeledContainer{}
+		v := kv.Get(comm.KEY_ALL_LABELED_CONTAINERS)
+		if v != nil {
+			m = v.([]LabeledContainer)
+		}
+		m = append(m, containers...)
+		kv.Set(comm.KEY_ALL_LABELED_CONTAINERS, m)
+		return nil
+	})
+	return nil
+}
+
+func NewScanContainersTask(dingoadm *cli.DingoAdm, dc *topology.DeployConfig) (*task.Task, error) {
+	hc, err := dingoadm.GetHost(dc.GetHost())
+	if err != nil {
+		return nil, err
+	}
+
+	// new task
+	subname := fmt.Sprintf("host=%s", dc.GetHost())
+	t := task.NewTask("Scan Labeled Containers", subname, hc.GetSSHConfig())
+
+	// add step to task
+	var out string
+	format := fmt.Sprintf(`'{{.ID}} {{.Label "%s"}} {{.Label "%s"}} {{.Label "%s"}}'`,
+		comm.LABEL_CLUSTER_UUID, comm.LABEL_SERVICE_ID, comm.LABEL_ROLE)
+	t.AddStep(&step.ListContainers{
+		ShowAll:     true,
+		Format:      format,
+		Filter:      fmt.Sprintf("label=%s", comm.LABEL_CLUSTER_UUID),
+		Out:         &out,
+		ExecOptions: dingoadm.ExecOptions(),
+	})
+	t.AddStep(&step2ParseLabeledContainers{
+		host:       dc.GetHost(),
+		out:        &out,
+		memStorage: dingoadm.MemStorage(),
+	})
+
+	return t, nil
+}
@@ -36,6 +36,7 @@ import (
 	"github.com/dingodb/dingoadm/cli/cli"
 	comm "github.com/dingodb/dingoadm/internal/common"
 	"github.com/dingodb/dingoadm/internal/configure/topology"
+	"github.com/dingodb/dingoadm/internal/storage"
 	"github.com/dingodb/dingoadm/internal/task/context"
 	"github.com/dingodb/dingoadm/internal/task/step"
 	"github.com/dingodb/dingoadm/internal/task/task"
@@ -74,32 +75,45 @@ type (
 		execOptions module.ExecOptions
 	}
 
+	step2GetContainerState struct {
+		containerId  string
+		status       *string
+		state        *string
+		restartCount *int
+		execOptions  module.ExecOptions
+	}
+
 	step2FormatServiceStatus struct {
-		dc          *topology.DeployConfig
-		serviceId   string
-		containerId string
-		isLeader    *bool
-		ports       *string
-		status      *string
-		memStorage  *utils.SafeMap
+		dc           *topology.DeployConfig
+		serviceId    string
+		containerId  string
+		isLeader     *bool
+		ports        *string
+		status       *string
+		state        *string
+		restartCount *int
+		memStorage   *utils.SafeMap
+		storage      *storage.Storage
+		clusterId    int
 	}
 
 	ServiceStatus struct {
-		Id          string
-		ParentId    string
-		Role        string
-		Host        string
-		Instances   string
-		ContainerId string
-		Ports       string
-		IsLeader    bool
-		Status      string
-		LogDir      string
-		DataDir     string
-		RaftDir     string
-		DocDir      string
-		VectorDir   string
-		Config      *topology.DeployConfig
+		Id           string                 `json:"id" yaml:"id"`
+		ParentId     string                 `json:"parent_id" yaml:"parent_id"`
+		Role         string                 `json:"role" yaml:"role"`
+		Host         string                 `json:"host" yaml:"host"`
+		Instances    string                 `json:"instances" yaml:"instances"`
+		ContainerId  string                 `json:"container_id" yaml:"container_id"`
+		Ports        string                 `json:"ports" yaml:"ports"`
+		IsLeader     bool                   `json:"is_leader" yaml:"is_leader"`
+		Status       string                 `json:"status" yaml:"status"`
+		RestartCount int                    `json:"restart_count" yaml:"restart_count"`
+		LogDir       string                 `json:"log_dir" yaml:"log_dir"`
+		DataDir      string                 `json:"data_dir" yaml:"data_dir"`
+		RaftDir      string                 `json:"raft_dir" yaml:"raft_dir"`
+		DocDir       string                 `json:"doc_dir" yaml:"doc_dir"`
+		VectorDir    string                 `json:"vector_dir" yaml:"vector_dir"`
+		Config       *topology.DeployConfig `json:"-" yaml:"-"`
 	}
 )
 
@@ -216,33 +230,64 @@ func (s *step2GetLeader) Execute(ctx *context.Context) error {
 	return nil
 }
 
+// the state is only used for history, so the status is still available when inspect failed
+func (s *step2GetContainerState) Execute(ctx *context.Context) error {
+	if len(*s.status) == 0 {
+		return nil
+	}
+
+	count, state, err := inspectContainerState(ctx, s.containerId, s.execOptions)
+	if err == nil {
+		*s.restartCount = count
+		*s.state = state
+	}
+	return nil
+}
+
 func (s *step2FormatServiceStatus) Execute(ctx *context.Context) error {
 	status := *s.status
+	state := *s.state
 	if s.containerId == comm.CLEANED_CONTAINER_ID { // container cleaned
 		status = comm.SERVICE_STATUS_CLEANED
+		state = comm.SERVICE_STATUS_CLEANED
 	} else if len(status) == 0 { // container losed
 		status = comm.SERVICE_STATUS_LOSED
+		state = comm.SERVICE_STATUS_LOSED
 	}
 
 	dc := s.dc
 	id := s.serviceId
 	setServiceStatus(s.memStorage, id, ServiceStatus{
-		Id:          id,
-		ParentId:    dc.GetParentId(),
-		Role:        dc.GetRole(),
-		Host:        dc.GetHost(),
-		Instances:   fmt.Sprintf("1/%d", dc.GetInstances()),
-		ContainerId: tui.TrimContainerId(s.containerId),
-		Ports:       *s.ports,
-		IsLeader:    *s.isLeader,
-		Status:      status,
-		LogDir:      dc.GetLogDir(),
-		DataDir:     dc.GetDataDir(),
-		RaftDir:     dc.GetDingoRaftDir(),
-		DocDir:      dc.GetDingoStoreDocDir(),
-		VectorDir:   dc.GetDingoStoreVectorDir(),
-		Config:      dc,
+		Id:           id,
+		ParentId:     dc.GetParentId(),
+		Role:         dc.GetRole(),
+		Host:         dc.GetHost(),
+		Instances:    fmt.Sprintf("1/%d", dc.GetInstances()),
+		ContainerId:  tui.TrimContainerId(s.containerId),
+		Ports:        *s.ports,
+		IsLeader:     *s.isLeader,
+		Status:       status,
+		RestartCount: *s.restartCount,
+		LogDir:       dc.GetLogDir(),
+		DataDir:      dc.GetDataDir(),
+		RaftDir:      dc.GetDingoRaftDir(),
+		DocDir:       dc.GetDingoStoreDocDir(),
+		VectorDir:    dc.GetDingoStoreVectorDir(),
+		Config:       dc,
 	})
+
+	if len(state) > 0 {
+		RecordStatusHistory(s.storage, storage.StatusHistory{
+			ClusterId:    s.clusterId,
+			ServiceId:    id,
+			Role:         dc.GetRole(),
+			Host:         dc.GetHost(),
+			State:        state,
+			RestartCount: *s.restartCount,
+			Leader: getLeaderRole(dc.GetRole() == topology.ROLE_FS_MDS &&
+				strings.HasPrefix(status, "Up"), *s.isLeader),
+		})
+	}
 	return nil
 }
 
@@ -299,6 +344,8 @@ func NewGetServiceStatusTask(dingoadm *cli.DingoAdm, dc *topology.DeployConfig)
 	var status string
 	var ports string
 	var isLeader bool
+	var state string
+	var restartCount int
 	t.AddStep(&step.ListContainers{
 		ShowAll:     true,
 		Format:      `"{{.Status}}"`,
@@ -309,6 +356,13 @@ func NewGetServiceStatusTask(dingoadm *cli.DingoAdm, dc *topology.DeployConfig)
 	t.AddStep(&step.Lambda{
 		Lambda: TrimContainerStatus(&status),
 	})
+	t.AddStep(&step2GetContainerState{
+		containerId:  containerId,
+		status:       &status,
+		state:        &state,
+		restartCount: &restartCount,
+		execOptions:  dingoadm.ExecOptions(),
+	})
 	t.AddStep(&Step2GetListenPorts{
 		ContainerId: containerId,
 		Status:      &status,
@@ -323,13 +377,17 @@ func NewGetServiceStatusTask(dingoadm *cli.DingoAdm, dc *topology.DeployConfig)
 		execOptions: dingoadm.ExecOptions(),
 	})
 	t.AddStep(&step2FormatServiceStatus{
-		dc:          dc,
-		serviceId:   serviceId,
-		containerId: containerId,
-		isLeader:    &isLeader,
-		ports:       &ports,
-		status:      &status,
-		memStorage:  dingoadm.MemStorage(),
+		dc:           dc,
+		serviceId:    serviceId,
+		containerId:  containerId,
+		isLeader:     &isLeader,
+		ports:        &ports,
+		status:       &status,
+		state:        &state,
+		restartCount: &restartCount,
+		memStorage:   dingoadm.MemStorage(),
+		storage:      dingoadm.Storage(),
+		clusterId:    dingoadm.ClusterId(),
 	})
 
 	return t, nil
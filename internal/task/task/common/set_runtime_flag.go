@@ -0,0 +1,153 @@
+/*
+ *  Copyright (c) 2025 dingodb.com Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: dingoadm
+ * Author: dongwei (jackblack369)
+ */
+
+package common
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/dingodb/dingoadm/cli/cli"
+	comm "github.com/dingodb/dingoadm/internal/common"
+	"github.com/dingodb/dingoadm/internal/configure/topology"
+	"github.com/dingodb/dingoadm/internal/task/context"
+	"github.com/dingodb/dingoadm/internal/task/step"
+	"github.com/dingodb/dingoadm/internal/task/task"
+	tui "github.com/dingodb/dingoadm/internal/tui/common"
+	"github.com/dingodb/dingoadm/internal/utils"
+	"github.com/dingodb/dingoadm/pkg/module"
+)
+
+const (
+	URL_BRPC_SET_FLAG   = "--connect-timeout 1 --max-time 3 'http://%s:%d/flags/%s?setvalue=%s'"
+	SIGNATURE_FLAG_SET  = "Set `"
+	GFLAGS_CONFIG_PREFX = "gflags."
+)
+
+type step2SetRuntimeFlag struct {
+	dc          *topology.DeployConfig
+	serviceId   string
+	flag        string
+	value       string
+	memStorage  *utils.SafeMap
+	execOptions module.ExecOptions
+}
+
+// GetRuntimeFlagName returns the gflag name of config item for brpc /flags,
+// empty string means the config item is not a gflag:
+//
+//	coordinator/store: gflags.xxx -> xxx
+//	mds v2:            xxx        -> xxx (items with dot are not gflags, e.g. listen.port)
+func GetRuntimeFlagName(dc *topology.DeployConfig, key string) string {
+	switch dc.GetRole() {
+	case topology.ROLE_COORDINATOR, topology.ROLE_STORE:
+		if strings.HasPrefix(key, GFLAGS_CONFIG_PREFX) {
+			return strings.TrimPrefix(key, GFLAGS_CONFIG_PREFX)
+		}
+	case topology.ROLE_FS_MDS:
+		if dc.GetCtx().Lookup(topology.CTX_KEY_MDS_VERSION) == topology.CTX_VAL_MDS_V2 &&
+			!strings.Contains(key, ".") {
+			return key
+		}
+	}
+	return ""
+}
+
+func setRuntimeFlagResult(memStorage *utils.SafeMap, id string, reloaded bool) {
+	memStorage.TX(func(kv *utils.SafeMap) error {
+		m := map[string]bool{}
+		v := kv.Get(comm.KEY_RUNTIME_FLAG_RESULTS)
+		if v != nil {
+			m = v.(map[string]bool)
+		}
+		m[id] = reloaded
+		kv.Set(comm.KEY_RUNTIME_FLAG_RESULTS, m)
+		return nil
+	})
+}
+
+// brpc refuses to set the flag which is not reloadable (without validator),
+// so the service falls back to restart if the flag isn't set successfully
+func (s *step2SetRuntimeFlag) Execute(ctx *context.Context) error {
+	reloaded := false
+	defer func() { setRuntimeFlagResult(s.memStorage, s.serviceId, reloaded) }()
+	if len(s.flag) == 0 {
+		return nil
+	}
+
+	value, err := s.dc.GetVariables().Rendering(s.value)
+	if err != nil {
+		return err
+	}
+
+	var success bool
+	var out string
+	(&step.Curl{
+		Url: fmt.Sprintf(URL_BRPC_SET_FLAG, s.dc.GetListenIp(), s.dc.GetDingoServerPort(),
+			s.flag, url.QueryEscape(value)),
+		Silent:      true,
+		Success:     &success,
+		Out:         &out,
+		ExecOptions: s.execOptions,
+	}).Execute(ctx)
+	reloaded = success && strings.Contains(out, SIGNATURE_FLAG_SET)
+	return nil
+}
+
+func NewSetRuntimeFlagTask(dingoadm *cli.DingoAdm, dc *topology.DeployConfig) (*task.Task, error) {
+	serviceId := dingoadm.GetServiceId(dc.GetId())
+	containerId, err := dingoadm.GetContainerId(serviceId)
+	if dingoadm.IsSkip(dc) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	hc, err := dingoadm.GetHost(dc.GetHost())
+	if err != nil {
+		return nil, err
+	}
+
+	// new task
+	// push the value which the service actually resolves from the updated
+	// topology, instance-level config may override the services-level one
+	key := dingoadm.MemStorage().Get(comm.KEY_CONFIG_ITEM_KEY).(string)
+	flag := GetRuntimeFlagName(dc, key)
+	value, ok := dc.GetServiceConfig()[key]
+	if !ok {
+		flag = ""
+	}
+	subname := fmt.Sprintf("host=%s role=%s containerId=%s key=%s",
+		dc.GetHost(), dc.GetRole(), tui.TrimContainerId(containerId), key)
+	t := task.NewTask("Set Runtime Flag", subname, hc.GetSSHConfig())
+
+	// add step to task
+	t.AddStep(&step2SetRuntimeFlag{
+		dc:          dc,
+		serviceId:   serviceId,
+		flag:        flag,
+		value:       value,
+		memStorage:  dingoadm.MemStorage(),
+		execOptions: dingoadm.ExecOptions(),
+	})
+
+	return t, nil
+}
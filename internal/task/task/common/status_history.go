@@ -0,0 +1,213 @@
+/*
+ *  Copyright (c) 2025 dingodb.com Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: dingoadm
+ * Author: dongwei (jackblack369)
+ */
+
+package common
+
+import (
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/dingodb/dingoadm/internal/storage"
+	"github.com/dingodb/dingoadm/internal/utils"
+	"github.com/dingodb/dingoadm/pkg/log"
+)
+
+const (
+	STATUS_HISTORY_RETENTION = "-30 days"
+
+	STATUS_HISTORY_ITEM_STATE   = "state"
+	STATUS_HISTORY_ITEM_RESTART = "restart"
+	STATUS_HISTORY_ITEM_LEADER  = "leader"
+
+	SERVICE_LEADER   = "leader"
+	SERVICE_FOLLOWER = "follower"
+)
+
+type (
+	StatusTransition struct {
+		Time      time.Time `json:"time" yaml:"time"`
+		ServiceId string    `json:"service_id" yaml:"service_id"`
+		Role      string    `json:"role" yaml:"role"`
+		Host      string    `json:"host" yaml:"host"`
+		Item      string    `json:"item" yaml:"item"`
+		From      string    `json:"from" yaml:"from"`
+		To        string    `json:"to" yaml:"to"`
+	}
+
+	FlapSummary struct {
+		ServiceId     string `json:"service_id" yaml:"service_id"`
+		Role          string `json:"role" yaml:"role"`
+		Host          string `json:"host" yaml:"host"`
+		State         string `json:"state" yaml:"state"`
+		Restarts      int    `json:"restarts" yaml:"restarts"`
+		StateChanges  int    `json:"state_changes" yaml:"state_changes"`
+		LeaderChanges int    `json:"leader_changes" yaml:"leader_changes"`
+		Flapping      bool   `json:"flapping" yaml:"flapping"`
+	}
+)
+
+// returns "leader" or "follower" for the role whose leader is known, otherwise empty
+func getLeaderRole(known, isLeader bool) string {
+	if !known {
+		return ""
+	}
+	return map[bool]string{true: SERVICE_LEADER, false: SERVICE_FOLLOWER}[isLeader]
+}
+
+/*
+ * RecordStatusHistory records status of service only when it changed since last record,
+ * the history is best effort, so error is logged but not returned
+ */
+func RecordStatusHistory(s *storage.Storage, h storage.StatusHistory) {
+	var err error
+	defer func() {
+		log.SwitchLevel(err)("Record status history",
+			log.Field("ServiceId", h.ServiceId),
+			log.Field("State", h.State),
+			log.Field("RestartCount", h.RestartCount),
+			log.Field("Leader", h.Leader))
+	}()
+
+	histories, err := s.GetLastStatusHistory(h.ServiceId)
+	if err != nil {
+		return
+	} else if len(histories) > 0 {
+		last := histories[0]
+		if len(h.Leader) == 0 && h.State == last.State { // leader unknown, e.g. recorded by health check
+			h.Leader = last.Leader
+		}
+		if last.State == h.State && last.RestartCount == h.RestartCount && last.Leader == h.Leader {
+			return
+		}
+	}
+
+	if err = s.InsertStatusHistory(h); err != nil {
+		return
+	}
+	err = s.DeleteStatusHistory(STATUS_HISTORY_RETENTION)
+}
+
+func groupStatusHistory(histories []storage.StatusHistory) ([]string, map[string][]storage.StatusHistory) {
+	ids := []string{}
+	m := map[string][]storage.StatusHistory{}
+	for _, h := range histories {
+		if _, ok := m[h.ServiceId]; !ok {
+			ids = append(ids, h.ServiceId)
+		}
+		m[h.ServiceId] = append(m[h.ServiceId], h)
+	}
+	for _, id := range ids {
+		sort.SliceStable(m[id], func(i, j int) bool {
+			return m[id][i].RecordTime.Before(m[id][j].RecordTime)
+		})
+	}
+	sort.Strings(ids)
+	return ids, m
+}
+
+// restart count is reset when container recreated, the new count is the number of restarts
+func countRestarts(from, to int) int {
+	if to >= from {
+		return to - from
+	}
+	return to
+}
+
+/*
+ * GetStatusTransitions returns what changed between consecutive records of each service,
+ * the first record of service is compared with nothing, so it's not a transition
+ */
+func GetStatusTransitions(histories []storage.StatusHistory) []StatusTransition {
+	transitions := []StatusTransition{}
+	ids, m := groupStatusHistory(histories)
+	for _, id := range ids {
+		records := m[id]
+		for i := 1; i < len(records); i++ {
+			prev, cur := records[i-1], records[i]
+			add := func(item, from, to string) {
+				transitions = append(transitions, StatusTransition{
+					Time:      cur.RecordTime,
+					ServiceId: cur.ServiceId,
+					Role:      cur.Role,
+					Host:      cur.Host,
+					Item:      item,
+					From:      from,
+					To:        to,
+				})
+			}
+			if prev.State != cur.State {
+				add(STATUS_HISTORY_ITEM_STATE, prev.State, cur.State)
+			}
+			if countRestarts(prev.RestartCount, cur.RestartCount) > 0 {
+				add(STATUS_HISTORY_ITEM_RESTART, strconv.Itoa(prev.RestartCount), strconv.Itoa(cur.RestartCount))
+			}
+			if prev.Leader != cur.Leader && len(prev.Leader) > 0 && len(cur.Leader) > 0 {
+				add(STATUS_HISTORY_ITEM_LEADER, prev.Leader, cur.Leader)
+			}
+		}
+	}
+
+	sort.SliceStable(transitions, func(i, j int) bool {
+		return transitions[i].Time.Before(transitions[j].Time)
+	})
+	return transitions
+}
+
+// GetFlapSummaries counts changes of each service, it's flapping if restarts and state changes reach threshold
+func GetFlapSummaries(histories []storage.StatusHistory, threshold int) []FlapSummary {
+	summaries := []FlapSummary{}
+	ids, m := groupStatusHistory(histories)
+	for _, id := range ids {
+		records := m[id]
+		last := records[len(records)-1]
+		summary := FlapSummary{
+			ServiceId: id,
+			Role:      last.Role,
+			Host:      last.Host,
+			State:     last.State,
+		}
+		for i := 1; i < len(records); i++ {
+			prev, cur := records[i-1], records[i]
+			summary.Restarts += countRestarts(prev.RestartCount, cur.RestartCount)
+			if prev.State != cur.State {
+				summary.StateChanges++
+			}
+			if prev.Leader != cur.Leader && len(prev.Leader) > 0 && len(cur.Leader) > 0 {
+				summary.LeaderChanges++
+			}
+		}
+		summary.Flapping = summary.Restarts+summary.StateChanges >= threshold
+		summaries = append(summaries, summary)
+	}
+	return summaries
+}
+
+// FilterStatusHistory returns history of specified services
+func FilterStatusHistory(histories []storage.StatusHistory, ids []string) []storage.StatusHistory {
+	out := []storage.StatusHistory{}
+	for _, h := range histories {
+		if utils.Contains(ids, h.ServiceId) {
+			out = append(out, h)
+		}
+	}
+	return out
+}
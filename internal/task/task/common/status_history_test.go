@@ -0,0 +1,70 @@
+package common
+
+import (
+	"testing"
+	"time"
+
+	"github.com/dingodb/dingoadm/internal/storage"
+	"github.com/stretchr/testify/assert"
+)
+
+func newStatusHistory(id, state string, restartCount int, leader string, minute int) storage.StatusHistory {
+	return storage.StatusHistory{
+		ServiceId:    id,
+		Role:         "mds",
+		Host:         "server-1",
+		State:        state,
+		RestartCount: restartCount,
+		Leader:       leader,
+		RecordTime:   time.Date(2025, 1, 1, 12, minute, 0, 0, time.Local),
+	}
+}
+
+func TestGetStatusTransitions(t *testing.T) {
+	assert := assert.New(t)
+	histories := []storage.StatusHistory{
+		newStatusHistory("s1", "running", 0, "leader", 0),
+		newStatusHistory("s2", "running", 0, "", 1),
+		newStatusHistory("s1", "exited", 0, "", 2),
+		newStatusHistory("s1", "running", 1, "follower", 3),
+		newStatusHistory("s1", "running", 1, "leader", 4),
+	}
+
+	transitions := GetStatusTransitions(histories)
+	assert.Equal(4, len(transitions))
+	assert.Equal([]string{"state", "state", "restart", "leader"}, []string{
+		transitions[0].Item, transitions[1].Item, transitions[2].Item, transitions[3].Item})
+	assert.Equal("running", transitions[0].From)
+	assert.Equal("exited", transitions[0].To)
+	assert.Equal("0", transitions[2].From)
+	assert.Equal("1", transitions[2].To)
+	assert.Equal("follower", transitions[3].From)
+	assert.Equal("leader", transitions[3].To)
+
+	assert.Empty(GetStatusTransitions([]storage.StatusHistory{}))
+}
+
+func TestGetFlapSummaries(t *testing.T) {
+	assert := assert.New(t)
+	histories := []storage.StatusHistory{
+		newStatusHistory("s1", "running", 3, "", 0),
+		newStatusHistory("s1", "restarting", 4, "", 1),
+		newStatusHistory("s1", "running", 5, "", 2),
+		newStatusHistory("s1", "running", 1, "", 3), // container recreated
+		newStatusHistory("s2", "running", 0, "", 0),
+	}
+
+	summaries := GetFlapSummaries(histories, 3)
+	assert.Equal(2, len(summaries))
+	assert.Equal(FlapSummary{
+		ServiceId:    "s1",
+		Role:         "mds",
+		Host:         "server-1",
+		State:        "running",
+		Restarts:     3,
+		StateChanges: 2,
+		Flapping:     true,
+	}, summaries[0])
+	assert.Equal("s2", summaries[1].ServiceId)
+	assert.False(summaries[1].Flapping)
+}
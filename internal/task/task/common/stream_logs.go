@@ -0,0 +1,289 @@
+/*
+ *  Copyright (c) 2025 dingodb.com Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: dingoadm
+ * Author: dongwei (jackblack369)
+ */
+
+package common
+
+import (
+	"bytes"
+	gocontext "context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/dingodb/dingoadm/cli/cli"
+	comm "github.com/dingodb/dingoadm/internal/common"
+	"github.com/dingodb/dingoadm/internal/configure/topology"
+	"github.com/dingodb/dingoadm/internal/errno"
+	"github.com/dingodb/dingoadm/internal/task/context"
+	"github.com/dingodb/dingoadm/internal/task/task"
+	tui "github.com/dingodb/dingoadm/internal/tui/common"
+	"github.com/dingodb/dingoadm/pkg/module"
+)
+
+const (
+	LOG_SOURCE_CONTAINER = "container"
+	LOG_SOURCE_FILE      = "file"
+	LOG_SOURCE_ALL       = "all"
+
+	COMMAND_TAIL_LOG_FILES = "bash -c 'shopt -s nullglob; files=(%s); [ ${#files[@]} -eq 0 ] || tail -q %s ${files[@]}'"
+)
+
+var (
+	// glog, e.g. "I20250101 12:00:00.123456 ..." or "I0101 12:00:00.123456 ..."
+	REGEX_GLOG_TIME = regexp.MustCompile(`^[IWEF](\d{4})?(\d{4}) (\d{2}:\d{2}:\d{2}(\.\d+)?)`)
+	// e.g. "2025-01-01 12:00:00.123 ...", "[2025/01/01 12:00:00,123] ..."
+	REGEX_DATE_TIME = regexp.MustCompile(`^\[?(\d{4})[-/](\d{2})[-/](\d{2})[ T](\d{2}:\d{2}:\d{2})([.,]\d+)?`)
+)
+
+type (
+	LogOptions struct {
+		Context gocontext.Context
+		Lines   chan<- LogLine
+		Source  string
+		Files   []string // glob patterns under log dir
+		Since   time.Time
+		Tail    int // -1 means all
+		Follow  bool
+	}
+
+	LogLine struct {
+		Prefix string
+		Time   time.Time
+		Text   string
+	}
+
+	// logWriter splits output into lines and sends them to channel,
+	// the line without timestamp (e.g. stack trace) inherits the previous one
+	logWriter struct {
+		mutex  sync.Mutex
+		prefix string
+		lines  chan<- LogLine
+		buffer bytes.Buffer
+		last   time.Time
+	}
+
+	step2StreamLogs struct {
+		dc          *topology.DeployConfig
+		containerId string
+		prefix      string
+		options     LogOptions
+		execOptions module.ExecOptions
+	}
+)
+
+// ParseLogTime parses the leading timestamp of docker (--timestamps), glog and common log formats
+func ParseLogTime(line string, now time.Time) (time.Time, bool) {
+	if i := strings.IndexByte(line, ' '); i > 0 {
+		if t, err := time.Parse(time.RFC3339Nano, line[:i]); err == nil {
+			return t, true
+		}
+	}
+
+	if mu := REGEX_GLOG_TIME.FindStringSubmatch(line); len(mu) > 0 {
+		year := mu[1]
+		if len(year) == 0 {
+			year = strconv.Itoa(now.Year())
+		}
+		value := fmt.Sprintf("%s%s %s", year, mu[2], mu[3])
+		if t, err := time.ParseInLocation("20060102 15:04:05.999999999", value, time.Local); err == nil {
+			return t, true
+		}
+	}
+
+	if mu := REGEX_DATE_TIME.FindStringSubmatch(line); len(mu) > 0 {
+		value := fmt.Sprintf("%s-%s-%s %s%s", mu[1], mu[2], mu[3], mu[4], strings.Replace(mu[5], ",", ".", 1))
+		if t, err := time.ParseInLocation("2006-01-02 15:04:05.999999999", value, time.Local); err == nil {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// ParseSince parses relative duration (e.g. 10m) or absolute time (e.g. 2025-01-01 12:00:00)
+func ParseSince(since string, now time.Time) (time.Time, error) {
+	if d, err := time.ParseDuration(since); err == nil {
+		return now.Add(-d), nil
+	}
+	for _, layout := range []string{time.RFC3339, "2006-01-02 15:04:05", "2006-01-02T15:04:05", "2006-01-02"} {
+		if t, err := time.ParseInLocation(layout, since, time.Local); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, errno.ERR_INVALID_LOG_SINCE.F("since: %s", since)
+}
+
+// SortLogLines sorts lines by timestamp, the order of lines with same timestamp is kept
+func SortLogLines(lines []LogLine) {
+	sort.SliceStable(lines, func(i, j int) bool {
+		return lines[i].Time.Before(lines[j].Time)
+	})
+}
+
+func newLogWriter(prefix string, lines chan<- LogLine) *logWriter {
+	return &logWriter{prefix: prefix, lines: lines}
+}
+
+func (w *logWriter) send(text string) {
+	if t, ok := ParseLogTime(text, time.Now()); ok {
+		w.last = t
+	}
+	w.lines <- LogLine{Prefix: w.prefix, Time: w.last, Text: text}
+}
+
+func (w *logWriter) Write(p []byte) (int, error) {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	w.buffer.Write(p)
+	for {
+		line, err := w.buffer.ReadString('\n')
+		if err != nil { // incomplete line, wait for next write
+			w.buffer.Reset()
+			w.buffer.WriteString(line)
+			return len(p), nil
+		}
+		w.send(strings.TrimSuffix(line, "\n"))
+	}
+}
+
+func (w *logWriter) Flush() {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	if w.buffer.Len() > 0 {
+		w.send(w.buffer.String())
+		w.buffer.Reset()
+	}
+}
+
+func (s *step2StreamLogs) tailOption() string {
+	if s.options.Tail < 0 {
+		return "all"
+	}
+	return strconv.Itoa(s.options.Tail)
+}
+
+func (s *step2StreamLogs) streamContainerLogs(ctx *context.Context, w *logWriter) error {
+	cli := ctx.Module().DockerCli().ContainerLogs(s.containerId)
+	cli.AddOption("--timestamps")
+	cli.AddOption("--tail %s", s.tailOption())
+	if !s.options.Since.IsZero() {
+		cli.AddOption("--since %d", s.options.Since.Unix())
+	}
+	if s.options.Follow {
+		cli.AddOption("--follow")
+	}
+	err := cli.Stream(s.options.Context, s.execOptions, w)
+	if err != nil {
+		return errno.ERR_GET_CONTAINER_LOGS_FAILED.E(err)
+	}
+	return nil
+}
+
+func (s *step2StreamLogs) streamLogFiles(ctx *context.Context, w *logWriter) error {
+	files := []string{}
+	for _, pattern := range s.options.Files {
+		files = append(files, fmt.Sprintf("%s/%s", s.dc.GetLogDir(), pattern))
+	}
+	options := fmt.Sprintf("-n %s", strings.Replace(s.tailOption(), "all", "+1", 1))
+	if s.options.Follow {
+		options += " -F"
+	}
+	command := fmt.Sprintf(COMMAND_TAIL_LOG_FILES, strings.Join(files, " "), options)
+	err := ctx.Module().Shell().Command(command).Stream(s.options.Context, s.execOptions, w)
+	if err != nil {
+		return errno.ERR_RUN_A_BASH_COMMAND_FAILED.E(err)
+	}
+	return nil
+}
+
+func (s *step2StreamLogs) Execute(ctx *context.Context) error {
+	sources := []func(*context.Context, *logWriter) error{}
+	if s.options.Source != LOG_SOURCE_FILE && s.containerId != comm.CLEANED_CONTAINER_ID {
+		sources = append(sources, s.streamContainerLogs)
+	}
+	if s.options.Source != LOG_SOURCE_CONTAINER {
+		sources = append(sources, s.streamLogFiles)
+	}
+
+	// container logs and log files are streamed at the same time for follow
+	var wg sync.WaitGroup
+	errs := make([]error, len(sources))
+	for i, source := range sources {
+		wg.Add(1)
+		go func(i int, source func(*context.Context, *logWriter) error) {
+			defer wg.Done()
+			w := newLogWriter(s.prefix, s.options.Lines)
+			errs[i] = source(ctx, w)
+			w.Flush()
+		}(i, source)
+	}
+	wg.Wait()
+
+	for _, err := range errs {
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// the name (host sequence if not specified) distinguishes services which
+// deployed on the same host by different deploy items
+func GetLogPrefix(dc *topology.DeployConfig) string {
+	return fmt.Sprintf("%s/%s/%s/%d", dc.GetRole(), dc.GetHost(), dc.GetName(), dc.GetInstancesSequence())
+}
+
+func NewStreamLogsTask(dingoadm *cli.DingoAdm, dc *topology.DeployConfig) (*task.Task, error) {
+	serviceId := dingoadm.GetServiceId(dc.GetId())
+	containerId, err := dingoadm.GetContainerId(serviceId)
+	if dingoadm.IsSkip(dc) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	hc, err := dingoadm.GetHost(dc.GetHost())
+	if err != nil {
+		return nil, err
+	}
+
+	// new task
+	options := dingoadm.MemStorage().Get(comm.KEY_LOG_OPTIONS).(LogOptions)
+	if containerId == comm.CLEANED_CONTAINER_ID && options.Source == LOG_SOURCE_CONTAINER {
+		return nil, nil
+	}
+	subname := fmt.Sprintf("host=%s role=%s containerId=%s",
+		dc.GetHost(), dc.GetRole(), tui.TrimContainerId(containerId))
+	t := task.NewTask("Stream Logs", subname, hc.GetSSHConfig())
+
+	// add step to task
+	t.AddStep(&step2StreamLogs{
+		dc:          dc,
+		containerId: containerId,
+		prefix:      GetLogPrefix(dc),
+		options:     options,
+		execOptions: dingoadm.ExecOptions(),
+	})
+
+	return t, nil
+}
@@ -0,0 +1,97 @@
+package common
+
+import (
+	"testing"
+	"time"
+
+	"github.com/dingodb/dingoadm/internal/configure/topology"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseLogTime(t *testing.T) {
+	assert := assert.New(t)
+	now := time.Date(2025, 3, 1, 0, 0, 0, 0, time.Local)
+
+	tests := []struct {
+		line string
+		ok   bool
+		want time.Time
+	}{
+		{"2025-01-02T03:04:05.000000006Z hello", true, time.Date(2025, 1, 2, 3, 4, 5, 6, time.UTC)},
+		{"I20250102 03:04:05.123456 1234 server.cc:10] started", true, time.Date(2025, 1, 2, 3, 4, 5, 123456000, time.Local)},
+		{"E0102 03:04:05.5 1234 server.cc:10] failed", true, time.Date(2025, 1, 2, 3, 4, 5, 500000000, time.Local)},
+		{"2025-01-02 03:04:05.789 INFO started", true, time.Date(2025, 1, 2, 3, 4, 5, 789000000, time.Local)},
+		{"[2025/01/02 03:04:05,100] WARN slow", true, time.Date(2025, 1, 2, 3, 4, 5, 100000000, time.Local)},
+		{"    at com.dingodb.Main.main(Main.java:10)", false, time.Time{}},
+		{"", false, time.Time{}},
+	}
+	for _, tt := range tests {
+		got, ok := ParseLogTime(tt.line, now)
+		assert.Equal(tt.ok, ok, tt.line)
+		assert.True(tt.want.Equal(got), tt.line)
+	}
+}
+
+func TestParseSince(t *testing.T) {
+	assert := assert.New(t)
+	now := time.Date(2025, 3, 1, 12, 0, 0, 0, time.Local)
+
+	since, err := ParseSince("10m", now)
+	assert.Nil(err)
+	assert.Equal(now.Add(-10*time.Minute), since)
+
+	since, err = ParseSince("2025-01-02 03:04:05", now)
+	assert.Nil(err)
+	assert.Equal(time.Date(2025, 1, 2, 3, 4, 5, 0, time.Local), since)
+
+	since, err = ParseSince("2025-01-02", now)
+	assert.Nil(err)
+	assert.Equal(time.Date(2025, 1, 2, 0, 0, 0, 0, time.Local), since)
+
+	_, err = ParseSince("yesterday", now)
+	assert.NotNil(err)
+}
+
+func TestSortLogLines(t *testing.T) {
+	t1 := time.Date(2025, 1, 1, 0, 0, 1, 0, time.UTC)
+	t2 := time.Date(2025, 1, 1, 0, 0, 2, 0, time.UTC)
+	lines := []LogLine{
+		{Prefix: "store/a/0", Time: t2, Text: "a2"},
+		{Prefix: "store/b/0", Time: t1, Text: "b1"},
+		{Prefix: "store/a/0", Time: t2, Text: "a2 trace"},
+		{Prefix: "store/b/0", Time: t1, Text: "b1 trace"},
+	}
+	SortLogLines(lines)
+
+	texts := []string{}
+	for _, line := range lines {
+		texts = append(texts, line.Text)
+	}
+	assert.Equal(t, []string{"b1", "b1 trace", "a2", "a2 trace"}, texts)
+}
+
+func TestGetLogPrefix(t *testing.T) {
+	assert := assert.New(t)
+
+	data := `
+kind: dingo-store
+coordinator_services:
+  deploy:
+    - host: host1
+store_services:
+  deploy:
+    - host: host1
+    - host: host1
+    - host: host1
+      name: store-ssd
+`
+	ctx := topology.NewContext()
+	ctx.Add("host1", "host1")
+	dcs, err := topology.ParseTopology(data, ctx)
+	assert.Nil(err)
+
+	// services on the same host never share a prefix
+	assert.Equal("store/host1/0/0", GetLogPrefix(dcs[1]))
+	assert.Equal("store/host1/1/0", GetLogPrefix(dcs[2]))
+	assert.Equal("store/host1/store-ssd/0", GetLogPrefix(dcs[3]))
+}
@@ -96,6 +96,16 @@ func NewMutate(dc *topology.DeployConfig, delimiter string, forceRender bool) st
 	}
 }
 
+// GetConfigDelimiter returns the delimiter between key and value in service config files
+func GetConfigDelimiter(dc *topology.DeployConfig) string {
+	role := dc.GetRole()
+	if role == topology.ROLE_ETCD || role == topology.ROLE_DINGODB_EXECUTOR ||
+		role == topology.ROLE_DINGODB_WEB || role == topology.ROLE_DINGODB_PROXY {
+		return CONFIG_DELIMITER_COLON
+	}
+	return CONFIG_DELIMITER_ASSIGN
+}
+
 func newCrontab(uuid string, dc *topology.DeployConfig, reportScriptPath string) string {
 	var period, command string
 	if dc.GetReportUsage() == true {
@@ -154,13 +164,7 @@ func NewSyncConfigTask(dingoadm *cli.DingoAdm, dc *topology.DeployConfig) (*task
 	// add step to task
 	var out string
 	layout := dc.GetProjectLayout()
-	role := dc.GetRole()
-
-	delimiter := CONFIG_DELIMITER_ASSIGN
-	if role == topology.ROLE_ETCD || role == topology.ROLE_DINGODB_EXECUTOR ||
-		role == topology.ROLE_DINGODB_WEB || role == topology.ROLE_DINGODB_PROXY {
-		delimiter = CONFIG_DELIMITER_COLON
-	}
+	delimiter := GetConfigDelimiter(dc)
 
 	t.AddStep(&step.ListContainers{ // gurantee container exist
 		ShowAll:     true,
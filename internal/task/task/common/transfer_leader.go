@@ -0,0 +1,262 @@
+/*
+ *  Copyright (c) 2025 dingodb.com Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: dingoadm
+ * Author: dongwei (jackblack369)
+ */
+
+package common
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/dingodb/dingoadm/cli/cli"
+	comm "github.com/dingodb/dingoadm/internal/common"
+	"github.com/dingodb/dingoadm/internal/configure/topology"
+	"github.com/dingodb/dingoadm/internal/errno"
+	"github.com/dingodb/dingoadm/internal/task/context"
+	"github.com/dingodb/dingoadm/internal/task/scripts"
+	"github.com/dingodb/dingoadm/internal/task/step"
+	"github.com/dingodb/dingoadm/internal/task/task"
+	tui "github.com/dingodb/dingoadm/internal/tui/common"
+	"github.com/dingodb/dingoadm/pkg/log"
+	"github.com/dingodb/dingoadm/pkg/module"
+)
+
+// return the other deployed services which have same role with dc and may take
+// over its leaders, services in KEY_LEADER_EXCLUDE_CONFIGS are operated together
+// with dc, so they are excluded
+func getRolePeers(dingoadm *cli.DingoAdm, dc *topology.DeployConfig) []*topology.DeployConfig {
+	v := dingoadm.MemStorage().Get(comm.KEY_ALL_DEPLOY_CONFIGS)
+	if v == nil {
+		return nil
+	}
+	excludes := map[string]bool{}
+	for _, exclude := range getLeaderExcludes(dingoadm) {
+		excludes[exclude.GetId()] = true
+	}
+
+	peers := []*topology.DeployConfig{}
+	for _, peer := range dingoadm.FilterDeployConfigByRole(v.([]*topology.DeployConfig), dc.GetRole()) {
+		if peer.GetId() == dc.GetId() || excludes[peer.GetId()] {
+			continue
+		}
+		containerId, err := dingoadm.GetContainerId(dingoadm.GetServiceId(peer.GetId()))
+		if err == nil && len(containerId) > 0 && containerId != comm.CLEANED_CONTAINER_ID {
+			peers = append(peers, peer)
+		}
+	}
+	return peers
+}
+
+func getLeaderExcludes(dingoadm *cli.DingoAdm) []*topology.DeployConfig {
+	v := dingoadm.MemStorage().Get(comm.KEY_LEADER_EXCLUDE_CONFIGS)
+	if v == nil {
+		return nil
+	}
+	return v.([]*topology.DeployConfig)
+}
+
+// return store instance ids which must not take over leaders, joined by comma
+func getExcludeInstanceIds(dc *topology.DeployConfig, excludes []*topology.DeployConfig) string {
+	ids := []string{}
+	for _, exclude := range excludes {
+		if exclude.GetId() != dc.GetId() && exclude.GetRole() == dc.GetRole() {
+			ids = append(ids, strconv.Itoa(exclude.GetDingoInstanceId()))
+		}
+	}
+	return strings.Join(ids, ",")
+}
+
+type step2TransferLeader struct {
+	dc          *topology.DeployConfig
+	containerId *string
+	scriptPath  string
+	peers       []*topology.DeployConfig
+	excludes    []*topology.DeployConfig
+	execOptions module.ExecOptions
+}
+
+func (s *step2TransferLeader) isRunning(ctx *context.Context, dc *topology.DeployConfig) bool {
+	var success bool
+	var out string
+	(&step.Curl{
+		Url:         fmt.Sprintf(URL_BRPC_HEALTH, dc.GetListenIp(), dc.GetDingoServerPort()),
+		Silent:      true,
+		Success:     &success,
+		Out:         &out,
+		ExecOptions: s.execOptions,
+	}).Execute(ctx)
+	return success && strings.Contains(out, "OK")
+}
+
+// the peer container may be stopped although it recorded in database,
+// so the leaders are only transferred to peers which are actually running
+func (s *step2TransferLeader) Execute(ctx *context.Context) error {
+	dc := s.dc
+	running := []*topology.DeployConfig{}
+	excludes := s.excludes
+	for _, peer := range s.peers {
+		if s.isRunning(ctx, peer) {
+			running = append(running, peer)
+		} else {
+			excludes = append(excludes, peer)
+		}
+	}
+	if len(running) == 0 {
+		log.Warn("No running peer to take over leaders",
+			log.Field("role", dc.GetRole()),
+			log.Field("host", dc.GetHost()))
+		return nil
+	}
+
+	var command string
+	switch dc.GetRole() {
+	case topology.ROLE_COORDINATOR:
+		command = fmt.Sprintf("bash %s --role=coordinator --peer=%s:%d --new_peer=%s:%d", s.scriptPath,
+			dc.GetListenIp(), dc.GetDingoStoreRaftPort(),
+			running[0].GetListenIp(), running[0].GetDingoStoreRaftPort())
+	case topology.ROLE_STORE:
+		command = fmt.Sprintf("bash %s --role=store --instance_id=%d --exclude=%s", s.scriptPath,
+			dc.GetDingoInstanceId(), getExcludeInstanceIds(dc, excludes))
+	}
+
+	var out string
+	return (&step.ContainerExec{
+		ContainerId: s.containerId,
+		Command:     command,
+		Out:         &out,
+		ExecOptions: s.execOptions,
+	}).Execute(ctx)
+}
+
+/*
+ * transfer leaders away from service before it restarted or stopped:
+ *   coordinator: transfer raft leader to another coordinator
+ *   store:       transfer region leaders to other peers until it holds zero leaders
+ *   mds v2:      leave mds group, its filesystems will be taken over by others,
+ *                and it joins mds group again by heartbeat after restarted
+ *
+ * nothing to transfer if there is no other service of same role, and the
+ * services in KEY_LEADER_EXCLUDE_CONFIGS never take over the leaders.
+ * if KEY_LEADER_BALANCE is set, region leaders are balanced among stores instead.
+ */
+func NewTransferLeaderTask(dingoadm *cli.DingoAdm, dc *topology.DeployConfig) (*task.Task, error) {
+	serviceId := dingoadm.GetServiceId(dc.GetId())
+	containerId, err := dingoadm.GetContainerId(serviceId)
+	if dingoadm.IsSkip(dc) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	} else if len(containerId) == 0 || containerId == comm.CLEANED_CONTAINER_ID {
+		return nil, nil
+	}
+
+	balance := false
+	if v := dingoadm.MemStorage().Get(comm.KEY_LEADER_BALANCE); v != nil {
+		balance = v.(bool)
+	}
+	peers := getRolePeers(dingoadm, dc)
+	if len(peers) == 0 {
+		return nil, nil
+	}
+
+	role := dc.GetRole()
+	host := dc.GetHost()
+	layout := dc.GetProjectLayout()
+	var script, scriptPath, command string
+	switch {
+	case role == topology.ROLE_COORDINATOR:
+		script = scripts.TRANSFER_LEADER
+		scriptPath = fmt.Sprintf("%s/%s", layout.DingoStoreScriptDir, topology.SCRIPT_TRANSFER_LEADER)
+	case role == topology.ROLE_STORE && balance:
+		script = scripts.TRANSFER_LEADER
+		scriptPath = fmt.Sprintf("%s/%s", layout.DingoStoreScriptDir, topology.SCRIPT_TRANSFER_LEADER)
+		command = fmt.Sprintf("bash %s --role=store --balance", scriptPath)
+	case role == topology.ROLE_STORE:
+		script = scripts.TRANSFER_LEADER
+		scriptPath = fmt.Sprintf("%s/%s", layout.DingoStoreScriptDir, topology.SCRIPT_TRANSFER_LEADER)
+	case role == topology.ROLE_FS_MDS &&
+		dc.GetCtx().Lookup(topology.CTX_KEY_MDS_VERSION) == topology.CTX_VAL_MDS_V2:
+		mdsCli, id := getMdsv2ClientContainer(dingoadm)
+		if mdsCli == nil {
+			return nil, errno.ERR_NO_MDSV2_CLIENT_FOR_TRANSFER_LEADER
+		}
+		containerId = id
+		host = mdsCli.GetHost()
+		layout = mdsCli.GetProjectLayout()
+		script = scripts.DRAIN_MDSV2
+		scriptPath = fmt.Sprintf("%s/%s", layout.FSMdsCliBinDir, topology.SCRIPT_DRAIN_MDSV2)
+		command = fmt.Sprintf("bash %s %s %d", scriptPath, layout.FSMdsCliBinaryPath, dc.GetDingoInstanceId())
+	default:
+		return nil, nil
+	}
+
+	hc, err := dingoadm.GetHost(host)
+	if err != nil {
+		return nil, err
+	}
+
+	// new task
+	subname := fmt.Sprintf("host=%s role=%s containerId=%s",
+		dc.GetHost(), dc.GetRole(), tui.TrimContainerId(containerId))
+	name := "Transfer Leader"
+	if balance {
+		name = "Balance Leader"
+	}
+	t := task.NewTask(name, subname, hc.GetSSHConfig())
+
+	// add step to task
+	var out string
+	t.AddStep(&step.ListContainers{
+		ShowAll:     true,
+		Format:      `"{{.ID}}"`,
+		Filter:      fmt.Sprintf("id=%s", containerId),
+		Out:         &out,
+		ExecOptions: dingoadm.ExecOptions(),
+	})
+	t.AddStep(&step.Lambda{
+		Lambda: CheckContainerExist(host, dc.GetRole(), containerId, &out),
+	})
+	t.AddStep(&step.InstallFile{
+		ContainerId:       &containerId,
+		ContainerDestPath: scriptPath,
+		Content:           &script,
+		ExecOptions:       dingoadm.ExecOptions(),
+	})
+	if len(command) > 0 {
+		t.AddStep(&step.ContainerExec{
+			ContainerId: &containerId,
+			Command:     command,
+			Out:         &out,
+			ExecOptions: dingoadm.ExecOptions(),
+		})
+	} else {
+		t.AddStep(&step2TransferLeader{
+			dc:          dc,
+			containerId: &containerId,
+			scriptPath:  scriptPath,
+			peers:       peers,
+			excludes:    getLeaderExcludes(dingoadm),
+			execOptions: dingoadm.ExecOptions(),
+		})
+	}
+
+	return t, nil
+}
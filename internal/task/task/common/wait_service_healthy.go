@@ -0,0 +1,87 @@
+/*
+ *  Copyright (c) 2025 dingodb.com Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: dingoadm
+ * Author: dongwei (jackblack369)
+ */
+
+package common
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/dingodb/dingoadm/cli/cli"
+	"github.com/dingodb/dingoadm/internal/configure/topology"
+	"github.com/dingodb/dingoadm/internal/errno"
+	"github.com/dingodb/dingoadm/internal/task/context"
+	"github.com/dingodb/dingoadm/internal/task/task"
+	tui "github.com/dingodb/dingoadm/internal/tui/common"
+	"github.com/dingodb/dingoadm/pkg/module"
+)
+
+const (
+	WAIT_HEALTHY_INTERVAL = 2 * time.Second
+	WAIT_HEALTHY_TIMEOUT  = 5 * time.Minute
+)
+
+type step2WaitServiceHealthy struct {
+	dc          *topology.DeployConfig
+	execOptions module.ExecOptions
+}
+
+func (s *step2WaitServiceHealthy) Execute(ctx *context.Context) error {
+	dc := s.dc
+	deadline := time.Now().Add(WAIT_HEALTHY_TIMEOUT)
+	for time.Now().Before(deadline) {
+		if checkBrpcHealth(ctx, dc, s.execOptions) {
+			return nil
+		}
+		time.Sleep(WAIT_HEALTHY_INTERVAL)
+	}
+	return errno.ERR_WAIT_SERVICE_HEALTHY_TIMEOUT.
+		F("%s.host[%s]: port %d not healthy in %s", dc.GetRole(), dc.GetHost(), getBrpcPort(dc), WAIT_HEALTHY_TIMEOUT)
+}
+
+// wait the restarted service healthy by its brpc health endpoint, e.g. before
+// transferring leaders of the next service to it in rolling restart
+func NewWaitServiceHealthyTask(dingoadm *cli.DingoAdm, dc *topology.DeployConfig) (*task.Task, error) {
+	serviceId := dingoadm.GetServiceId(dc.GetId())
+	containerId, err := dingoadm.GetContainerId(serviceId)
+	if dingoadm.IsSkip(dc) || !hasBrpcHealth(dc) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	hc, err := dingoadm.GetHost(dc.GetHost())
+	if err != nil {
+		return nil, err
+	}
+
+	// new task
+	subname := fmt.Sprintf("host=%s role=%s containerId=%s",
+		dc.GetHost(), dc.GetRole(), tui.TrimContainerId(containerId))
+	t := task.NewTask("Wait Service Healthy", subname, hc.GetSSHConfig())
+
+	// add step to task
+	t.AddStep(&step2WaitServiceHealthy{
+		dc:          dc,
+		execOptions: dingoadm.ExecOptions(),
+	})
+
+	return t, nil
+}
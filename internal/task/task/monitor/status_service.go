@@ -58,15 +58,15 @@ type step2FormatMonitorStatus struct {
 }
 
 type MonitorStatus struct {
-	Id           string
-	Role         string
-	Host         string
-	HostSequence int
-	ContainerId  string
-	Ports        string
-	Status       string
-	DataDir      string
-	Config       *configure.MonitorConfig
+	Id           string                   `json:"id" yaml:"id"`
+	Role         string                   `json:"role" yaml:"role"`
+	Host         string                   `json:"host" yaml:"host"`
+	HostSequence int                      `json:"host_sequence" yaml:"host_sequence"`
+	ContainerId  string                   `json:"container_id" yaml:"container_id"`
+	Ports        string                   `json:"ports" yaml:"ports"`
+	Status       string                   `json:"status" yaml:"status"`
+	DataDir      string                   `json:"data_dir" yaml:"data_dir"`
+	Config       *configure.MonitorConfig `json:"-" yaml:"-"`
 }
 
 func setMonitorStatus(memStorage *utils.SafeMap, id string, status MonitorStatus) {
@@ -46,10 +46,10 @@ type (
 	}
 
 	PlaygroundStatus struct {
-		Id         string
-		Name       string
-		CreateTime string
-		Status     string
+		Id         string `json:"id" yaml:"id"`
+		Name       string `json:"name" yaml:"name"`
+		CreateTime string `json:"create_time" yaml:"create_time"`
+		Status     string `json:"status" yaml:"status"`
 	}
 )
 
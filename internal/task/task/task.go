@@ -55,6 +55,7 @@ type (
 		steps     []Step
 		postSteps []Step
 		sshConfig *module.SSHConfig
+		sshPool   *module.SSHClientPool
 		context   context.Context
 	}
 )
@@ -98,6 +99,12 @@ func (t *Task) SetSubname(name string) {
 	t.subname = name
 }
 
+// SetSSHClientPool lets task reuse the opened ssh client in pool
+// instead of connecting and closing it for every execution
+func (t *Task) SetSSHClientPool(pool *module.SSHClientPool) {
+	t.sshPool = pool
+}
+
 func (t *Task) AddStep(step Step) {
 	t.steps = append(t.steps, step)
 }
@@ -117,7 +124,13 @@ func (t *Task) executePost(ctx *context.Context) {
 
 func (t *Task) Execute() error {
 	var sshClient *module.SSHClient
-	if t.sshConfig != nil {
+	if t.sshConfig != nil && t.sshPool != nil {
+		client, err := t.sshPool.Get(*t.sshConfig)
+		if err != nil {
+			return errno.ERR_SSH_CONNECT_FAILED.E(err)
+		}
+		sshClient = client
+	} else if t.sshConfig != nil {
 		client, err := module.NewSSHClient(*t.sshConfig)
 		if err != nil {
 			return errno.ERR_SSH_CONNECT_FAILED.E(err)
@@ -129,7 +142,9 @@ func (t *Task) Execute() error {
 	if err != nil {
 		return err
 	}
-	defer ctx.Close()
+	if t.sshPool == nil { // the client in pool is closed by its owner
+		defer ctx.Close()
+	}
 	defer t.executePost(ctx)
 
 	for _, step := range t.steps {
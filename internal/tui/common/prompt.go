@@ -130,6 +130,13 @@ func PromptRemoveCluster(clusterName string) string {
 	return prompt.Build()
 }
 
+func PromptAdoptCluster(clusterName string, services int) string {
+	prompt := NewPrompt(color.YellowString(PROMPT_WARNING) + DEFAULT_CONFIRM_PROMPT)
+	prompt.data["warning"] = fmt.Sprintf("WARNING: cluster '%s' already has %d services,\n"+
+		"their container ids will be replaced by the adopted ones", clusterName, services)
+	return prompt.Build()
+}
+
 func PromptRenameCluster(clusterOldName string, clusterNewName string) string {
 	prompt := NewPrompt(color.YellowString(PROMPT_WARNING) + DEFAULT_CONFIRM_PROMPT)
 	prompt.data["warning"] = fmt.Sprintf("WARNING: cluster '%s' will be renamed to '%s'",
@@ -198,6 +205,13 @@ func PromptReloadService(id, role, host string) string {
 	return prompt.Build()
 }
 
+func PromptFixDrift(ids []string) string {
+	prompt := NewPrompt(color.YellowString(PROMPT_WARNING) + DEFAULT_CONFIRM_PROMPT)
+	prompt.data["warning"] = fmt.Sprintf("WARNING: config of services %s will be synced,\n"+
+		"and these services will be restarted", strings.Join(ids, ","))
+	return prompt.Build()
+}
+
 func PromptCleanService(role, host string, items []string) string {
 	prompt := NewPrompt(color.YellowString(PROMPT_CLEAN_SERVICE) + DEFAULT_CONFIRM_PROMPT)
 	prompt.data["warning"] = "WARNING: service items which matched will be cleaned up"
@@ -0,0 +1,76 @@
+/*
+ *  Copyright (c) 2025 dingodb.com Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: dingoadm
+ * Author: dongwei (jackblack369)
+ */
+
+package common
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+const (
+	ANSI_ENTER_ALT_SCREEN = "\033[?1049h"
+	ANSI_LEAVE_ALT_SCREEN = "\033[?1049l"
+	ANSI_HIDE_CURSOR      = "\033[?25l"
+	ANSI_SHOW_CURSOR      = "\033[?25h"
+	ANSI_CLEAR_SCREEN     = "\033[2J"
+	ANSI_MOVE_CURSOR      = "\033[%d;1H" // row starts from 1
+	ANSI_CLEAR_LINE       = "\033[K"
+)
+
+// Screen is a full-screen terminal which only redraws the changed lines,
+// so the refreshed content never flickers
+type Screen struct {
+	out   io.Writer
+	lines []string
+}
+
+func NewScreen(out io.Writer) *Screen {
+	return &Screen{out: out}
+}
+
+func (s *Screen) Open() {
+	s.lines = nil
+	fmt.Fprint(s.out, ANSI_ENTER_ALT_SCREEN+ANSI_HIDE_CURSOR+ANSI_CLEAR_SCREEN)
+}
+
+func (s *Screen) Close() {
+	fmt.Fprint(s.out, ANSI_SHOW_CURSOR+ANSI_LEAVE_ALT_SCREEN)
+}
+
+// Render draws the lines which differ from last rendering,
+// and clears the lines which no longer exist
+func (s *Screen) Render(content string) {
+	lines := strings.Split(strings.TrimSuffix(content, "\n"), "\n")
+	var sb strings.Builder
+	for i, line := range lines {
+		if i < len(s.lines) && s.lines[i] == line {
+			continue
+		}
+		sb.WriteString(fmt.Sprintf(ANSI_MOVE_CURSOR, i+1) + line + ANSI_CLEAR_LINE)
+	}
+	for i := len(lines); i < len(s.lines); i++ {
+		sb.WriteString(fmt.Sprintf(ANSI_MOVE_CURSOR, i+1) + ANSI_CLEAR_LINE)
+	}
+	s.lines = lines
+	fmt.Fprint(s.out, sb.String())
+}
@@ -0,0 +1,32 @@
+package common
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestScreenRender(t *testing.T) {
+	assert := assert.New(t)
+	out := &bytes.Buffer{}
+	screen := NewScreen(out)
+
+	screen.Render("a\nb\nc\n")
+	assert.Equal("\033[1;1Ha\033[K\033[2;1Hb\033[K\033[3;1Hc\033[K", out.String())
+
+	// only the changed line is redrawn
+	out.Reset()
+	screen.Render("a\nB\nc\n")
+	assert.Equal("\033[2;1HB\033[K", out.String())
+
+	// nothing changed
+	out.Reset()
+	screen.Render("a\nB\nc\n")
+	assert.Equal("", out.String())
+
+	// the disappeared lines are cleared
+	out.Reset()
+	screen.Render("a\n")
+	assert.Equal("\033[2;1H\033[K\033[3;1H\033[K", out.String())
+}
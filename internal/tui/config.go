@@ -0,0 +1,63 @@
+/*
+ *  Copyright (c) 2025 dingodb.com Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: dingoadm
+ * Author: dongwei (jackblack369)
+ */
+
+package tui
+
+import (
+	"github.com/dingodb/dingoadm/internal/configure/topology"
+	tuicommon "github.com/dingodb/dingoadm/internal/tui/common"
+	"github.com/fatih/color"
+)
+
+const (
+	CONFIG_APPLY_RUNTIME = "runtime"
+	CONFIG_APPLY_RESTART = "restart"
+)
+
+func applyPathDecorate(message string) string {
+	if message == CONFIG_APPLY_RUNTIME {
+		return color.GreenString(message)
+	}
+	return color.YellowString(message)
+}
+
+// paths: service id -> the path which config applied by, runtime or restart
+func FormatConfigSet(dcs []*topology.DeployConfig,
+	serviceIds map[string]string,
+	paths map[string]string) string {
+	lines := [][]interface{}{}
+	title := []string{"Id", "Role", "Host", "Applied By"}
+	first, second := tuicommon.FormatTitle(title)
+	lines = append(lines, first)
+	lines = append(lines, second)
+
+	for _, dc := range dcs {
+		id := serviceIds[dc.GetId()]
+		lines = append(lines, []interface{}{
+			id,
+			dc.GetRole(),
+			dc.GetHost(),
+			tuicommon.DecorateMessage{Message: paths[id], Decorate: applyPathDecorate},
+		})
+	}
+
+	return tuicommon.FixedFormat(lines, 2)
+}
@@ -0,0 +1,71 @@
+/*
+ *  Copyright (c) 2025 dingodb.com Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: dingoadm
+ * Author: dongwei (jackblack369)
+ */
+
+package output
+
+import (
+	"encoding/json"
+
+	"github.com/dingodb/dingoadm/internal/errno"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	FORMAT_TABLE = "table"
+	FORMAT_JSON  = "json"
+	FORMAT_YAML  = "yaml"
+
+	FLAG_FORMAT_USAGE = "Output format (table/json/yaml)"
+)
+
+func CheckFormat(format string) error {
+	switch format {
+	case FORMAT_TABLE, FORMAT_JSON, FORMAT_YAML:
+		return nil
+	}
+	return errno.ERR_UNSUPPORT_OUTPUT_FORMAT.F("format: %s", format)
+}
+
+// IsStructured returns true if the output is for machine, the progress
+// bar and other prompt messages should be silenced for it
+func IsStructured(format string) bool {
+	return format == FORMAT_JSON || format == FORMAT_YAML
+}
+
+// Marshal encodes v in json or yaml, the field names come from the
+// struct tags, so they are stable for automation
+func Marshal(format string, v interface{}) (string, error) {
+	var data []byte
+	var err error
+	switch format {
+	case FORMAT_JSON:
+		data, err = json.MarshalIndent(v, "", "  ")
+		data = append(data, '\n')
+	case FORMAT_YAML:
+		data, err = yaml.Marshal(v)
+	default:
+		return "", errno.ERR_UNSUPPORT_OUTPUT_FORMAT.F("format: %s", format)
+	}
+	if err != nil {
+		return "", errno.ERR_ENCODE_OUTPUT_FAILED.E(err)
+	}
+	return string(data), nil
+}
@@ -0,0 +1,39 @@
+package output
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type item struct {
+	Id       string `json:"id" yaml:"id"`
+	IsLeader bool   `json:"is_leader" yaml:"is_leader"`
+	Config   string `json:"-" yaml:"-"`
+}
+
+func TestMarshal(t *testing.T) {
+	assert := assert.New(t)
+	items := []item{{Id: "c1", IsLeader: true, Config: "hidden"}}
+
+	out, err := Marshal(FORMAT_JSON, items)
+	assert.Nil(err)
+	assert.Equal("[\n  {\n    \"id\": \"c1\",\n    \"is_leader\": true\n  }\n]\n", out)
+
+	out, err = Marshal(FORMAT_YAML, items)
+	assert.Nil(err)
+	assert.Equal("- id: c1\n  is_leader: true\n", out)
+
+	_, err = Marshal(FORMAT_TABLE, items)
+	assert.NotNil(err)
+}
+
+func TestCheckFormat(t *testing.T) {
+	assert := assert.New(t)
+	assert.Nil(CheckFormat(FORMAT_TABLE))
+	assert.Nil(CheckFormat(FORMAT_JSON))
+	assert.Nil(CheckFormat(FORMAT_YAML))
+	assert.NotNil(CheckFormat("xml"))
+	assert.True(IsStructured(FORMAT_JSON))
+	assert.False(IsStructured(FORMAT_TABLE))
+}
@@ -0,0 +1,59 @@
+/*
+ *  Copyright (c) 2025 dingodb.com Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: dingoadm
+ * Author: dongwei (jackblack369)
+ */
+
+package tui
+
+import (
+	"sort"
+	"strconv"
+
+	"github.com/dingodb/dingoadm/internal/configure/topology"
+	tuicommon "github.com/dingodb/dingoadm/internal/tui/common"
+	"github.com/dingodb/dingoadm/internal/utils"
+)
+
+func FormatPorts(dcs []*topology.DeployConfig, serviceIds map[string]string) string {
+	lines := [][]interface{}{}
+	title := []string{"Host", "Role", "Id", "Item", "Port"}
+	first, second := tuicommon.FormatTitle(title)
+	lines = append(lines, first)
+	lines = append(lines, second)
+
+	dcs = append([]*topology.DeployConfig{}, dcs...)
+	sort.SliceStable(dcs, func(i, j int) bool {
+		return dcs[i].GetHost() < dcs[j].GetHost()
+	})
+
+	for _, dc := range dcs {
+		id := utils.Choose(len(serviceIds[dc.GetId()]) > 0, serviceIds[dc.GetId()], "-")
+		for _, port := range topology.GetServicePorts(dc) {
+			lines = append(lines, []interface{}{
+				dc.GetHost(),
+				dc.GetRole(),
+				id,
+				port.Name,
+				strconv.Itoa(port.Port),
+			})
+		}
+	}
+
+	return tuicommon.FixedFormat(lines, 2)
+}
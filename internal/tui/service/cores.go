@@ -0,0 +1,50 @@
+/*
+ *  Copyright (c) 2025 dingodb.com Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: dingoadm
+ * Author: dongwei (jackblack369)
+ */
+package service
+
+import (
+	task "github.com/dingodb/dingoadm/internal/task/task/common"
+	tui "github.com/dingodb/dingoadm/internal/tui/common"
+	"github.com/dingodb/dingoadm/internal/utils"
+	"github.com/dustin/go-humanize"
+)
+
+func FormatCores(cores []task.Core) string {
+	lines := [][]interface{}{}
+	title := []string{"Id", "Role", "Host", "Size", "Time", "Binary", "Path"}
+	first, second := tui.FormatTitle(title)
+	lines = append(lines, first)
+	lines = append(lines, second)
+
+	for _, core := range cores {
+		lines = append(lines, []interface{}{
+			core.Id,
+			core.Role,
+			core.Host,
+			humanize.IBytes(uint64(core.Size)),
+			core.Time.Format("2006-01-02 15:04:05"),
+			utils.Choose(len(core.Binary) == 0, "-", core.Binary),
+			core.Path,
+		})
+	}
+
+	return tui.FixedFormat(lines, 2)
+}
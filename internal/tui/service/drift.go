@@ -0,0 +1,79 @@
+/*
+ *  Copyright (c) 2025 dingodb.com Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: dingoadm
+ * Author: dongwei (jackblack369)
+ */
+package service
+
+import (
+	"fmt"
+	"sort"
+
+	task "github.com/dingodb/dingoadm/internal/task/task/common"
+	tui "github.com/dingodb/dingoadm/internal/tui/common"
+	"github.com/fatih/color"
+)
+
+// SortDrifts sorts drifts by role and service id, the order of items in same service is kept
+func SortDrifts(drifts []task.Drift) {
+	sort.SliceStable(drifts, func(i, j int) bool {
+		d1, d2 := drifts[i], drifts[j]
+		if d1.Role != d2.Role {
+			return ROLE_SCORE[d1.Role] < ROLE_SCORE[d2.Role]
+		}
+		return d1.Id < d2.Id
+	})
+}
+
+/*
+ * Id            Role   Host      Item    Key                       Expected  Actual
+ * --            ----   ----      ----    ---                       --------  ------
+ * c0a0d6dbc8c5  store  server-1  config  gflags.conf:-server_port  20001     20002
+ *
+ * Summary: 1 drifts in 1 services
+ */
+func FormatDrifts(drifts []task.Drift) string {
+	lines := [][]interface{}{}
+	title := []string{"Id", "Role", "Host", "Item", "Key", "Expected", "Actual"}
+	first, second := tui.FormatTitle(title)
+	lines = append(lines, first)
+	lines = append(lines, second)
+
+	SortDrifts(drifts)
+	services := map[string]bool{}
+	for _, drift := range drifts {
+		services[drift.Id] = true
+		lines = append(lines, []interface{}{
+			drift.Id,
+			drift.Role,
+			drift.Host,
+			drift.Item,
+			drift.Key,
+			drift.Expected,
+			tui.DecorateMessage{Message: drift.Actual, Decorate: normalDecorate(false)},
+		})
+	}
+
+	output := tui.FixedFormat(lines, 2)
+	summary := fmt.Sprintf("%d drifts in %d services", len(drifts), len(services))
+	if len(drifts) == 0 {
+		summary = color.GreenString("no drift found")
+	}
+	output += fmt.Sprintf("\nSummary: %s\n", summary)
+	return output
+}
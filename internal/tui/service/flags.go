@@ -0,0 +1,80 @@
+/*
+ *  Copyright (c) 2025 dingodb.com Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: dingoadm
+ * Author: dongwei (jackblack369)
+ */
+package service
+
+import (
+	"fmt"
+	"sort"
+
+	task "github.com/dingodb/dingoadm/internal/task/task/common"
+	tui "github.com/dingodb/dingoadm/internal/tui/common"
+	"github.com/dingodb/dingoadm/internal/utils"
+)
+
+// SortFlagValues sorts values by role and service id, the order of flags in same service is kept
+func SortFlagValues(values []task.FlagValue) {
+	sort.SliceStable(values, func(i, j int) bool {
+		v1, v2 := values[i], values[j]
+		if v1.Role != v2.Role {
+			return ROLE_SCORE[v1.Role] < ROLE_SCORE[v2.Role]
+		}
+		return v1.Id < v2.Id
+	})
+}
+
+/*
+ * Id            Role   Host      Name       Value  Configured
+ * --            ----   ----      ----       -----  ----------
+ * c0a0d6dbc8c5  store  server-1  raft_sync  false  true
+ *
+ * Total: 1 values, 1 differ from configured
+ */
+func FormatFlagValues(values []task.FlagValue) string {
+	lines := [][]interface{}{}
+	title := []string{"Id", "Role", "Host", "Name", "Value", "Configured"}
+	first, second := tui.FormatTitle(title)
+	lines = append(lines, first)
+	lines = append(lines, second)
+
+	SortFlagValues(values)
+	differ := 0
+	for _, value := range values {
+		if value.Differ {
+			differ++
+		}
+		lines = append(lines, []interface{}{
+			value.Id,
+			value.Role,
+			value.Host,
+			value.Name,
+			tui.DecorateMessage{
+				Message:  utils.Choose(len(value.Value) == 0, "\"\"", value.Value),
+				Decorate: normalDecorate(!value.Differ),
+			},
+			utils.Choose(len(value.Configured) == 0, "-", value.Configured),
+		})
+	}
+
+	output := tui.FixedFormat(lines, 2)
+	output += fmt.Sprintf("\nTotal: %d values, %s\n", len(values),
+		normalDecorate(differ == 0)(fmt.Sprintf("%d differ from configured", differ)))
+	return output
+}
@@ -0,0 +1,102 @@
+/*
+ *  Copyright (c) 2025 dingodb.com Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: dingoadm
+ * Author: dongwei (jackblack369)
+ */
+
+package service
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	task "github.com/dingodb/dingoadm/internal/task/task/common"
+	tui "github.com/dingodb/dingoadm/internal/tui/common"
+	"github.com/fatih/color"
+)
+
+func healthDecorate(result string) func(string) string {
+	switch result {
+	case task.HEALTH_PASS:
+		return func(s string) string { return color.GreenString(s) }
+	case task.HEALTH_WARN:
+		return func(s string) string { return color.YellowString(s) }
+	}
+	return func(s string) string { return color.RedString(s) }
+}
+
+// SortHealthChecks sorts checks by role and service id, the order of items in same service is kept
+func SortHealthChecks(checks []task.HealthCheck) {
+	sort.SliceStable(checks, func(i, j int) bool {
+		c1, c2 := checks[i], checks[j]
+		if c1.Role != c2.Role {
+			return ROLE_SCORE[c1.Role] < ROLE_SCORE[c2.Role]
+		}
+		return c1.Id < c2.Id
+	})
+}
+
+/*
+ * Id            Role         Host      Item       Result  Message
+ * --            ----         ----      ----       ------  -------
+ * c0a0d6dbc8c5  coordinator  server-1  container  PASS    running
+ * c0a0d6dbc8c5  coordinator  server-1  leader     FAIL    no leader found
+ *
+ * Hints:
+ *   c0a0d6dbc8c5 leader: ensure the majority of coordinators are running
+ *
+ * Summary: 1 PASS, 0 WARN, 1 FAIL
+ */
+func FormatHealthChecks(checks []task.HealthCheck) string {
+	lines := [][]interface{}{}
+	title := []string{"Id", "Role", "Host", "Item", "Result", "Message"}
+	first, second := tui.FormatTitle(title)
+	lines = append(lines, first)
+	lines = append(lines, second)
+
+	SortHealthChecks(checks)
+	hints := []string{}
+	count := map[string]int{}
+	for _, check := range checks {
+		count[check.Result]++
+		lines = append(lines, []interface{}{
+			check.Id,
+			check.Role,
+			check.Host,
+			check.Item,
+			tui.DecorateMessage{Message: check.Result, Decorate: healthDecorate(check.Result)},
+			check.Message,
+		})
+		if check.Result != task.HEALTH_PASS && len(check.Hint) > 0 {
+			hints = append(hints, fmt.Sprintf("  %s %s: %s", check.Id, check.Item, check.Hint))
+		}
+	}
+
+	var sb strings.Builder
+	sb.WriteString(tui.FixedFormat(lines, 2))
+	if len(hints) > 0 {
+		sb.WriteString("\nHints:\n")
+		sb.WriteString(strings.Join(hints, "\n") + "\n")
+	}
+	sb.WriteString(fmt.Sprintf("\nSummary: %s, %s, %s\n",
+		healthDecorate(task.HEALTH_PASS)(fmt.Sprintf("%d PASS", count[task.HEALTH_PASS])),
+		healthDecorate(task.HEALTH_WARN)(fmt.Sprintf("%d WARN", count[task.HEALTH_WARN])),
+		healthDecorate(task.HEALTH_FAIL)(fmt.Sprintf("%d FAIL", count[task.HEALTH_FAIL]))))
+	return sb.String()
+}
@@ -0,0 +1,105 @@
+/*
+ *  Copyright (c) 2025 dingodb.com Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: dingoadm
+ * Author: dongwei (jackblack369)
+ */
+package service
+
+import (
+	"fmt"
+	"sort"
+
+	task "github.com/dingodb/dingoadm/internal/task/task/common"
+	tui "github.com/dingodb/dingoadm/internal/tui/common"
+	"github.com/fatih/color"
+)
+
+/*
+ * Time                 Id            Role   Host      Item     From     To
+ * ----                 --            ----   ----      ----     ----     --
+ * 2025-01-01 12:00:00  c0a0d6dbc8c5  store  server-1  state    running  exited
+ * 2025-01-01 12:05:00  c0a0d6dbc8c5  store  server-1  restart  0        1
+ *
+ * Id            Role   Host      State    Restarts  State Changes  Leader Changes  Flapping
+ * --            ----   ----      -----    --------  -------------  --------------  --------
+ * c0a0d6dbc8c5  store  server-1  running  1         2              0               Y
+ *
+ * Summary: 1 flapping services in 1 services
+ */
+func FormatStatusHistory(transitions []task.StatusTransition, summaries []task.FlapSummary) string {
+	lines := [][]interface{}{}
+	title := []string{"Time", "Id", "Role", "Host", "Item", "From", "To"}
+	first, second := tui.FormatTitle(title)
+	lines = append(lines, first)
+	lines = append(lines, second)
+	for _, transition := range transitions {
+		lines = append(lines, []interface{}{
+			transition.Time.Format("2006-01-02 15:04:05"),
+			transition.ServiceId,
+			transition.Role,
+			transition.Host,
+			transition.Item,
+			transition.From,
+			transition.To,
+		})
+	}
+	output := tui.FixedFormat(lines, 2)
+	if len(transitions) == 0 {
+		output += "no transition recorded\n"
+	}
+
+	sort.SliceStable(summaries, func(i, j int) bool {
+		s1, s2 := summaries[i], summaries[j]
+		if s1.Role != s2.Role {
+			return ROLE_SCORE[s1.Role] < ROLE_SCORE[s2.Role]
+		}
+		return s1.ServiceId < s2.ServiceId
+	})
+	lines = [][]interface{}{}
+	title = []string{"Id", "Role", "Host", "State", "Restarts", "State Changes", "Leader Changes", "Flapping"}
+	first, second = tui.FormatTitle(title)
+	lines = append(lines, first)
+	lines = append(lines, second)
+	flapping := 0
+	for _, summary := range summaries {
+		if summary.Flapping {
+			flapping++
+		}
+		lines = append(lines, []interface{}{
+			summary.ServiceId,
+			summary.Role,
+			summary.Host,
+			summary.State,
+			summary.Restarts,
+			summary.StateChanges,
+			summary.LeaderChanges,
+			tui.DecorateMessage{
+				Message:  map[bool]string{true: "Y", false: "N"}[summary.Flapping],
+				Decorate: normalDecorate(!summary.Flapping),
+			},
+		})
+	}
+	output += "\n" + tui.FixedFormat(lines, 2)
+
+	summary := fmt.Sprintf("%d flapping services in %d services", flapping, len(summaries))
+	if flapping == 0 {
+		summary = color.GreenString("no flapping service found")
+	}
+	output += fmt.Sprintf("\nSummary: %s\n", summary)
+	return output
+}
@@ -0,0 +1,120 @@
+/*
+ *  Copyright (c) 2025 dingodb.com Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: dingoadm
+ * Author: dongwei (jackblack369)
+ */
+
+package service
+
+import (
+	"fmt"
+	"strings"
+
+	task "github.com/dingodb/dingoadm/internal/task/task/common"
+	tui "github.com/dingodb/dingoadm/internal/tui/common"
+	"github.com/dingodb/dingoadm/internal/utils"
+	"github.com/fatih/color"
+)
+
+func normalDecorate(normal bool) func(string) string {
+	if normal {
+		return func(s string) string { return s }
+	}
+	return func(s string) string { return color.RedString(s) }
+}
+
+func FormatRegions(regions []task.Region) string {
+	lines := [][]interface{}{}
+	title := []string{"Region Id", "Name", "State", "Leader", "Peers", "Unhealthy"}
+	first, second := tui.FormatTitle(title)
+	lines = append(lines, first)
+	lines = append(lines, second)
+
+	unhealthy := 0
+	for _, region := range regions {
+		healthy := len(region.Unhealthy) == 0
+		if !healthy {
+			unhealthy++
+		}
+		lines = append(lines, []interface{}{
+			region.Id,
+			utils.Choose(len(region.Name) == 0, "-", region.Name),
+			region.State,
+			utils.Choose(len(region.LeaderStoreId) == 0, "-", region.LeaderStoreId),
+			strings.Join(region.StoreIds, ","),
+			tui.DecorateMessage{
+				Message:  utils.Choose(healthy, "-", region.Unhealthy),
+				Decorate: normalDecorate(healthy),
+			},
+		})
+	}
+
+	output := tui.FixedFormat(lines, 2)
+	output += fmt.Sprintf("\nTotal: %d regions, %s\n", len(regions),
+		normalDecorate(unhealthy == 0)(fmt.Sprintf("%d unhealthy", unhealthy)))
+	return output
+}
+
+func FormatStores(stores []task.Store) string {
+	lines := [][]interface{}{}
+	title := []string{"Store Id", "Type", "Address", "State", "In State", "Regions", "Leaders"}
+	first, second := tui.FormatTitle(title)
+	lines = append(lines, first)
+	lines = append(lines, second)
+
+	for _, store := range stores {
+		lines = append(lines, []interface{}{
+			store.Id,
+			utils.Choose(len(store.Type) == 0, "-", store.Type),
+			utils.Choose(len(store.Address) == 0, "-", store.Address),
+			tui.DecorateMessage{
+				Message:  store.State,
+				Decorate: normalDecorate(strings.HasSuffix(store.State, "NORMAL")),
+			},
+			utils.Choose(len(store.InState) == 0, "-", store.InState),
+			store.Regions,
+			store.Leaders,
+		})
+	}
+
+	return tui.FixedFormat(lines, 2)
+}
+
+func FormatRaftNodes(nodes []task.RaftNode) string {
+	lines := [][]interface{}{}
+	title := []string{"Store Id", "Host", "Group", "State", "Term", "Peers"}
+	first, second := tui.FormatTitle(title)
+	lines = append(lines, first)
+	lines = append(lines, second)
+
+	for _, node := range nodes {
+		lines = append(lines, []interface{}{
+			node.StoreId,
+			node.Host,
+			node.Group,
+			tui.DecorateMessage{
+				Message:  node.State,
+				Decorate: normalDecorate(node.State == "LEADER" || node.State == "FOLLOWER"),
+			},
+			utils.Choose(len(node.Term) == 0, "-", node.Term),
+			len(node.Peers),
+		})
+	}
+
+	return tui.FixedFormat(lines, 2)
+}
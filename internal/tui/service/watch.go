@@ -0,0 +1,185 @@
+/*
+ *  Copyright (c) 2025 dingodb.com Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: dingoadm
+ * Author: dongwei (jackblack369)
+ */
+
+package service
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	task "github.com/dingodb/dingoadm/internal/task/task/common"
+	tui "github.com/dingodb/dingoadm/internal/tui/common"
+	"github.com/dingodb/dingoadm/internal/utils"
+	"github.com/fatih/color"
+)
+
+const (
+	WATCH_MAX_EVENTS = 10
+
+	EVENT_SERVICE_UP   = "up"
+	EVENT_SERVICE_DOWN = "down"
+	EVENT_LEADER       = "leader"
+	EVENT_ERROR        = "error"
+
+	SERVICE_STATE_UP = "Up"
+)
+
+type WatchEvent struct {
+	Time    time.Time
+	Kind    string
+	Id      string
+	Role    string
+	Host    string
+	Message string
+}
+
+// state of service without uptime, e.g. "Up 2 hours" -> "Up", "Exited (1) 3 seconds ago" -> "Exited"
+func serviceState(status string) string {
+	fields := strings.Fields(status)
+	if len(fields) == 0 {
+		return status
+	}
+	return fields[0]
+}
+
+// DiffServiceStatus returns the transitions between two refreshes,
+// the service which not exist in last refresh is ignored
+func DiffServiceStatus(old, new []task.ServiceStatus, now time.Time) []WatchEvent {
+	m := map[string]task.ServiceStatus{}
+	for _, status := range old {
+		m[status.Id] = status
+	}
+
+	events := []WatchEvent{}
+	for _, status := range new {
+		last, ok := m[status.Id]
+		if !ok {
+			continue
+		}
+
+		event := WatchEvent{Time: now, Id: status.Id, Role: status.Role, Host: status.Host}
+		from, to := serviceState(last.Status), serviceState(status.Status)
+		if from != to {
+			event.Kind = utils.Choose(to == SERVICE_STATE_UP, EVENT_SERVICE_UP, EVENT_SERVICE_DOWN)
+			event.Message = fmt.Sprintf("%s -> %s", from, to)
+			events = append(events, event)
+		}
+		if last.IsLeader != status.IsLeader {
+			event.Kind = EVENT_LEADER
+			event.Message = utils.Choose(status.IsLeader, "became leader", "lost leader")
+			events = append(events, event)
+		}
+	}
+
+	sort.SliceStable(events, func(i, j int) bool {
+		return events[i].Id < events[j].Id
+	})
+	return events
+}
+
+func eventDecorate(kind string) func(string) string {
+	switch kind {
+	case EVENT_SERVICE_UP:
+		return func(s string) string { return color.GreenString(s) }
+	case EVENT_SERVICE_DOWN, EVENT_ERROR:
+		return func(s string) string { return color.RedString(s) }
+	case EVENT_LEADER:
+		return func(s string) string { return color.YellowString(s) }
+	}
+	return func(s string) string { return s }
+}
+
+func formatEvent(event WatchEvent) string {
+	message := eventDecorate(event.Kind)(event.Message)
+	if len(event.Id) == 0 {
+		return fmt.Sprintf("%s  %s", event.Time.Format("15:04:05"), message)
+	}
+	return fmt.Sprintf("%s  %s  %s  %s  %s", event.Time.Format("15:04:05"),
+		event.Id, event.Role, event.Host, message)
+}
+
+/*
+ * render the whole screen, the rows which changed in last refresh
+ * are marked and decorated by its transition:
+ *
+ * Every 5s: dingoadm status                                  2025-01-01 10:00:00
+ *
+ *    Id            Role         Host      Container Id  Status          Leader  Ports
+ *    --            ----         ----      ------------  ------          ------  -----
+ *    c0a0d6dbc8c5  coordinator  server-1  e52e0c7c1c32  Up 2 hours      -       22001
+ * *  9a86de5e4b4c  store        server-1  0cb77ea89ddc  Exited (1) ...  -       20001
+ *
+ * Events:
+ *   10:00:00  9a86de5e4b4c  store  server-1  Up -> Exited
+ */
+func FormatWatchStatus(header string,
+	statuses []task.ServiceStatus,
+	changed []WatchEvent,
+	events []WatchEvent) string {
+	kinds := map[string]string{}
+	for _, event := range changed {
+		if _, ok := kinds[event.Id]; !ok || event.Kind != EVENT_LEADER {
+			kinds[event.Id] = event.Kind
+		}
+	}
+
+	lines := [][]interface{}{}
+	title := []string{" ", "Id", "Role", "Host", "Container Id", "Status", "Leader", "Ports"}
+	first, second := tui.FormatTitle(title)
+	second[0] = ""
+	lines = append(lines, first)
+	lines = append(lines, second)
+
+	sortStatues(statuses)
+	for _, status := range statuses {
+		mark := " "
+		decorate := statusDecorate
+		kind, ok := kinds[status.Id]
+		if ok {
+			mark = "*"
+			decorate = eventDecorate(kind)
+		}
+		lines = append(lines, []interface{}{
+			tui.DecorateMessage{Message: mark, Decorate: eventDecorate(kind)},
+			status.Id,
+			status.Role,
+			status.Host,
+			status.ContainerId,
+			tui.DecorateMessage{Message: status.Status, Decorate: decorate},
+			utils.Choose(status.IsLeader, "Y", "-"),
+			utils.Choose(len(status.Ports) == 0, "-", status.Ports),
+		})
+	}
+
+	var sb strings.Builder
+	sb.WriteString(header + "\n\n")
+	sb.WriteString(tui.FixedFormat(lines, 2))
+	sb.WriteString("\nEvents:\n")
+	if len(events) > WATCH_MAX_EVENTS {
+		events = events[len(events)-WATCH_MAX_EVENTS:]
+	}
+	for _, event := range events {
+		sb.WriteString("  " + formatEvent(event) + "\n")
+	}
+	return sb.String()
+}
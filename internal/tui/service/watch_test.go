@@ -0,0 +1,34 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	task "github.com/dingodb/dingoadm/internal/task/task/common"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiffServiceStatus(t *testing.T) {
+	assert := assert.New(t)
+	now := time.Now()
+	old := []task.ServiceStatus{
+		{Id: "s1", Status: "Up 2 hours"},
+		{Id: "s2", Status: "Up 2 hours", IsLeader: true},
+		{Id: "s3", Status: "Exited (1) 3 seconds ago"},
+	}
+	new := []task.ServiceStatus{
+		{Id: "s1", Status: "Up 3 hours"}, // uptime changed only
+		{Id: "s2", Status: "Exited (137) 1 second ago"},
+		{Id: "s3", Status: "Up 1 second", IsLeader: true},
+		{Id: "s4", Status: "Up 1 second"}, // not exist in last refresh
+	}
+
+	events := DiffServiceStatus(old, new, now)
+	assert.Len(events, 4)
+	assert.Equal(WatchEvent{Time: now, Kind: EVENT_SERVICE_DOWN, Id: "s2", Message: "Up -> Exited"}, events[0])
+	assert.Equal(WatchEvent{Time: now, Kind: EVENT_LEADER, Id: "s2", Message: "lost leader"}, events[1])
+	assert.Equal(WatchEvent{Time: now, Kind: EVENT_SERVICE_UP, Id: "s3", Message: "Exited -> Up"}, events[2])
+	assert.Equal(WatchEvent{Time: now, Kind: EVENT_LEADER, Id: "s3", Message: "became leader"}, events[3])
+
+	assert.Len(DiffServiceStatus(nil, new, now), 0)
+}
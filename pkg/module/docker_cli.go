@@ -29,7 +29,9 @@
 package module
 
 import (
+	"context"
 	"fmt"
+	"io"
 	"strings"
 	"text/template"
 )
@@ -80,6 +82,13 @@ func (cli *DockerCli) Execute(options ExecOptions) (string, error) {
 	return execCommand(cli.sshClient, cli.tmpl, cli.data, options)
 }
 
+// Stream executes command and writes its output into w until it exits or ctx is canceled
+func (cli *DockerCli) Stream(ctx context.Context, options ExecOptions, w io.Writer) error {
+	cli.data["options"] = strings.Join(cli.options, " ")
+	cli.data["engine"] = options.ExecWithEngine
+	return streamCommand(ctx, cli.sshClient, cli.tmpl, cli.data, options, w)
+}
+
 func (cli *DockerCli) DockerInfo() *DockerCli {
 	cli.tmpl = template.Must(template.New("DockerInfo").Parse(TEMPLATE_DOCKER_INFO))
 	return cli
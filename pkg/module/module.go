@@ -32,6 +32,7 @@ import (
 	"bytes"
 	"context"
 	"fmt"
+	"io"
 	"os/exec"
 	"strings"
 	"text/template"
@@ -90,7 +91,7 @@ func remoteAddr(client *SSHClient) string {
 	return fmt.Sprintf("%s@%s:%d", config.User, config.Host, config.Port)
 }
 
-func execCommand(sshClient *SSHClient,
+func renderCommand(sshClient *SSHClient,
 	tmpl *template.Template,
 	data map[string]interface{},
 	options ExecOptions) (string, error) {
@@ -121,6 +122,17 @@ func execCommand(sshClient *SSHClient,
 			command = strings.Join([]string{become, command}, " ")
 		}
 	}
+	return command, nil
+}
+
+func execCommand(sshClient *SSHClient,
+	tmpl *template.Template,
+	data map[string]interface{},
+	options ExecOptions) (string, error) {
+	command, err := renderCommand(sshClient, tmpl, data, options)
+	if err != nil {
+		return "", err
+	}
 
 	// (4) create context for timeout
 	ctx := context.Background()
@@ -132,7 +144,6 @@ func execCommand(sshClient *SSHClient,
 
 	// (5) execute command
 	var out []byte
-	var err error
 	if options.ExecInLocal {
 		cmd := exec.CommandContext(ctx, "bash", "-c", command)
 		cmd.Env = []string{"LANG=en_US.UTF-8"}
@@ -156,3 +167,47 @@ func execCommand(sshClient *SSHClient,
 		log.Field("error", err))
 	return string(out), err
 }
+
+/*
+ * streamCommand executes command and copies its output into w as it comes,
+ * until the command exits or ctx is canceled, e.g. 'docker logs -f'.
+ * the stdout and stderr may be written concurrently, w should be goroutine safe.
+ */
+func streamCommand(ctx context.Context,
+	sshClient *SSHClient,
+	tmpl *template.Template,
+	data map[string]interface{},
+	options ExecOptions,
+	w io.Writer) error {
+	command, err := renderCommand(sshClient, tmpl, data, options)
+	if err != nil {
+		return err
+	}
+
+	if options.ExecInLocal {
+		cmd := exec.CommandContext(ctx, "bash", "-c", command)
+		cmd.Env = []string{"LANG=en_US.UTF-8"}
+		cmd.Stdout = w
+		cmd.Stderr = w
+		err = cmd.Run()
+	} else {
+		var cmd *goph.Cmd
+		cmd, err = sshClient.Client().CommandContext(ctx, command)
+		if err == nil {
+			cmd.Stdout = w
+			cmd.Stderr = w
+			err = cmd.Run()
+			cmd.Session.Close()
+		}
+	}
+
+	if ctx.Err() == context.Canceled { // canceled by caller
+		err = nil
+	}
+
+	log.SwitchLevel(err)("Stream command",
+		log.Field("remoteAddr", remoteAddr(sshClient)),
+		log.Field("command", command),
+		log.Field("error", err))
+	return err
+}
@@ -30,7 +30,9 @@ package module
 
 import (
 	"bytes"
+	"context"
 	"fmt"
+	"io"
 	"strings"
 	"text/template"
 )
@@ -119,6 +121,12 @@ func (s *Shell) Execute(options ExecOptions) (string, error) {
 	return execCommand(s.sshClient, s.tmpl, s.data, options)
 }
 
+// Stream executes command and writes its output into w until it exits or ctx is canceled
+func (s *Shell) Stream(ctx context.Context, options ExecOptions, w io.Writer) error {
+	s.data["options"] = strings.Join(s.options, " ")
+	return streamCommand(ctx, s.sshClient, s.tmpl, s.data, options, w)
+}
+
 // text
 func (s *Shell) Sed(file ...string) *Shell {
 	s.tmpl = template.Must(template.New("sed").Parse(TEMPLATE_SED))
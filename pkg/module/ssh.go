@@ -30,7 +30,9 @@ package module
 
 import (
 	"errors"
+	"fmt"
 	"net"
+	"sync"
 	"time"
 
 	log "github.com/dingodb/dingoadm/pkg/log/glg"
@@ -155,3 +157,70 @@ connect:
 		config: config,
 	}, err
 }
+
+type (
+	pooledSSHClient struct {
+		sync.Mutex
+		client *SSHClient
+	}
+
+	// SSHClientPool keeps ssh clients open for reusing, it's useful
+	// for the command which executes tasks repeatedly, e.g. status --watch
+	SSHClientPool struct {
+		sync.Mutex
+		clients map[string]*pooledSSHClient
+	}
+)
+
+func NewSSHClientPool() *SSHClientPool {
+	return &SSHClientPool{clients: map[string]*pooledSSHClient{}}
+}
+
+func (client *SSHClient) isAlive() bool {
+	_, _, err := client.client.SendRequest("keepalive@openssh.com", true, nil)
+	return err == nil
+}
+
+func (pool *SSHClientPool) get(key string) *pooledSSHClient {
+	pool.Lock()
+	defer pool.Unlock()
+	c, ok := pool.clients[key]
+	if !ok {
+		c = &pooledSSHClient{}
+		pool.clients[key] = c
+	}
+	return c
+}
+
+// Get returns the opened client for config, a new client will be
+// created if there is no one or the opened one is broken
+func (pool *SSHClientPool) Get(config SSHConfig) (*SSHClient, error) {
+	key := fmt.Sprintf("%s@%s:%d/%s", config.User, config.Host, config.Port, config.BecomeUser)
+	c := pool.get(key)
+	c.Lock()
+	defer c.Unlock()
+	if c.client != nil && c.client.isAlive() {
+		return c.client, nil
+	} else if c.client != nil {
+		c.client.client.Close()
+		c.client = nil
+	}
+
+	client, err := NewSSHClient(config)
+	if err != nil {
+		return nil, err
+	}
+	c.client = client
+	return client, nil
+}
+
+func (pool *SSHClientPool) Close() {
+	pool.Lock()
+	defer pool.Unlock()
+	for key, c := range pool.clients {
+		if c.client != nil {
+			c.client.client.Close()
+		}
+		delete(pool.clients, key)
+	}
+}
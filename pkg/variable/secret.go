@@ -0,0 +1,69 @@
+/*
+ *  Copyright (c) 2025 dingodb.com Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: dingoadm
+ * Author: dongwei (jackblack369)
+ */
+
+package variable
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+const (
+	SECRET_PREFIX_ENV  = "env:"  // ${env:NAME}
+	SECRET_PREFIX_FILE = "file:" // ${file:/path/to/secret}
+)
+
+// secrets are resolved on the control machine while rendering,
+// the resolved value never be written back into variables
+func IsSecret(name string) bool {
+	return strings.HasPrefix(name, SECRET_PREFIX_ENV) ||
+		strings.HasPrefix(name, SECRET_PREFIX_FILE)
+}
+
+func resolveSecret(name string) (string, error) {
+	switch {
+	case strings.HasPrefix(name, SECRET_PREFIX_ENV):
+		key := strings.TrimPrefix(name, SECRET_PREFIX_ENV)
+		if len(key) == 0 {
+			return "", fmt.Errorf("secret '${%s}' requires environment variable name", name)
+		}
+		value, ok := os.LookupEnv(key)
+		if !ok {
+			return "", fmt.Errorf("secret '${%s}' not found: environment variable '%s' not set", name, key)
+		}
+		return value, nil
+
+	case strings.HasPrefix(name, SECRET_PREFIX_FILE):
+		path := strings.TrimPrefix(name, SECRET_PREFIX_FILE)
+		if !filepath.IsAbs(path) {
+			return "", fmt.Errorf("secret '${%s}' requires an absolute file path", name)
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return "", fmt.Errorf("secret '${%s}' not found: %v", name, err)
+		}
+		return strings.TrimRight(string(data), "\r\n"), nil
+	}
+
+	return "", fmt.Errorf("variable '%s' is not a secret", name)
+}
@@ -0,0 +1,62 @@
+package variable
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestVariables(t *testing.T, kv map[string]string) *Variables {
+	vars := NewVariables()
+	for k, v := range kv {
+		assert.Nil(t, vars.Register(Variable{Name: k, Value: v}))
+	}
+	assert.Nil(t, vars.Build())
+	return vars
+}
+
+func TestRenderingEnvSecret(t *testing.T) {
+	assert := assert.New(t)
+	t.Setenv("DINGOADM_TEST_SECRET", "s3cr3t")
+
+	vars := newTestVariables(t, map[string]string{
+		"user":     "admin",
+		"password": "${env:DINGOADM_TEST_SECRET}",
+	})
+	value, err := vars.Rendering("${user}:${password}")
+	assert.Nil(err)
+	assert.Equal("admin:s3cr3t", value)
+
+	// resolved secret never be stored in variables
+	password, err := vars.Get("password")
+	assert.Nil(err)
+	assert.Equal("${env:DINGOADM_TEST_SECRET}", password)
+}
+
+func TestRenderingFileSecret(t *testing.T) {
+	assert := assert.New(t)
+	path := filepath.Join(t.TempDir(), "secret")
+	assert.Nil(os.WriteFile(path, []byte("from-file\n"), 0600))
+
+	vars := newTestVariables(t, map[string]string{})
+	value, err := vars.Rendering("key=${file:" + path + "}")
+	assert.Nil(err)
+	assert.Equal("key=from-file", value)
+}
+
+func TestRenderingMissingSecret(t *testing.T) {
+	assert := assert.New(t)
+	os.Unsetenv("DINGOADM_TEST_MISSING")
+
+	vars := newTestVariables(t, map[string]string{})
+	_, err := vars.Rendering("${env:DINGOADM_TEST_MISSING}")
+	assert.ErrorContains(err, "DINGOADM_TEST_MISSING")
+
+	_, err = vars.Rendering("${file:/not/exist/secret}")
+	assert.ErrorContains(err, "/not/exist/secret")
+
+	_, err = vars.Rendering("${file:relative/secret}")
+	assert.ErrorContains(err, "absolute")
+}
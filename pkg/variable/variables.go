@@ -107,13 +107,18 @@ func (vars *Variables) resolve(name string, marked map[string]bool) (string, err
 	// resolve all sub-variable
 	for _, mu := range matches {
 		name = mu[1]
-		if _, err := vars.resolve(name, marked); err != nil {
+		if IsSecret(name) { // secret will be resolved while rendering
+			continue
+		} else if _, err := vars.resolve(name, marked); err != nil {
 			return "", err
 		}
 	}
 
 	// ${var}
 	v.Value = vars.r.ReplaceAllStringFunc(v.Value, func(name string) string {
+		if IsSecret(name[2 : len(name)-1]) {
+			return name
+		}
 		return vars.m[name[2:len(name)-1]].Value
 	})
 	v.Resolved = true
@@ -143,14 +148,33 @@ func (vars *Variables) Rendering(s string) (string, error) {
 		return s, nil
 	}
 
+	// (1) rendering variables, the value of variable may contains secret
 	var err error
 	value := vars.r.ReplaceAllStringFunc(s, func(name string) string {
+		if IsSecret(name[2 : len(name)-1]) {
+			return name
+		}
 		val, e := vars.Get(name[2 : len(name)-1])
 		if e != nil && err == nil {
 			err = e
 		}
 		return val
 	})
+	if err != nil {
+		return value, err
+	}
+
+	// (2) rendering secrets: ${env:NAME}, ${file:/path/to/secret}
+	value = vars.r.ReplaceAllStringFunc(value, func(name string) string {
+		if !IsSecret(name[2 : len(name)-1]) {
+			return name
+		}
+		val, e := resolveSecret(name[2 : len(name)-1])
+		if e != nil && err == nil {
+			err = e
+		}
+		return val
+	})
 	return value, err
 }
 